@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// recordActivity stamps ev with the current time, appends it to the persistent Activity feed and
+// emits it as devkit:activity. Level defaults to "info" for successes and "error" for failures.
+func (a *App) recordActivity(ev model.ActivityEvent) {
+	ev.Time = time.Now().Format(time.RFC3339)
+	if ev.Level == "" {
+		ev.Level = "info"
+		if !ev.Success {
+			ev.Level = "error"
+		}
+	}
+	_ = a.activity.Append(ev)
+	if a.ctx != nil {
+		a.emitEvent("devkit:activity", ev)
+	}
+}
+
+// GetActivity returns recorded Activity events matching query (type, target, level, time range and
+// free-text search), newest first, with the total match count for pagination. Events persist across restarts.
+func (a *App) GetActivity(query model.ActivityQuery) (*model.ActivityPage, error) {
+	page, err := a.activity.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity: %w", err)
+	}
+	return page, nil
+}
+
+// ClearActivity deletes every recorded Activity event
+func (a *App) ClearActivity() error {
+	if err := a.activity.Clear(); err != nil {
+		return fmt.Errorf("failed to clear activity: %w", err)
+	}
+	return nil
+}
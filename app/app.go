@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,10 +11,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	goruntime "runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/wabisaby/devkit-dashboard/internal/activity"
+	"github.com/wabisaby/devkit-dashboard/internal/ansi"
 	"github.com/wabisaby/devkit-dashboard/internal/config"
 	"github.com/wabisaby/devkit-dashboard/internal/git"
 	"github.com/wabisaby/devkit-dashboard/internal/model"
@@ -21,6 +26,7 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/menu/keys"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"golang.org/x/sync/errgroup"
 )
 
 // App struct holds the application state and dependencies
@@ -28,38 +34,142 @@ type App struct {
 	ctx              context.Context
 	devkitRoot       string
 	projectsDir      string
+	appDataDir       string
 	wabisabyCorePath string
 	processManager   *service.ProcessManager
 	migrationSvc     *service.MigrationService
 	envSvc           *service.EnvService
 	protoSvc         *service.ProtoService
 	githubSvc        *service.GitHubService
+	activity         *activity.Store       // persistent Activity feed
+	crashWebhook     *service.CrashWebhook // nil unless WABISABY_CRASH_WEBHOOK_URL is set
 	startedAt        time.Time
 
-	// Stream cancellation
+	// Serializes settings.json read-modify-write cycles (and applying the result), so concurrent
+	// setting changes don't overwrite each other
+	settingsMu sync.Mutex
+
+	// Background backend status polling (devkit:backend:status)
+	backendPollInterval time.Duration
+	stopBackendPoll     context.CancelFunc
+
+	// Stream cancellation, and the recent events of each stream id for ResumeStream
 	streamMu      sync.Mutex
-	activeStreams map[string]context.CancelFunc
+	activeStreams map[string]*activeStream
+	streamReplay  map[string]*replayBuffer
+	streamRuns    uint64 // last stream run id handed out (replayBuffer.run)
+
+	// Most recent failed stream operation, for RerunLastFailed
+	opMu         sync.Mutex
+	lastFailedOp *operation
+
+	bulkParallelism int                 // StartBulkProjectStream worker count
+	keepANSI        atomic.Bool         // Leave ANSI codes in streamed output (settings KeepANSI)
+	tagPrefixPolicy git.TagPrefixPolicy // "v" prefix rule for version tags (SuggestTagName, SuggestNextTag)
+	artifactDirs    []string            // Project-relative build output dirs (ProjectArtifacts, CleanProjectArtifacts)
+	cloneDepth      int                 // History depth for ProjectClone; 0 = full history
+	httpClient      *http.Client        // Outbound HTTP with the configured timeout (health checks, GitHub, webhook)
+
+	// Sends events to the frontend; runtime.EventsEmit except in tests
+	eventsEmit func(ctx context.Context, eventName string, optionalData ...interface{})
+
+	// In-flight GitHubPollAuth, for CancelDeviceFlow
+	githubPollMu     sync.Mutex
+	githubPollCancel context.CancelFunc
+	githubPollSeq    uint64
 }
 
 // NewApp creates a new App instance
 func NewApp(cfg *config.Config) *App {
+	httpClient := service.NewHTTPClient(cfg.HTTPTimeout)
 	processManager := service.NewProcessManager(cfg.WabisabyCorePath, cfg.ProjectsDir, cfg.DevKitRoot)
+	processManager.SetHTTPClient(httpClient)
+	processManager.SetBuildMode(cfg.BackendBuildMode)
+	processManager.SetStopTimeouts(cfg.StopTimeout, cfg.StopAllTimeout)
 	migrationSvc := service.NewMigrationService(cfg.WabisabyCorePath)
 	envSvc := service.NewEnvService(cfg.WabisabyCorePath)
-	protoSvc := service.NewProtoService(cfg.ProjectsDir)
-	githubSvc := service.NewGitHubService(cfg.GitHubClientID, cfg.GitHubOrg, cfg.AppDataDir)
+	protoSvc := service.NewProtoService(cfg.ProjectsDir, cfg.ProtoTargets)
+	githubSvc := service.NewGitHubService(cfg.GitHubClientID, cfg.GitHubOrgs, cfg.AppDataDir)
+	githubSvc.SetHTTPClient(httpClient)
+	githubSvc.SetScopes(cfg.GitHubScopes)
+	githubSvc.SetTeamsTTL(cfg.GitHubTeamsTTL)
 
-	return &App{
+	crashWebhook := service.NewCrashWebhook(cfg.CrashWebhookURL, cfg.CrashWebhookFormat)
+	crashWebhook.SetHTTPClient(httpClient)
+
+	app := &App{
 		devkitRoot:       cfg.DevKitRoot,
 		projectsDir:      cfg.ProjectsDir,
+		appDataDir:       cfg.AppDataDir,
 		wabisabyCorePath: cfg.WabisabyCorePath,
 		processManager:   processManager,
 		migrationSvc:     migrationSvc,
 		envSvc:           envSvc,
 		protoSvc:         protoSvc,
 		githubSvc:        githubSvc,
-		activeStreams:    make(map[string]context.CancelFunc),
+		activity:         activity.NewStore(cfg.AppDataDir, activity.DefaultMaxEvents),
+		crashWebhook:     crashWebhook,
+		activeStreams:    make(map[string]*activeStream),
+		streamReplay:     make(map[string]*replayBuffer),
+
+		backendPollInterval: cfg.BackendPollInterval,
+		bulkParallelism:     cfg.BulkParallelism,
+		tagPrefixPolicy:     git.ParseTagPrefixPolicy(cfg.TagPrefix),
+		artifactDirs:        cfg.ArtifactDirs,
+		cloneDepth:          cfg.CloneDepth,
+		httpClient:          httpClient,
+		eventsEmit:          runtime.EventsEmit,
+	}
+	app.keepANSI.Store(cfg.KeepANSI)
+	migrationSvc.SetAutoStart(cfg.AutoStartDB)
+	migrationSvc.SetDatabaseStarter(func() error {
+		_, err := app.StartDatabase()
+		return err
+	})
+	return app
+}
+
+// emitEvent sends an event to the frontend
+func (a *App) emitEvent(eventName string, optionalData ...interface{}) {
+	a.eventsEmit(a.ctx, eventName, optionalData...)
+}
+
+// outputLine prepares a line of command output for the frontend: ANSI escape codes are stripped
+// unless the KeepANSI setting is on
+func (a *App) outputLine(line string) string {
+	if a.keepANSI.Load() {
+		return line
+	}
+	return ansi.Strip(line)
+}
+
+// KeepANSI reports whether streamed output keeps its ANSI escape codes
+func (a *App) KeepANSI() bool {
+	return a.keepANSI.Load()
+}
+
+// SetKeepANSI chooses between raw (keep=true, for frontends that render colors) and plain streamed
+// output, and saves the choice in settings
+func (a *App) SetKeepANSI(keep bool) error {
+	a.settingsMu.Lock()
+	defer a.settingsMu.Unlock()
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+	settings.KeepANSI = keep
+	if err := config.SaveSettings(a.appDataDir, settings); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
 	}
+	a.keepANSI.Store(keep)
+	return nil
+}
+
+// requireCommand returns a permission-denied error unless the connected GitHub user's teams grant
+// the command group (service.Command*). Guards bindings that change infrastructure, backend services,
+// the database schema or generated code, so team permissions aren't only enforced by the UI.
+func (a *App) requireCommand(group string) error {
+	return a.githubSvc.RequireCommand(group)
 }
 
 // Startup is called when the app starts
@@ -78,14 +188,26 @@ func (a *App) Startup(ctx context.Context) {
 			"error":      errStr,
 			"lastOutput": lastOutput,
 		}
-		runtime.EventsEmit(a.ctx, "devkit:backend:exited", payload)
+		a.emitEvent("devkit:backend:exited", payload)
+		if err != nil {
+			a.crashWebhook.Notify(serviceName, errStr, lastOutput)
+			a.recordActivity(model.ActivityEvent{Type: "backend", Target: serviceName, Success: false, Message: "Exited with error: " + errStr})
+		} else {
+			a.recordActivity(model.ActivityEvent{Type: "backend", Target: serviceName, Success: true, Message: "Stopped"})
+		}
 	})
 	a.processManager.SetOnActivityLine(func(serviceName string, line string) {
-		runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+		a.emitEvent("devkit:backend:logs", map[string]interface{}{
 			"name": serviceName,
 			"line": line,
 		})
 	})
+	// Adopt services still running from a previous session so they can be stopped/restarted
+	go a.processManager.Reconcile()
+
+	pollCtx, stopPoll := context.WithCancel(ctx)
+	a.stopBackendPoll = stopPoll
+	a.startBackendStatusPoller(pollCtx, a.backendPollInterval, a.ListBackendServices)
 
 	// Application menu: View > Toggle Sidebar (Cmd+B / Ctrl+B) so the shortcut works on macOS
 	appMenu := menu.NewMenu()
@@ -94,7 +216,7 @@ func (a *App) Startup(ctx context.Context) {
 	}
 	viewMenu := appMenu.AddSubmenu("View")
 	viewMenu.AddText("Toggle Sidebar", keys.CmdOrCtrl("b"), func(_ *menu.CallbackData) {
-		runtime.EventsEmit(a.ctx, "devkit:toggle-sidebar", nil)
+		a.emitEvent("devkit:toggle-sidebar", nil)
 	})
 	if goruntime.GOOS == "darwin" {
 		appMenu.Append(menu.EditMenu())
@@ -104,13 +226,12 @@ func (a *App) Startup(ctx context.Context) {
 
 // Shutdown is called when the app is closing
 func (a *App) Shutdown(ctx context.Context) {
-	// Cancel all active streams
-	a.streamMu.Lock()
-	for _, cancel := range a.activeStreams {
-		cancel()
+	if a.stopBackendPoll != nil {
+		a.stopBackendPoll()
 	}
-	a.activeStreams = make(map[string]context.CancelFunc)
-	a.streamMu.Unlock()
+
+	// Cancel all active streams
+	a.stopAllStreams()
 
 	// Stop all backend processes
 	a.processManager.StopAll()
@@ -197,7 +318,7 @@ func (a *App) Status() map[string]interface{} {
 		info["dockerRunning"] = running
 	}
 
-	if envStatus, err := a.envSvc.GetStatus(); err == nil && envStatus != nil {
+	if envStatus, err := a.envSvc.GetStatus(false); err == nil && envStatus != nil {
 		missingRequired := 0
 		for _, v := range envStatus.RequiredVars {
 			if !v.IsSet {
@@ -234,8 +355,39 @@ func (a *App) SubmoduleSyncStatus() (map[string]interface{}, error) {
 	return map[string]interface{}{"needsSync": needsSync}, nil
 }
 
-// SubmoduleSync stages and commits submodule ref changes in DevKit
-func (a *App) SubmoduleSync(message string) (map[string]string, error) {
+// SubmoduleDrift returns recorded vs current commit details for projects that need sync
+func (a *App) SubmoduleDrift() ([]git.SubmoduleDriftInfo, error) {
+	projects, err := service.GetProjects(a.projectsDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return git.SubmoduleDrift(a.devkitRoot, a.projectsDir, names)
+}
+
+// SubmoduleSyncPreview shows the commits SubmoduleSync would record: per project, the recorded and new
+// commit with subjects and how many commits that moves
+func (a *App) SubmoduleSyncPreview() ([]model.SubmoduleChange, error) {
+	projects, err := service.GetProjects(a.projectsDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return git.SubmoduleSyncPreview(a.devkitRoot, a.projectsDir, names)
+}
+
+// SubmoduleSync stages and commits submodule ref changes in DevKit. With push set the DevKit branch
+// is then pushed to its upstream whenever it is ahead, even with nothing new to commit; push output is
+// emitted line by line. The result's status is "synced", "unchanged" or, when the commit was made but
+// the push failed, "push_failed" (with the push error in the message rather than as an error).
+// Emits: devkit:submodule:push
+func (a *App) SubmoduleSync(message string, push bool) (map[string]string, error) {
 	projects, err := service.GetProjects(a.projectsDir)
 	if err != nil {
 		return nil, err
@@ -248,13 +400,40 @@ func (a *App) SubmoduleSync(message string) (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(needsSync) == 0 {
-		return map[string]string{"message": "No submodule changes to sync"}, nil
+	if len(needsSync) == 0 && !push {
+		return map[string]string{"message": "No submodule changes to sync", "status": "unchanged"}, nil
+	}
+	target := "DevKit"
+	if len(needsSync) > 0 {
+		target = strings.Join(needsSync, ", ")
 	}
-	if err := git.SubmoduleSync(a.devkitRoot, a.projectsDir, needsSync, message); err != nil {
+	pushOutput := func(line string) {
+		a.emitEvent("devkit:submodule:push", map[string]interface{}{"line": line})
+	}
+	pushed, err := git.SubmoduleSync(a.devkitRoot, a.projectsDir, needsSync, message, push, pushOutput)
+	var pushErr *git.CommittedPushError
+	if errors.As(err, &pushErr) {
+		msg := "Submodules committed to DevKit, but the push failed: " + pushErr.Err.Error()
+		a.recordActivity(model.ActivityEvent{Type: "submodule", Target: target, Success: false, Message: msg})
+		return map[string]string{"message": msg, "status": "push_failed"}, nil
+	}
+	if err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "submodule", Target: target, Success: false, Message: "Sync failed: " + err.Error()})
 		return nil, err
 	}
-	return map[string]string{"message": "Submodules synced to DevKit"}, nil
+	var msg string
+	switch {
+	case len(needsSync) > 0 && pushed:
+		msg = "Submodules synced to DevKit and pushed"
+	case len(needsSync) > 0:
+		msg = "Submodules synced to DevKit"
+	case pushed:
+		msg = "No submodule changes to sync; pushed earlier DevKit commits"
+	default:
+		return map[string]string{"message": "No submodule changes to sync", "status": "unchanged"}, nil
+	}
+	a.recordActivity(model.ActivityEvent{Type: "submodule", Target: target, Success: true, Message: msg})
+	return map[string]string{"message": msg, "status": "synced"}, nil
 }
 
 // ====================
@@ -271,14 +450,102 @@ func (a *App) ListProjectDependencies(name string) ([]model.Dependency, error) {
 	return service.GetProjectDependencies(a.projectsDir, name)
 }
 
+// CheckDependencyVersions reports Go dependencies on sibling projects whose go.mod version differs
+// from the tag or commit checked out in the local clone
+func (a *App) CheckDependencyVersions() ([]model.DepMismatch, error) {
+	return service.CheckDependencyVersions(a.projectsDir)
+}
+
+// ListLocalReplaces returns the go.mod replace directives of a project that point at local directories
+func (a *App) ListLocalReplaces(project string) ([]model.LocalReplace, error) {
+	return service.ListLocalReplaces(a.projectsDir, project)
+}
+
+// ToggleLocalReplace makes a project build against the local clone of dependency (enable) by adding
+// a go.mod replace directive, or removes that directive
+func (a *App) ToggleLocalReplace(project, dependency string, enable bool) (map[string]string, error) {
+	if project == "" || dependency == "" {
+		return nil, fmt.Errorf("project and dependency are required")
+	}
+	if err := service.SetLocalReplace(a.projectsDir, project, dependency, enable); err != nil {
+		return nil, err
+	}
+	if enable {
+		return map[string]string{"message": fmt.Sprintf("%s now uses the local %s", project, dependency)}, nil
+	}
+	return map[string]string{"message": fmt.Sprintf("%s no longer replaces %s locally", project, dependency)}, nil
+}
+
+// GetDependencyGraph returns how the cloned projects depend on each other, with dependency cycles flagged
+func (a *App) GetDependencyGraph() (*model.DependencyGraph, error) {
+	return service.GetDependencyGraph(a.projectsDir)
+}
+
+// ProjectReadme returns the project's README content for preview
+func (a *App) ProjectReadme(name string) (map[string]interface{}, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	content, err := service.GetProjectReadme(a.projectsDir, name)
+	if errors.Is(err, service.ErrReadmeNotFound) {
+		return map[string]interface{}{"found": false, "content": ""}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"found": true, "content": content}, nil
+}
+
+// ProjectArtifacts returns the project's build output files (artifact dirs and module-root binary)
+func (a *App) ProjectArtifacts(name string) ([]model.Artifact, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.ListBuildArtifacts(filepath.Join(a.projectsDir, name), a.artifactDirs)
+}
+
+// ProjectSize returns how much disk space a cloned project occupies (including .git). The result is
+// cached until the project is cloned, updated or cleaned; refresh measures again.
+func (a *App) ProjectSize(name string, refresh bool) (model.ProjectSize, error) {
+	if name == "" {
+		return model.ProjectSize{}, fmt.Errorf("project name is required")
+	}
+	return service.ProjectSize(a.projectsDir, name, refresh)
+}
+
+// CleanProjectArtifacts deletes the files in the project's artifact dirs (the module-root binary is kept)
+func (a *App) CleanProjectArtifacts(name string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	removed, err := service.CleanBuildArtifacts(filepath.Join(a.projectsDir, name), a.artifactDirs)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Removed %d artifact(s) from %s", removed, name)}, nil
+}
+
 // ProjectClone clones a project submodule
 func (a *App) ProjectClone(name string) (map[string]string, error) {
-	if err := service.CloneProject(a.devkitRoot, a.projectsDir, name); err != nil {
+	if err := service.CloneProject(a.devkitRoot, a.projectsDir, name, a.cloneDepth); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "project", Target: name, Success: false, Message: "Clone failed: " + err.Error()})
 		return nil, fmt.Errorf("failed to clone submodule: %w", err)
 	}
+	a.recordActivity(model.ActivityEvent{Type: "project", Target: name, Success: true, Message: "Cloned"})
 	return map[string]string{"message": fmt.Sprintf("Successfully cloned %s", name)}, nil
 }
 
+// ProjectUnshallow fetches the full git history of a project that was shallow-cloned
+func (a *App) ProjectUnshallow(name string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	if err := service.UnshallowProject(a.projectsDir, name); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Fetched full history for %s", name)}, nil
+}
+
 // ProjectUpdate updates a project
 func (a *App) ProjectUpdate(name string) (map[string]string, error) {
 	projectDir := filepath.Join(a.projectsDir, name)
@@ -286,8 +553,10 @@ func (a *App) ProjectUpdate(name string) (map[string]string, error) {
 		return nil, fmt.Errorf("project not found. Please clone the project first")
 	}
 	if err := service.UpdateProject(a.devkitRoot, a.projectsDir, name); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "project", Target: name, Success: false, Message: "Update failed: " + err.Error()})
 		return nil, err
 	}
+	a.recordActivity(model.ActivityEvent{Type: "project", Target: name, Success: true, Message: "Updated"})
 	return map[string]string{"message": "update completed successfully"}, nil
 }
 
@@ -303,8 +572,9 @@ func (a *App) ProjectOpen(name string) (map[string]string, error) {
 	return map[string]string{"message": "Opening workspace"}, nil
 }
 
-// CreateTag creates an annotated tag at HEAD and optionally pushes to origin
-func (a *App) CreateTag(name, tag, message string, push bool) (map[string]string, error) {
+// CreateTag creates a tag at commit (any commit-ish; HEAD when empty) and optionally pushes to origin.
+// The tag is annotated with message unless lightweight is set.
+func (a *App) CreateTag(name, tag, message, commit string, lightweight, push bool) (map[string]string, error) {
 	if name == "" {
 		return nil, fmt.Errorf("project name is required")
 	}
@@ -315,16 +585,68 @@ func (a *App) CreateTag(name, tag, message string, push bool) (map[string]string
 	if message == "" {
 		message = "Release " + tag
 	}
-	if err := service.CreateReleaseTag(a.devkitRoot, a.projectsDir, name, tag, message, push); err != nil {
+	if err := service.CreateReleaseTag(a.devkitRoot, a.projectsDir, name, tag, message, commit, lightweight, push); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "tag", Target: name, Success: false, Message: "Tag " + tag + " failed: " + err.Error()})
 		return nil, err
 	}
 	msg := "Tag " + tag + " created"
 	if push {
 		msg += " and pushed to remote"
 	}
+	a.recordActivity(model.ActivityEvent{Type: "tag", Target: name, Success: true, Message: msg})
+	return map[string]string{"message": msg}, nil
+}
+
+// DeleteTag removes a mistaken tag from a project, and from origin when remote is set
+func (a *App) DeleteTag(name, tag string, remote bool) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	tag = strings.TrimSpace(tag)
+	if err := git.ValidateTagName(tag); err != nil {
+		return nil, err
+	}
+	if err := service.DeleteReleaseTag(a.projectsDir, name, tag, remote); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "tag", Target: name, Success: false, Message: "Deleting tag " + tag + " failed: " + err.Error()})
+		return nil, err
+	}
+	msg := "Tag " + tag + " deleted"
+	if remote {
+		msg += " locally and from remote"
+	}
+	a.recordActivity(model.ActivityEvent{Type: "tag", Target: name, Success: true, Message: msg})
 	return map[string]string{"message": msg}, nil
 }
 
+// SuggestNextTag returns the next version tag for a project from its highest semver tag, bumped by
+// bump ("patch", "minor" or "major"); v0.1.0 when it has no version tags yet
+func (a *App) SuggestNextTag(name, bump string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("project name is required")
+	}
+	if _, err := os.Stat(filepath.Join(a.projectsDir, name)); os.IsNotExist(err) {
+		return "", fmt.Errorf("project not cloned: clone the project first")
+	}
+	tags, err := service.ListProjectTags(a.devkitRoot, a.projectsDir, name)
+	if err != nil {
+		return "", err
+	}
+	return git.NextVersionTag(tags, bump, a.tagPrefixPolicy)
+}
+
+// SuggestTagName validates a tag name without creating it and returns a normalized suggestion when it is invalid
+func (a *App) SuggestTagName(tag string) map[string]interface{} {
+	suggestion, err := git.SuggestTagName(tag, a.tagPrefixPolicy)
+	if err != nil {
+		return map[string]interface{}{
+			"valid":      false,
+			"error":      err.Error(),
+			"suggestion": suggestion,
+		}
+	}
+	return map[string]interface{}{"valid": true, "suggestion": suggestion}
+}
+
 // ListTags returns existing tag names for the project
 func (a *App) ListTags(name string) (map[string]interface{}, error) {
 	if name == "" {
@@ -337,7 +659,103 @@ func (a *App) ListTags(name string) (map[string]interface{}, error) {
 	return map[string]interface{}{"tags": tags}, nil
 }
 
-// StartProjectStream starts streaming project operation output
+// ProjectDiff returns the project's uncommitted changes as a unified diff (staged changes when staged
+// is true). Empty when there are no changes; very large diffs end with a truncation notice.
+func (a *App) ProjectDiff(name string, staged bool) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("project name is required")
+	}
+	return service.ProjectDiff(a.projectsDir, name, staged)
+}
+
+// ProjectCommits returns the project's n most recent commits, newest first (20 when n <= 0)
+func (a *App) ProjectCommits(name string, n int) ([]model.Commit, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.ProjectCommits(a.projectsDir, name, n)
+}
+
+// ListProjectBranches returns the project's local and remote-tracking branches (deduplicated)
+func (a *App) ListProjectBranches(name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.ListProjectBranches(a.projectsDir, name)
+}
+
+// CheckoutProjectBranch switches the project to branch. Fails if the project has uncommitted changes.
+func (a *App) CheckoutProjectBranch(name, branch string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	if err := service.CheckoutProjectBranch(a.projectsDir, name, branch); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Switched %s to %s", name, branch)}, nil
+}
+
+// StashProject stashes the project's uncommitted changes (e.g. before an update or checkout)
+func (a *App) StashProject(name, message string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	if err := service.StashProject(a.projectsDir, name, message); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Stashed changes in %s", name)}, nil
+}
+
+// UnstashProject restores the project's most recently stashed changes
+func (a *App) UnstashProject(name string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	if err := service.UnstashProject(a.projectsDir, name); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": fmt.Sprintf("Restored stashed changes in %s", name)}, nil
+}
+
+// ListProjectStashes returns the project's stash entries ("stash@{N}: <message>"), newest first
+func (a *App) ListProjectStashes(name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.ListProjectStashes(a.projectsDir, name)
+}
+
+// ListProjectTargets returns the make targets StartProjectStream accepts for the project: the built-in
+// actions plus the targets listed in its .devkit.yml (see service.ProjectTargets)
+func (a *App) ListProjectTargets(name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.ProjectTargets(a.projectsDir, name)
+}
+
+// AllMakeTargets returns make targets for every cloned project that has a Makefile (project -> targets)
+func (a *App) AllMakeTargets() (map[string][]string, error) {
+	projects, err := service.GetProjects(a.projectsDir)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string)
+	for _, p := range projects {
+		if p.Status == "not-cloned" {
+			continue
+		}
+		targets, err := service.ListMakeTargets(a.projectsDir, p.Name)
+		if err != nil {
+			continue
+		}
+		result[p.Name] = targets
+	}
+	return result, nil
+}
+
+// StartProjectStream starts streaming project operation output. action is test, build, format, lint
+// or any target from ListProjectTargets.
 // Emits: devkit:project:stream and devkit:project:stream:done
 func (a *App) StartProjectStream(name, action string) error {
 	projectDir := filepath.Join(a.projectsDir, name)
@@ -346,70 +764,19 @@ func (a *App) StartProjectStream(name, action string) error {
 	}
 
 	streamID := fmt.Sprintf("project:%s:%s", name, action)
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	// Cancel any existing stream for this project/action
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
-	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "project", Params: []string{name, action}, At: time.Now()}
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
-
-		// Generate protos for wabisaby-core tests
-		if name == "wabisaby-core" && action == "test" {
-			protosDir := filepath.Join(a.projectsDir, "wabisaby-protos")
-			if _, err := os.Stat(protosDir); err == nil {
-				runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
-					"project": name,
-					"action":  action,
-					"line":    "[INFO] Generating protobuf code in wabisaby-protos...",
-				})
+		defer a.finishStream(stream)
 
-				protoCmd := exec.CommandContext(ctx, "make", "proto")
-				protoCmd.Dir = protosDir
-				protoOutput, err := protoCmd.CombinedOutput()
-				if err != nil {
-					runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
-						"project": name,
-						"action":  action,
-						"line":    fmt.Sprintf("[WARNING] Failed to generate protos: %s", string(protoOutput)),
-					})
-					runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
-						"project": name,
-						"action":  action,
-						"success": false,
-						"error":   "Cannot run tests without generated protobuf code",
-					})
-					return
-				}
-				runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
-					"project": name,
-					"action":  action,
-					"line":    "[INFO] Protobuf code generated successfully",
-				})
-			}
+		if action == "test" && !a.prepareCoreTestProtos(ctx, stream, name, action) {
+			return
 		}
 
-		var cmd *exec.Cmd
-		switch action {
-		case "test":
-			cmd = exec.CommandContext(ctx, "make", "test")
-		case "build":
-			cmd = exec.CommandContext(ctx, "make", "build")
-		case "format":
-			cmd = exec.CommandContext(ctx, "make", "format")
-		case "lint":
-			cmd = exec.CommandContext(ctx, "make", "lint")
-		default:
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+		// Built-in actions plus the targets listed in the project's .devkit.yml
+		if !service.IsProjectTarget(a.projectsDir, name, action) {
+			stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
 				"project": name,
 				"action":  action,
 				"success": false,
@@ -417,121 +784,314 @@ func (a *App) StartProjectStream(name, action string) error {
 			})
 			return
 		}
+		cmd := exec.CommandContext(ctx, "make", action)
 		cmd.Dir = projectDir
+		a.runProjectCommand(ctx, stream, name, action, cmd)
+	}()
 
-		stdout, err := cmd.StdoutPipe()
-		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
-				"project": name,
-				"action":  action,
-				"success": false,
-				"error":   err.Error(),
-			})
-			return
-		}
+	return nil
+}
 
-		stderr, err := cmd.StderrPipe()
-		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
-				"project": name,
-				"action":  action,
-				"success": false,
-				"error":   err.Error(),
-			})
+// StartProjectTestRun streams a test run limited to tests matching pattern (a -run regex) under pkg
+// (a path relative to the project; empty = all packages), as the "test-run" project action.
+// Emits: devkit:project:stream and devkit:project:stream:done
+func (a *App) StartProjectTestRun(name, pattern, pkg string) error {
+	projectDir := filepath.Join(a.projectsDir, name)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project not found")
+	}
+	args, err := service.TestRunArgs(projectDir, pattern, pkg)
+	if err != nil {
+		return err
+	}
+
+	const action = "test-run"
+	streamID := fmt.Sprintf("project:%s:%s", name, action)
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "test-run", Params: []string{name, pattern, pkg}, At: time.Now()}
+
+	go func() {
+		defer a.finishStream(stream)
+
+		if !a.prepareCoreTestProtos(ctx, stream, name, action) {
 			return
 		}
 
-		if err := cmd.Start(); err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+			"project": name,
+			"action":  action,
+			"line":    fmt.Sprintf("[INFO] %s", strings.Join(args, " ")),
+		})
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = projectDir
+		a.runProjectCommand(ctx, stream, name, action, cmd)
+	}()
+
+	return nil
+}
+
+// StopProjectStream stops an active project stream
+func (a *App) StopProjectStream(name, action string) {
+	streamID := fmt.Sprintf("project:%s:%s", name, action)
+	a.stopStream(streamID)
+}
+
+// prepareCoreTestProtos generates protobuf code in wabisaby-protos before wabisaby-core tests.
+// Returns false (after emitting done) if generation failed and the tests cannot run.
+func (a *App) prepareCoreTestProtos(ctx context.Context, stream *activeStream, name, action string) bool {
+	if name == "wabisaby-core" {
+		protosDir := filepath.Join(a.projectsDir, "wabisaby-protos")
+		if _, err := os.Stat(protosDir); err == nil {
+			stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
 				"project": name,
 				"action":  action,
-				"success": false,
-				"error":   err.Error(),
+				"line":    "[INFO] Generating protobuf code in wabisaby-protos...",
 			})
-			return
-		}
 
-		var wg sync.WaitGroup
-		wg.Add(2)
+			protoCmd := exec.CommandContext(ctx, "make", "proto")
+			protoCmd.Dir = protosDir
+			service.CancelProcessGroup(protoCmd)
+			protoOutput, err := protoCmd.CombinedOutput()
+			if err != nil {
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"line":    fmt.Sprintf("[WARNING] Failed to generate protos: %s", string(protoOutput)),
+				})
+				stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"success": false,
+					"error":   "Cannot run tests without generated protobuf code",
+				})
+				return false
+			}
+			stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+				"project": name,
+				"action":  action,
+				"line":    "[INFO] Protobuf code generated successfully",
+			})
+		}
+	}
 
-		// Read stdout
-		go func() {
-			defer wg.Done()
-			scanner := bufio.NewScanner(stdout)
-			for scanner.Scan() {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
-						"project": name,
-						"action":  action,
-						"line":    scanner.Text(),
-					})
-				}
+	return true
+}
+
+// runProjectCommand runs cmd for a project stream, emitting each output line on devkit:project:stream
+// and the result on devkit:project:stream:done.
+func (a *App) runProjectCommand(ctx context.Context, stream *activeStream, name, action string, cmd *exec.Cmd) {
+	success, exitCode, err := a.execProjectCommand(ctx, stream, name, action, cmd)
+	if err != nil {
+		stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+			"project": name,
+			"action":  action,
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+		"project":  name,
+		"action":   action,
+		"success":  success,
+		"exitCode": exitCode,
+	})
+}
+
+// execProjectCommand runs cmd, emitting its output and a [COMPLETE] line on devkit:project:stream.
+// err is set only when the command could not be started.
+func (a *App) execProjectCommand(ctx context.Context, stream *activeStream, name, action string, cmd *exec.Cmd) (success bool, exitCode int, err error) {
+	service.CancelProcessGroup(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false, 0, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return false, 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return false, 0, err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// Read stdout
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"line":    a.outputLine(scanner.Text()),
+				})
 			}
-		}()
+		}
+	}()
 
-		// Read stderr
-		go func() {
-			defer wg.Done()
-			scanner := bufio.NewScanner(stderr)
-			for scanner.Scan() {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
+	// Read stderr
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"line":    "[ERROR] " + a.outputLine(scanner.Text()),
+				})
+			}
+		}
+	}()
+
+	wg.Wait()
+	waitErr := cmd.Wait()
+	success = waitErr == nil
+
+	if waitErr != nil {
+		if exitError, ok := waitErr.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		}
+	}
+	stream.setExitCode(exitCode)
+
+	completeLine := "[COMPLETE] Operation completed successfully"
+	if !success {
+		completeLine = fmt.Sprintf("[COMPLETE] Operation failed with exit code %d", exitCode)
+	}
+
+	stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+		"project": name,
+		"action":  action,
+		"line":    completeLine,
+	})
+	return success, exitCode, nil
+}
+
+// CleanProjectPlan returns what CleanProject would do for a project, for the confirmation dialog
+func (a *App) CleanProjectPlan(name string) (*model.CleanPlan, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	return service.PlanClean(a.projectsDir, name, a.artifactDirs)
+}
+
+// CleanProject recovers disk space and forces a clean rebuild: it runs "make clean" when the
+// project's Makefile has that target, otherwise removes exactly the confirmed paths (as returned by
+// CleanProjectPlan). A confirmed path that is no longer in the plan (e.g. git now tracks it) is
+// skipped, and new plan paths that weren't confirmed are left alone. Output streams as the "clean"
+// project action; the done event carries reclaimedBytes.
+// Emits: devkit:project:stream and devkit:project:stream:done
+func (a *App) CleanProject(name string, confirmedPaths []string) error {
+	plan, err := a.CleanProjectPlan(name)
+	if err != nil {
+		return err
+	}
+	if !plan.UseMake && len(plan.Paths) > 0 && len(confirmedPaths) == 0 {
+		return fmt.Errorf("confirmation required to delete: %s", strings.Join(plan.Paths, ", "))
+	}
+	planned := make(map[string]bool, len(plan.Paths))
+	for _, p := range plan.Paths {
+		planned[p] = true
+	}
+
+	const action = "clean"
+	projectDir := filepath.Join(a.projectsDir, name)
+	ctx, stream := a.startStream(fmt.Sprintf("project:%s:%s", name, action))
+
+	go func() {
+		defer a.finishStream(stream)
+
+		before, _ := service.ProjectSize(a.projectsDir, name, true)
+		success, exitCode := true, 0
+		if plan.UseMake {
+			cmd := exec.CommandContext(ctx, "make", "clean")
+			cmd.Dir = projectDir
+			if success, exitCode, err = a.execProjectCommand(ctx, stream, name, action, cmd); err != nil {
+				stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"success": false,
+					"error":   err.Error(),
+				})
+				return
+			}
+		} else {
+			if len(confirmedPaths) == 0 {
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"line":    "[INFO] Nothing to clean",
+				})
+			}
+			for _, p := range confirmedPaths {
+				if ctx.Err() != nil {
+					stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
 						"project": name,
 						"action":  action,
-						"line":    "[ERROR] " + scanner.Text(),
+						"success": false,
+						"error":   "cancelled",
 					})
+					return
 				}
-			}
-		}()
-
-		wg.Wait()
-		err = cmd.Wait()
-		success := err == nil
-
-		var exitCode int
-		if err != nil {
-			if exitError, ok := err.(*exec.ExitError); ok {
-				exitCode = exitError.ExitCode()
+				line := "[INFO] Removed " + p
+				if !planned[p] {
+					line = "[WARN] Skipped " + p + ": no longer safe to delete"
+				} else if err := service.RemoveCleanPath(projectDir, p); err != nil {
+					line, success = "[ERROR] "+err.Error(), false
+				}
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					"project": name,
+					"action":  action,
+					"line":    line,
+				})
 			}
 		}
 
-		completeLine := "[COMPLETE] Operation completed successfully"
-		if !success {
-			completeLine = fmt.Sprintf("[COMPLETE] Operation failed with exit code %d", exitCode)
+		after, _ := service.ProjectSize(a.projectsDir, name, true)
+		reclaimed := before.SizeBytes - after.SizeBytes
+		if reclaimed < 0 {
+			reclaimed = 0
 		}
-
-		runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
 			"project": name,
 			"action":  action,
-			"line":    completeLine,
+			"line":    fmt.Sprintf("[INFO] Reclaimed %s", formatBytes(reclaimed)),
 		})
-
-		runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
-			"project":  name,
-			"action":   action,
-			"success":  success,
-			"exitCode": exitCode,
+		a.recordActivity(model.ActivityEvent{Type: "project", Target: name, Success: success, Message: fmt.Sprintf("Cleaned (%s reclaimed)", formatBytes(reclaimed))})
+		stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+			"project":        name,
+			"action":         action,
+			"success":        success,
+			"exitCode":       exitCode,
+			"reclaimedBytes": reclaimed,
 		})
 	}()
 
 	return nil
 }
 
-// StopProjectStream stops an active project stream
-func (a *App) StopProjectStream(name, action string) {
-	streamID := fmt.Sprintf("project:%s:%s", name, action)
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
+// formatBytes renders a byte count for output lines, e.g. "412.3 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
 	}
-	a.streamMu.Unlock()
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 const webAppProjectName = "wabisaby-web"
@@ -548,30 +1108,20 @@ func (a *App) StartWebAppDev() error {
 		return fmt.Errorf("project %s not found", webAppProjectName)
 	}
 
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[webAppDevStreamID]; ok {
-		existing()
-	}
-	ctx, cancel := context.WithCancel(a.ctx)
-	a.activeStreams[webAppDevStreamID] = cancel
-	a.streamMu.Unlock()
+	ctx, stream := a.startStream(webAppDevStreamID)
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, webAppDevStreamID)
-			a.streamMu.Unlock()
-		}()
+		defer a.finishStream(stream)
 
 		// Free the web app port so Vite can bind to 5175
 		_ = a.processManager.KillProcessOnPort(webAppDevServerPort)
 		if !a.processManager.WaitForPortFree(webAppDevServerPort, 3*time.Second) {
-			runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
 				"project": webAppProjectName,
 				"action":  "dev",
 				"line":    fmt.Sprintf("[ERROR] Port %d still in use after freeing it", webAppDevServerPort),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
 				"project": webAppProjectName,
 				"action":  "dev",
 				"success": false,
@@ -582,18 +1132,19 @@ func (a *App) StartWebAppDev() error {
 
 		cmd := exec.CommandContext(ctx, "npm", "run", "dev")
 		cmd.Dir = projectDir
+		service.CancelProcessGroup(cmd)
 		cmd.Env = append(os.Environ(), fmt.Sprintf("PORT=%d", webAppDevServerPort))
 
 		stdout, _ := cmd.StdoutPipe()
 		stderr, _ := cmd.StderrPipe()
 
 		if err := cmd.Start(); err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
 				"project": webAppProjectName,
 				"action":  "dev",
 				"line":    "[ERROR] Failed to start: " + err.Error(),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
 				"project": webAppProjectName,
 				"action":  "dev",
 				"success": false,
@@ -612,7 +1163,7 @@ func (a *App) StartWebAppDev() error {
 				case <-ctx.Done():
 					return
 				default:
-					runtime.EventsEmit(a.ctx, "devkit:project:stream", map[string]interface{}{
+					stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{
 						"project": webAppProjectName,
 						"action":  "dev",
 						"line":    prefix + scanner.Text(),
@@ -625,7 +1176,7 @@ func (a *App) StartWebAppDev() error {
 		wg.Wait()
 		_ = cmd.Wait()
 
-		runtime.EventsEmit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{
 			"project": webAppProjectName,
 			"action":  "dev",
 			"success": ctx.Err() == nil,
@@ -637,12 +1188,7 @@ func (a *App) StartWebAppDev() error {
 
 // StopWebAppDev stops the wabisaby-web dev server if running.
 func (a *App) StopWebAppDev() {
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[webAppDevStreamID]; ok {
-		cancel()
-		delete(a.activeStreams, webAppDevStreamID)
-	}
-	a.streamMu.Unlock()
+	a.stopStream(webAppDevStreamID)
 }
 
 // OpenWebAppURL opens the wabisaby-web dev server URL in the default browser.
@@ -650,8 +1196,10 @@ func (a *App) OpenWebAppURL() {
 	runtime.BrowserOpenURL(a.ctx, webAppDevServerURL)
 }
 
-// StartBulkProjectStream starts streaming bulk operation across all projects
-// Emits: devkit:project:bulk:stream and devkit:project:bulk:stream:done
+// StartBulkProjectStream starts streaming bulk operation across all projects, running up to
+// WABISABY_BULK_PARALLELISM (default 2) projects at once. success in the done event is false if
+// any project failed; its results list every project that ran.
+// Emits: devkit:project:bulk:stream, devkit:project:bulk:result and devkit:project:bulk:stream:done
 func (a *App) StartBulkProjectStream(action string) error {
 	switch action {
 	case "format", "lint", "test", "build":
@@ -665,111 +1213,162 @@ func (a *App) StartBulkProjectStream(action string) error {
 	}
 
 	streamID := fmt.Sprintf("bulk:%s", action)
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
-	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "bulk", Params: []string{action}, At: time.Now()}
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
-
+		defer a.finishStream(stream)
+
+		// Worker pool: up to bulkParallelism projects run make at once. Each project's output is
+		// emitted as one block after it finishes, holding blockMu, so lines from parallel runs don't
+		// interleave.
+		parallelism := a.bulkParallelism
+		if parallelism < 1 {
+			parallelism = 1
+		}
+		jobs := make(chan model.Project)
+		var (
+			wg        sync.WaitGroup
+			blockMu   sync.Mutex
+			resultsMu sync.Mutex
+			results   []model.BulkProjectResult
+		)
+		for i := 0; i < parallelism; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for p := range jobs {
+					if result, ran := a.runBulkProject(ctx, stream, &blockMu, action, p.Name); ran {
+						resultsMu.Lock()
+						results = append(results, result)
+						resultsMu.Unlock()
+					}
+				}
+			}()
+		}
+	feed:
 		for _, p := range projects {
 			select {
 			case <-ctx.Done():
-				return
-			default:
-			}
-
-			projectDir := filepath.Join(a.projectsDir, p.Name)
-			if _, err := os.Stat(projectDir); os.IsNotExist(err) {
-				runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
-					"project": p.Name,
-					"action":  action,
-					"line":    fmt.Sprintf("[%s] skipped (not cloned)", p.Name),
-				})
-				continue
+				break feed
+			case jobs <- p:
 			}
+		}
+		close(jobs)
+		wg.Wait()
+		if ctx.Err() != nil {
+			return
+		}
 
-			runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
-				"project": p.Name,
-				"action":  action,
-				"line":    fmt.Sprintf("[%s] Running make %s...", p.Name, action),
-			})
-
-			cmd := exec.CommandContext(ctx, "make", action)
-			cmd.Dir = projectDir
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
-					"project": p.Name,
-					"action":  action,
-					"line":    fmt.Sprintf("[%s] [ERROR] exit: %v", p.Name, err),
-				})
-			}
-			lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
-			for _, line := range lines {
-				if line == "" {
-					continue
-				}
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
-						"project": p.Name,
-						"action":  action,
-						"line":    fmt.Sprintf("[%s] %s", p.Name, line),
-					})
-				}
+		failed := false
+		for _, r := range results {
+			if !r.Success {
+				failed = true
 			}
 		}
 
-		runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
 			"action": action,
 			"line":   fmt.Sprintf("[COMPLETE] Bulk %s finished", action),
 		})
 
-		runtime.EventsEmit(a.ctx, "devkit:project:bulk:stream:done", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:project:bulk:stream:done", map[string]interface{}{
 			"action":  action,
-			"success": true,
+			"success": !failed,
+			"results": results,
 		})
 	}()
 
 	return nil
 }
 
+// runBulkProject runs make action in one project for StartBulkProjectStream, emitting its prefixed
+// output and a devkit:project:bulk:result event. Every emit holds blockMu, the output block as a
+// whole, so parallel projects' lines never mix. ran is false when the project is not cloned or the
+// stream was cancelled.
+func (a *App) runBulkProject(ctx context.Context, stream *activeStream, blockMu *sync.Mutex, action, name string) (result model.BulkProjectResult, ran bool) {
+	projectDir := filepath.Join(a.projectsDir, name)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		blockMu.Lock()
+		defer blockMu.Unlock()
+		stream.emit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
+			"project": name,
+			"action":  action,
+			"line":    fmt.Sprintf("[%s] skipped (not cloned)", name),
+		})
+		return result, false
+	}
+
+	blockMu.Lock()
+	stream.emit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
+		"project": name,
+		"action":  action,
+		"line":    fmt.Sprintf("[%s] Running make %s...", name, action),
+	})
+	blockMu.Unlock()
+
+	started := time.Now()
+	cmd := exec.CommandContext(ctx, "make", action)
+	cmd.Dir = projectDir
+	service.CancelProcessGroup(cmd)
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return result, false
+	}
+	result = model.BulkProjectResult{
+		Project:    name,
+		Success:    err == nil,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	blockMu.Lock()
+	defer blockMu.Unlock()
+	if err != nil {
+		result.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		stream.emit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
+			"project": name,
+			"action":  action,
+			"line":    fmt.Sprintf("[%s] [ERROR] exit: %v", name, err),
+		})
+	}
+	lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			return result, false
+		}
+		stream.emit(a.ctx, "devkit:project:bulk:stream", map[string]interface{}{
+			"project": name,
+			"action":  action,
+			"line":    fmt.Sprintf("[%s] %s", name, a.outputLine(line)),
+		})
+	}
+
+	stream.emit(a.ctx, "devkit:project:bulk:result", map[string]interface{}{
+		"action":     action,
+		"project":    result.Project,
+		"success":    result.Success,
+		"exitCode":   result.ExitCode,
+		"durationMs": result.DurationMs,
+	})
+	return result, true
+}
+
 // StopBulkProjectStream stops an active bulk project stream
 func (a *App) StopBulkProjectStream(action string) {
 	streamID := fmt.Sprintf("bulk:%s", action)
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
-	}
-	a.streamMu.Unlock()
+	a.stopStream(streamID)
 }
 
 // ====================
 // Services (Docker) API
 // ====================
 
-// Service UI URLs
-var serviceUIURLs = map[string]string{
-	"pgAdmin":        "http://localhost:5050",
-	"RedisCommander": "http://localhost:8081",
-	"MinIO":          "http://localhost:9001",
-	"Vault":          "http://localhost:8200",
-	"Keycloak":       "http://localhost:8180/admin",
-}
-
 // IsDockerConnected returns true if the Docker daemon is running and accessible.
 func (a *App) IsDockerConnected() bool {
 	return service.IsDockerConnected()
@@ -777,21 +1376,27 @@ func (a *App) IsDockerConnected() bool {
 
 // ListServices returns all Docker services with their status
 func (a *App) ListServices() []model.Service {
-	services := []model.Service{
-		{Name: "PostgreSQL", Port: 5432},
-		{Name: "Redis", Port: 6379},
-		{Name: "RedisCommander", Port: 8081},
-		{Name: "MinIO", Port: 9000},
-		{Name: "Vault", Port: 8200},
-		{Name: "Keycloak", Port: 8180},
-		{Name: "pgAdmin", Port: 5050},
-	}
-
-	for i := range services {
-		services[i].Status = service.CheckServiceStatus(services[i].Name, services[i].Port, a.devkitRoot)
-		if url, ok := serviceUIURLs[services[i].Name]; ok {
-			services[i].URL = url
+	// Services added to docker-compose.yml; on failure the configured list is shown as is
+	_ = service.SyncComposeServices(a.devkitRoot)
+	configs := config.GetDockerServices()
+	// Ports remapped in docker-compose.override.yml; fall back to configured defaults
+	ports, _ := service.ResolveServicePorts(a.devkitRoot)
+
+	services := make([]model.Service, 0, len(configs))
+	for _, svc := range configs {
+		port := svc.Port
+		if p, ok := ports[svc.Name]; ok {
+			port = p
 		}
+		status := service.CheckServiceStatus(svc.Name, port, a.devkitRoot)
+		services = append(services, model.Service{
+			Name:          svc.Name,
+			Port:          port,
+			Status:        status,
+			Health:        service.CheckServiceHealth(svc.Name, status),
+			URL:           service.ResolveServiceURL(a.devkitRoot, svc),
+			ContainerName: svc.ContainerName,
+		})
 	}
 
 	return services
@@ -799,10 +1404,15 @@ func (a *App) ListServices() []model.Service {
 
 // StartService starts a Docker service
 func (a *App) StartService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
 	if err := service.StartService(name, a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: false, Message: "Failed to start: " + err.Error()})
 		return nil, fmt.Errorf("failed to start %s: %w", name, err)
 	}
-	runtime.EventsEmit(a.ctx, "devkit:service:logs", map[string]interface{}{
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: true, Message: "Started"})
+	a.emitEvent("devkit:service:logs", map[string]interface{}{
 		"name": name,
 		"line": "Started",
 	})
@@ -811,74 +1421,108 @@ func (a *App) StartService(name string) (map[string]string, error) {
 
 // StopService stops a Docker service
 func (a *App) StopService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
 	if err := service.StopService(name, a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: false, Message: "Failed to stop: " + err.Error()})
 		return nil, fmt.Errorf("failed to stop %s: %w", name, err)
 	}
-	runtime.EventsEmit(a.ctx, "devkit:service:logs", map[string]interface{}{
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: true, Message: "Stopped"})
+	a.emitEvent("devkit:service:logs", map[string]interface{}{
 		"name": name,
 		"line": "Stopped",
 	})
 	return map[string]string{"message": fmt.Sprintf("stop %s completed", name)}, nil
 }
 
+// RestartService restarts a Docker service
+func (a *App) RestartService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
+	if err := service.RestartService(name, a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: false, Message: "Failed to restart: " + err.Error()})
+		return nil, fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: name, Success: true, Message: "Restarted"})
+	a.emitEvent("devkit:service:logs", map[string]interface{}{
+		"name": name,
+		"line": "Restarted",
+	})
+	return map[string]string{"message": fmt.Sprintf("restart %s completed", name)}, nil
+}
+
 // StartAllServices starts all Docker services
 func (a *App) StartAllServices() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
 	if err := service.StartAllServices(a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: false, Message: "Failed to start all services: " + err.Error()})
 		return nil, fmt.Errorf("failed to start all services: %w", err)
 	}
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: true, Message: "Started all services"})
 	return map[string]string{"message": "start all completed"}, nil
 }
 
 // StopAllServices stops all Docker services
 func (a *App) StopAllServices() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
 	if err := service.StopAllServices(a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: false, Message: "Failed to stop all services: " + err.Error()})
 		return nil, fmt.Errorf("failed to stop all services: %w", err)
 	}
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: true, Message: "Stopped all services"})
 	return map[string]string{"message": "stop all completed"}, nil
 }
 
-// Map service names to docker-compose service names
-var serviceNameMap = map[string]string{
-	"PostgreSQL":     "postgres",
-	"Redis":          "redis",
-	"RedisCommander": "redis-commander",
-	"MinIO":          "minio",
-	"Vault":          "vault",
-	"Keycloak":       "keycloak",
-	"pgAdmin":        "pgadmin",
+// RestartAllServices restarts all running Docker services
+func (a *App) RestartAllServices() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandInfrastructure); err != nil {
+		return nil, err
+	}
+	if err := service.RestartAllServices(a.devkitRoot); err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: false, Message: "Failed to restart all services: " + err.Error()})
+		return nil, fmt.Errorf("failed to restart all services: %w", err)
+	}
+	a.recordActivity(model.ActivityEvent{Type: "service", Target: "all", Success: true, Message: "Restarted all services"})
+	return map[string]string{"message": "restart all completed"}, nil
+}
+
+// ServiceStats returns the CPU, memory and network usage of a running Docker service's container
+func (a *App) ServiceStats(name string) (*model.ContainerStats, error) {
+	svc := config.GetDockerServiceByName(name)
+	if svc == nil || svc.ContainerName == "" {
+		return nil, fmt.Errorf("unknown service: %s", name)
+	}
+	return service.ContainerStats(svc.ContainerName)
 }
 
-// StartServiceLogsStream starts streaming Docker service logs
+// StartServiceLogsStream starts streaming Docker service logs, emitting only lines that contain
+// filter (or match it as a regex when regex is set); an empty filter emits every line.
 // Emits: devkit:service:logs and devkit:service:logs:done
-func (a *App) StartServiceLogsStream(name string) error {
-	composeServiceName, ok := serviceNameMap[name]
-	if !ok {
-		composeServiceName = strings.ToLower(name)
+func (a *App) StartServiceLogsStream(name, filter string, regex bool) error {
+	matches, err := newLineFilter(filter, regex)
+	if err != nil {
+		return err
 	}
 
+	composeServiceName := service.ComposeServiceName(name)
 	composeFile := filepath.Join(a.devkitRoot, "docker/docker-compose.yml")
 
 	streamID := fmt.Sprintf("service:logs:%s", name)
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
-	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+	ctx, stream := a.startStream(streamID)
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
+		defer a.finishStream(stream)
 
-		cmd := exec.CommandContext(ctx, "docker-compose", "-f", composeFile, "logs", "-f", "--tail=500", composeServiceName)
+		cmd := service.ComposeCmd(ctx, "-f", composeFile, "logs", "-f", "--tail=500", composeServiceName)
 		stdout, err := cmd.StdoutPipe()
 		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
 				"service": name,
 				"error":   err.Error(),
 			})
@@ -887,7 +1531,7 @@ func (a *App) StartServiceLogsStream(name string) error {
 
 		stderr, err := cmd.StderrPipe()
 		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
 				"service": name,
 				"error":   err.Error(),
 			})
@@ -895,14 +1539,14 @@ func (a *App) StartServiceLogsStream(name string) error {
 		}
 
 		if err := cmd.Start(); err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
 				"service": name,
 				"error":   err.Error(),
 			})
 			return
 		}
 
-		runtime.EventsEmit(a.ctx, "devkit:service:logs", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:service:logs", map[string]interface{}{
 			"service": name,
 			"line":    fmt.Sprintf("[Connected to %s logs]", name),
 		})
@@ -919,9 +1563,13 @@ func (a *App) StartServiceLogsStream(name string) error {
 				case <-ctx.Done():
 					return
 				default:
-					runtime.EventsEmit(a.ctx, "devkit:service:logs", map[string]interface{}{
+					// Filter on the visible text so color codes can't break matches
+					if !matches.match(ansi.Strip(scanner.Text())) {
+						continue
+					}
+					stream.emit(a.ctx, "devkit:service:logs", map[string]interface{}{
 						"service": name,
-						"line":    scanner.Text(),
+						"line":    a.outputLine(scanner.Text()),
 					})
 				}
 			}
@@ -936,9 +1584,13 @@ func (a *App) StartServiceLogsStream(name string) error {
 				case <-ctx.Done():
 					return
 				default:
-					runtime.EventsEmit(a.ctx, "devkit:service:logs", map[string]interface{}{
+					// Filter on the visible text so color codes can't break matches
+					if !matches.match(ansi.Strip(scanner.Text())) {
+						continue
+					}
+					stream.emit(a.ctx, "devkit:service:logs", map[string]interface{}{
 						"service": name,
-						"line":    "[ERROR] " + scanner.Text(),
+						"line":    "[ERROR] " + a.outputLine(scanner.Text()),
 					})
 				}
 			}
@@ -947,7 +1599,7 @@ func (a *App) StartServiceLogsStream(name string) error {
 		wg.Wait()
 		cmd.Wait()
 
-		runtime.EventsEmit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:service:logs:done", map[string]interface{}{
 			"service": name,
 		})
 	}()
@@ -958,43 +1610,119 @@ func (a *App) StartServiceLogsStream(name string) error {
 // StopServiceLogsStream stops an active service logs stream
 func (a *App) StopServiceLogsStream(name string) {
 	streamID := fmt.Sprintf("service:logs:%s", name)
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
-	}
-	a.streamMu.Unlock()
+	a.stopStream(streamID)
 }
 
-// ====================
-// Backend (WabiSaby-Go) API
-// ====================
+// allServiceLogsTail is how many past lines per service StartAllServiceLogsStream replays
+const allServiceLogsTail = 100
 
-// ListBackendServices returns all WabiSaby-Go services with their status
-func (a *App) ListBackendServices() []model.BackendService {
-	services := config.GetBackendServices()
-	result := make([]model.BackendService, 0, len(services))
+// StartAllServiceLogsStream streams the logs of every Docker service at once, tagging each line with
+// the service it came from (taken from the compose log prefix).
+// Emits: devkit:service:logs:all and devkit:service:logs:all:done
+func (a *App) StartAllServiceLogsStream() error {
+	if err := service.RequireDocker(); err != nil {
+		return err
+	}
+	composeFile := filepath.Join(a.devkitRoot, "docker/docker-compose.yml")
 
-	for _, svc := range services {
-		bs := model.BackendService{
-			Name:       svc.Name,
-			Group:      svc.Group,
-			Port:       svc.Port,
-			Status:     a.processManager.GetStatus(svc.Name),
-			PID:        a.processManager.GetPID(svc.Name),
-			Error:      a.processManager.GetError(svc.Name),
-			LastOutput: a.processManager.GetLastOutput(svc.Name),
-		}
+	streamID := "service:logs:all"
+	ctx, stream := a.startStream(streamID)
 
-		// If not in process manager, detect running via health probe
-		if bs.Status == "stopped" && svc.Port > 0 && svc.HealthPath != "" {
-			if a.processManager.ProbeHealth(svc.Port, svc.HealthPath) {
-				bs.Status = "running"
-			}
-		}
+	go func() {
+		defer a.finishStream(stream)
 
-		// Add health and docs URLs for running services with ports
-		if bs.Status == "running" && svc.Port > 0 {
+		cmd := service.ComposeCmd(ctx, "-f", composeFile, "logs", "-f", fmt.Sprintf("--tail=%d", allServiceLogsTail))
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			stream.emit(a.ctx, "devkit:service:logs:all:done", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			stream.emit(a.ctx, "devkit:service:logs:all:done", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			stream.emit(a.ctx, "devkit:service:logs:all:done", map[string]interface{}{"error": err.Error()})
+			return
+		}
+
+		stream.emit(a.ctx, "devkit:service:logs:all", map[string]interface{}{
+			"service": "",
+			"line":    "[Connected to all service logs]",
+		})
+
+		var wg sync.WaitGroup
+		read := func(r io.Reader, prefix string) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(r)
+			for scanner.Scan() {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					source, text := service.ComposeLogSource(scanner.Text())
+					stream.emit(a.ctx, "devkit:service:logs:all", map[string]interface{}{
+						"service": source,
+						"line":    prefix + a.outputLine(text),
+					})
+				}
+			}
+		}
+		wg.Add(2)
+		go read(stdout, "")
+		go read(stderr, "[ERROR] ")
+		wg.Wait()
+		cmd.Wait()
+
+		stream.emit(a.ctx, "devkit:service:logs:all:done", map[string]interface{}{})
+	}()
+
+	return nil
+}
+
+// StopAllServiceLogsStream stops the combined service logs stream
+func (a *App) StopAllServiceLogsStream() {
+	a.stopStream("service:logs:all")
+}
+
+// ====================
+// Backend (WabiSaby-Go) API
+// ====================
+
+// ListBackendServices returns all WabiSaby-Go services with their status
+func (a *App) ListBackendServices() []model.BackendService {
+	services := config.GetBackendServices()
+	result := make([]model.BackendService, 0, len(services))
+	snapshot := a.processManager.Snapshot()
+
+	for _, svc := range services {
+		bs := model.BackendService{
+			Name:   svc.Name,
+			Group:  svc.Group,
+			Port:   svc.Port,
+			Status: string(service.ProcessStopped),
+		}
+		if info, ok := snapshot[svc.Name]; ok {
+			bs.Status = info.Status
+			bs.PID = info.PID
+			bs.Error = info.Error
+			bs.LastOutput = info.LastOutput
+			bs.UptimeSeconds = int64(info.Uptime.Seconds())
+			bs.Restarts = info.Restarts
+			bs.Adopted = info.Adopted
+		}
+
+		// If not in process manager, detect running via health probe (uptime unknown)
+		if bs.Status == "stopped" && svc.Port > 0 && svc.HealthPath != "" {
+			if a.processManager.ProbeHealth(svc) {
+				bs.Status = "running"
+				bs.Adopted = true
+			}
+		}
+
+		// Add health and docs URLs for running services with ports
+		if bs.Status == "running" && svc.Port > 0 {
 			if svc.HealthPath != "" {
 				bs.HealthURL = fmt.Sprintf("http://localhost:%d%s", svc.Port, svc.HealthPath)
 			}
@@ -1009,6 +1737,14 @@ func (a *App) ListBackendServices() []model.BackendService {
 	return result
 }
 
+// BackendStats returns the memory and CPU usage of a running backend service, for the frontend to poll
+func (a *App) BackendStats(name string) (*model.ProcessStats, error) {
+	if name == "" {
+		return nil, fmt.Errorf("service name required")
+	}
+	return a.processManager.ResourceUsage(name)
+}
+
 // BackendHealth proxies a GET to the service's health endpoint
 func (a *App) BackendHealth(name string) (map[string]interface{}, error) {
 	if name == "" {
@@ -1028,8 +1764,13 @@ func (a *App) BackendHealth(name string) (map[string]interface{}, error) {
 	}
 
 	url := fmt.Sprintf("http://localhost:%d%s", svc.Port, svc.HealthPath)
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Get(url)
+	ctx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, svc.HealthCheckMethod(), url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.httpClient.Do(req)
 	if err != nil {
 		return map[string]interface{}{
 			"ok":         false,
@@ -1039,37 +1780,221 @@ func (a *App) BackendHealth(name string) (map[string]interface{}, error) {
 			"error":      err.Error(),
 		}, nil
 	}
-	defer resp.Body.Close()
+	defer service.DrainAndClose(resp.Body)
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
+	bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
 	body := strings.TrimSpace(string(bodyBytes))
 
 	return map[string]interface{}{
-		"ok":         resp.StatusCode >= 200 && resp.StatusCode < 300,
+		"ok":         svc.HealthStatusOK(resp.StatusCode),
 		"statusCode": resp.StatusCode,
 		"status":     resp.Status,
 		"body":       body,
 	}, nil
 }
 
+// ServiceEnvUsage reports which env vars the service's cmd package reads, flagging vars that are
+// not declared in the known env list and known vars the package never reads (they may still be
+// read by shared packages outside the cmd directory).
+func (a *App) ServiceEnvUsage(name string) (*model.ServiceEnvUsage, error) {
+	if name == "" {
+		return nil, fmt.Errorf("service name required")
+	}
+
+	services := config.GetBackendServices()
+	var svc *config.BackendServiceConfig
+	for i := range services {
+		if services[i].Name == name {
+			svc = &services[i]
+			break
+		}
+	}
+	if svc == nil {
+		return nil, fmt.Errorf("unknown service: %s", name)
+	}
+
+	projectDir := a.wabisabyCorePath
+	if svc.RepoName != "" {
+		projectDir = filepath.Join(a.projectsDir, svc.RepoName)
+	}
+	used, err := service.ScanServiceEnvUsage(projectDir, svc.CmdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &model.ServiceEnvUsage{
+		Service:    name,
+		Used:       used,
+		Undeclared: []string{},
+		Unused:     []string{},
+	}
+	known := config.KnownEnvVars()
+	usedSet := make(map[string]bool, len(used))
+	for _, v := range used {
+		usedSet[v] = true
+		if !known[v] {
+			usage.Undeclared = append(usage.Undeclared, v)
+		}
+	}
+	for _, v := range append(config.RequiredEnvVars(), config.OptionalEnvVars()...) {
+		if !usedSet[v] {
+			usage.Unused = append(usage.Unused, v)
+		}
+	}
+	return usage, nil
+}
+
+// BackendEffectiveEnv returns the environment the service would be started with (dashboard env,
+// GOTOOLCHAIN, .env and per-service defaults, last value winning), with sensitive values masked.
+func (a *App) BackendEffectiveEnv(name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("service name required")
+	}
+	env, err := a.processManager.EffectiveEnv(name)
+	if err != nil {
+		return nil, err
+	}
+	return service.MaskSensitiveEnv(env), nil
+}
+
+// PortCollisions returns pairs of backend/Docker services configured on the same port
+func (a *App) PortCollisions() []config.PortCollision {
+	return config.CheckPortCollisions()
+}
+
 // StartBackendService starts a specific backend service
 func (a *App) StartBackendService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return nil, err
+	}
 	if name == "" {
 		return nil, fmt.Errorf("service name required")
 	}
 	if err := a.processManager.Start(name); err != nil {
 		return nil, fmt.Errorf("failed to start %s: %w", name, err)
 	}
-	runtime.EventsEmit(a.ctx, "devkit:backend:started", map[string]interface{}{"name": name})
-	runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+	a.emitEvent("devkit:backend:started", map[string]interface{}{"name": name})
+	a.recordActivity(model.ActivityEvent{Type: "backend", Target: name, Success: true, Message: "Started"})
+	a.emitEvent("devkit:backend:logs", map[string]interface{}{
 		"name": name,
 		"line": "Started",
 	})
 	return map[string]string{"message": fmt.Sprintf("Started %s", name)}, nil
 }
 
+// BackendBuildMode returns how backend services are started: "run" (go run) or "build" (go build, then exec)
+func (a *App) BackendBuildMode() string {
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil || settings.BackendBuildMode == "" {
+		return config.BackendBuildModeRun
+	}
+	return settings.BackendBuildMode
+}
+
+// SetBackendBuildMode switches between "run" (fast iteration) and "build" (fast restart) for subsequent
+// starts and saves the choice in settings
+func (a *App) SetBackendBuildMode(mode string) error {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return err
+	}
+	if mode != config.BackendBuildModeRun && mode != config.BackendBuildModeBuild {
+		return fmt.Errorf("invalid build mode (use 'run' or 'build')")
+	}
+	a.settingsMu.Lock()
+	defer a.settingsMu.Unlock()
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+	settings.BackendBuildMode = mode
+	if err := config.SaveSettings(a.appDataDir, settings); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	a.processManager.SetBuildMode(mode)
+	return nil
+}
+
+// SetServiceEnvOverride sets an env var for one backend service only, overriding .env from its next start,
+// and saves it in settings. An empty value is kept as an override (the var is set but empty); use
+// DeleteServiceEnvOverride to remove one.
+func (a *App) SetServiceEnvOverride(name, key, value string) error {
+	return a.updateServiceEnvOverride(name, key, func(vars map[string]string) {
+		vars[key] = value
+	})
+}
+
+// DeleteServiceEnvOverride removes a backend service's env var override, so .env applies again from
+// its next start
+func (a *App) DeleteServiceEnvOverride(name, key string) error {
+	return a.updateServiceEnvOverride(name, key, func(vars map[string]string) {
+		delete(vars, key)
+	})
+}
+
+// updateServiceEnvOverride applies change to the service's saved overrides and reloads them
+func (a *App) updateServiceEnvOverride(name, key string, change func(vars map[string]string)) error {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return err
+	}
+	if config.GetServiceByName(name) == nil {
+		return fmt.Errorf("unknown service: %s", name)
+	}
+	if key == "" || strings.ContainsAny(key, "= \t\n") {
+		return fmt.Errorf("invalid variable name %q", key)
+	}
+
+	a.settingsMu.Lock()
+	defer a.settingsMu.Unlock()
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+	if settings.ServiceEnvOverrides == nil {
+		settings.ServiceEnvOverrides = make(map[string]map[string]string)
+	}
+	vars := settings.ServiceEnvOverrides[name]
+	if vars == nil {
+		vars = make(map[string]string)
+		settings.ServiceEnvOverrides[name] = vars
+	}
+	change(vars)
+	if len(vars) == 0 {
+		delete(settings.ServiceEnvOverrides, name)
+	}
+
+	if err := config.SaveSettings(a.appDataDir, settings); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	config.SetServiceEnvOverrides(settings.ServiceEnvOverrides)
+	return nil
+}
+
+// RestartBackendService stops a backend service and starts it again (e.g. after a crash or to pick up new code).
+// Open log streams for the service end with "[Restarting ...]"; the frontend resubscribes to the new process.
+func (a *App) RestartBackendService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("service name required")
+	}
+	if err := a.processManager.Restart(name); err != nil {
+		return nil, fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+	a.emitEvent("devkit:backend:started", map[string]interface{}{"name": name})
+	a.recordActivity(model.ActivityEvent{Type: "backend", Target: name, Success: true, Message: "Restarted"})
+	a.emitEvent("devkit:backend:logs", map[string]interface{}{
+		"name": name,
+		"line": "Restarted",
+	})
+	return map[string]string{"message": fmt.Sprintf("Restarted %s", name)}, nil
+}
+
 // StopBackendService stops a specific backend service
 func (a *App) StopBackendService(name string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return nil, err
+	}
 	if name == "" {
 		return nil, fmt.Errorf("service name required")
 	}
@@ -1081,7 +2006,7 @@ func (a *App) StopBackendService(name string) (map[string]string, error) {
 	if svc != nil && svc.Port > 0 {
 		_ = a.processManager.KillProcessOnPort(svc.Port)
 	}
-	runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+	a.emitEvent("devkit:backend:logs", map[string]interface{}{
 		"name": name,
 		"line": "Stopped",
 	})
@@ -1090,6 +2015,9 @@ func (a *App) StopBackendService(name string) (map[string]string, error) {
 
 // StartBackendGroup starts all services in a group
 func (a *App) StartBackendGroup(group string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return nil, err
+	}
 	if group == "" {
 		return nil, fmt.Errorf("group name required")
 	}
@@ -1097,8 +2025,9 @@ func (a *App) StartBackendGroup(group string) (map[string]string, error) {
 		return nil, fmt.Errorf("failed to start group %s: %w", group, err)
 	}
 	for _, svc := range config.GetServicesByGroup(group) {
-		runtime.EventsEmit(a.ctx, "devkit:backend:started", map[string]interface{}{"name": svc.Name})
-		runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+		a.emitEvent("devkit:backend:started", map[string]interface{}{"name": svc.Name})
+		a.recordActivity(model.ActivityEvent{Type: "backend", Target: svc.Name, Success: true, Message: "Started"})
+		a.emitEvent("devkit:backend:logs", map[string]interface{}{
 			"name": svc.Name,
 			"line": "Started",
 		})
@@ -1108,6 +2037,9 @@ func (a *App) StartBackendGroup(group string) (map[string]string, error) {
 
 // StopBackendGroup stops all services in a group
 func (a *App) StopBackendGroup(group string) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return nil, err
+	}
 	if group == "" {
 		return nil, fmt.Errorf("group name required")
 	}
@@ -1115,7 +2047,7 @@ func (a *App) StopBackendGroup(group string) (map[string]string, error) {
 		return nil, fmt.Errorf("failed to stop group %s: %w", group, err)
 	}
 	for _, svc := range config.GetServicesByGroup(group) {
-		runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+		a.emitEvent("devkit:backend:logs", map[string]interface{}{
 			"name": svc.Name,
 			"line": "Stopped",
 		})
@@ -1123,35 +2055,30 @@ func (a *App) StopBackendGroup(group string) (map[string]string, error) {
 	return map[string]string{"message": fmt.Sprintf("Stopped all services in %s group", group)}, nil
 }
 
-// StartBackendLogsStream starts streaming backend service logs
+// StartBackendLogsStream starts streaming backend service logs, emitting only lines that contain
+// filter (or match it as a regex when regex is set); an empty filter emits every line. Each event
+// carries the line's level, stream and timestamp; the service's recent output is sent first with replayed set.
 // Emits: devkit:backend:logs and devkit:backend:logs:done
-func (a *App) StartBackendLogsStream(name string) error {
+func (a *App) StartBackendLogsStream(name, filter string, regex bool) error {
 	if name == "" {
 		return fmt.Errorf("service name required")
 	}
+	matches, err := newLineFilter(filter, regex)
+	if err != nil {
+		return err
+	}
 
 	streamID := fmt.Sprintf("backend:logs:%s", name)
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
-	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+	ctx, stream := a.startStream(streamID)
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
+		defer a.finishStream(stream)
 
 		// Subscribe to logs
 		logCh, unsubscribe := a.processManager.SubscribeLogs(name)
 		defer unsubscribe()
 
-		runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:backend:logs", map[string]interface{}{
 			"name": name,
 			"line": fmt.Sprintf("[Connected to %s logs]", name),
 		})
@@ -1162,18 +2089,25 @@ func (a *App) StartBackendLogsStream(name string) error {
 				return
 			case line, ok := <-logCh:
 				if !ok {
-					runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+					stream.emit(a.ctx, "devkit:backend:logs", map[string]interface{}{
 						"name": name,
 						"line": "[Log stream ended]",
 					})
-					runtime.EventsEmit(a.ctx, "devkit:backend:logs:done", map[string]interface{}{
+					stream.emit(a.ctx, "devkit:backend:logs:done", map[string]interface{}{
 						"name": name,
 					})
 					return
 				}
-				runtime.EventsEmit(a.ctx, "devkit:backend:logs", map[string]interface{}{
-					"name": name,
-					"line": line,
+				if !matches.match(line.Text) {
+					continue
+				}
+				stream.emit(a.ctx, "devkit:backend:logs", map[string]interface{}{
+					"name":      name,
+					"line":      line.Text,
+					"level":     line.Level,
+					"stream":    line.Stream,
+					"timestamp": line.Timestamp,
+					"replayed":  line.Replayed, // recent history sent on connect; the UI greys these out
 				})
 			}
 		}
@@ -1185,12 +2119,7 @@ func (a *App) StartBackendLogsStream(name string) error {
 // StopBackendLogsStream stops an active backend logs stream
 func (a *App) StopBackendLogsStream(name string) {
 	streamID := fmt.Sprintf("backend:logs:%s", name)
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
-	}
-	a.streamMu.Unlock()
+	a.stopStream(streamID)
 }
 
 // ====================
@@ -1202,73 +2131,310 @@ func (a *App) GetMigrationStatus() (*model.MigrationStatus, error) {
 	return a.migrationSvc.GetStatus()
 }
 
+// TestDatabaseConnection checks that DATABASE_URL from .env accepts connections (SELECT 1), so a
+// down or misconfigured database is reported clearly before migrating
+func (a *App) TestDatabaseConnection() map[string]interface{} {
+	if err := a.migrationSvc.TestConnection(); err != nil {
+		return map[string]interface{}{"ok": false, "message": err.Error()}
+	}
+	return map[string]interface{}{"ok": true, "message": "Connected to Postgres"}
+}
+
+// RefreshMigrationStatus returns the current migration status, re-probing the current version instead
+// of using the briefly cached one (for an explicit refresh in the UI)
+func (a *App) RefreshMigrationStatus() (*model.MigrationStatus, error) {
+	return a.migrationSvc.RefreshStatus()
+}
+
+// dbStartTimeout bounds how long an auto-start waits for PostgreSQL to become healthy
+const dbStartTimeout = 60 * time.Second
+
+// SetAutoStartDB controls whether migrations start PostgreSQL automatically when it is down
+func (a *App) SetAutoStartDB(enabled bool) {
+	a.migrationSvc.SetAutoStart(enabled)
+}
+
+// StartDatabase starts the PostgreSQL container and waits until it reports healthy
+func (a *App) StartDatabase() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
+	if err := service.StartService("PostgreSQL", a.devkitRoot); err != nil {
+		return nil, fmt.Errorf("failed to start PostgreSQL: %w", err)
+	}
+	if err := service.WaitForServiceHealthy("PostgreSQL", dbStartTimeout); err != nil {
+		return nil, err
+	}
+	return map[string]string{"message": "PostgreSQL is running"}, nil
+}
+
 // RunMigrationUp runs pending migrations
 func (a *App) RunMigrationUp() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
 	output, err := a.migrationSvc.Up()
 	if err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "migration", Target: "up", Success: false, Message: "Migration failed: " + err.Error()})
 		return nil, fmt.Errorf("migration failed: %w\n%s", err, output)
 	}
+	a.recordActivity(model.ActivityEvent{Type: "migration", Target: "up", Success: true, Message: "Migrations applied"})
 	return map[string]string{"message": "Migrations applied", "output": output}, nil
 }
 
-// RunMigrationDown rolls back the last migration
-func (a *App) RunMigrationDown() (map[string]string, error) {
-	output, err := a.migrationSvc.Down()
-	if err != nil {
-		return nil, fmt.Errorf("migration rollback failed: %w\n%s", err, output)
-	}
-	return map[string]string{"message": "Migration rolled back", "output": output}, nil
+// RunMigrationDownN rolls back the last n migrations (1 to the number applied)
+func (a *App) RunMigrationDownN(n int) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
+	output, err := a.migrationSvc.DownN(n)
+	if err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "migration", Target: fmt.Sprintf("down %d", n), Success: false, Message: "Rollback failed: " + err.Error()})
+		return nil, fmt.Errorf("migration rollback failed: %w\n%s", err, output)
+	}
+	a.recordActivity(model.ActivityEvent{Type: "migration", Target: fmt.Sprintf("down %d", n), Success: true, Message: fmt.Sprintf("Rolled back %d migrations", n)})
+	return map[string]string{"message": fmt.Sprintf("Rolled back %d migrations", n), "output": output}, nil
+}
+
+// RunMigrationGoto migrates up or down to the given version, which must be one of the known migrations
+func (a *App) RunMigrationGoto(version uint) (map[string]string, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
+	output, err := a.migrationSvc.Goto(version)
+	if err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "migration", Target: fmt.Sprintf("goto %d", version), Success: false, Message: "Migration failed: " + err.Error()})
+		return nil, fmt.Errorf("migration to version %d failed: %w\n%s", version, err, output)
+	}
+	a.recordActivity(model.ActivityEvent{Type: "migration", Target: fmt.Sprintf("goto %d", version), Success: true, Message: fmt.Sprintf("Migrated to version %d", version)})
+	return map[string]string{"message": fmt.Sprintf("Migrated to version %d", version), "output": output}, nil
+}
+
+// RunMigrationDown rolls back the last migration
+func (a *App) RunMigrationDown() (map[string]string, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
+	output, err := a.migrationSvc.Down()
+	if err != nil {
+		a.recordActivity(model.ActivityEvent{Type: "migration", Target: "down", Success: false, Message: "Rollback failed: " + err.Error()})
+		return nil, fmt.Errorf("migration rollback failed: %w\n%s", err, output)
+	}
+	a.recordActivity(model.ActivityEvent{Type: "migration", Target: "down", Success: true, Message: "Migration rolled back"})
+	return map[string]string{"message": "Migration rolled back", "output": output}, nil
+}
+
+// CreateMigration writes an empty up/down migration pair with the next version number and opens
+// them in the editor (Cursor or VSCode) when one is found
+func (a *App) CreateMigration(name string) (map[string]interface{}, error) {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return nil, err
+	}
+	paths, err := a.migrationSvc.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("Created %s", filepath.Base(paths[0]))
+	if err := service.OpenFiles(paths...); err != nil {
+		message += fmt.Sprintf(" (not opened: %v)", err)
+	}
+	return map[string]interface{}{"message": message, "files": paths}, nil
+}
+
+// StartMigrationStream starts streaming migration output
+// Emits: devkit:migration:stream and devkit:migration:stream:done
+func (a *App) StartMigrationStream(action string) error {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return err
+	}
+	if action != "up" && action != "down" {
+		return fmt.Errorf("invalid action (use 'up' or 'down')")
+	}
+
+	streamID := fmt.Sprintf("migration:%s", action)
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "migration", Params: []string{action}, At: time.Now()}
+
+	go a.streamMigration(ctx, stream, action, func(ctx context.Context) (<-chan string, error) {
+		if action == "up" {
+			return a.migrationSvc.UpStream(ctx)
+		}
+		return a.migrationSvc.DownStream(ctx)
+	})
+
+	return nil
+}
+
+// StartMigrationGotoStream migrates up or down to version (one of the known migrations) and streams
+// output, as the "goto" migration action.
+// Emits: devkit:migration:stream and devkit:migration:stream:done
+func (a *App) StartMigrationGotoStream(version uint) error {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return err
+	}
+	const action = "goto"
+	ctx, stream := a.startStream("migration:" + action)
+	stream.op = &operation{Kind: "migration-goto", Params: []string{strconv.FormatUint(uint64(version), 10)}, At: time.Now()}
+
+	go a.streamMigration(ctx, stream, action, func(ctx context.Context) (<-chan string, error) {
+		return a.migrationSvc.GotoStream(ctx, version)
+	})
+
+	return nil
+}
+
+// StartMigrationDownNStream rolls back the last n migrations and streams output, as the "down-n"
+// migration action.
+// Emits: devkit:migration:stream and devkit:migration:stream:done
+func (a *App) StartMigrationDownNStream(n int) error {
+	if err := a.requireCommand(service.CommandMigrations); err != nil {
+		return err
+	}
+	const action = "down-n"
+	ctx, stream := a.startStream("migration:" + action)
+	stream.op = &operation{Kind: "migration-down-n", Params: []string{strconv.Itoa(n)}, At: time.Now()}
+
+	go a.streamMigration(ctx, stream, action, func(ctx context.Context) (<-chan string, error) {
+		return a.migrationSvc.DownNStream(ctx, n)
+	})
+
+	return nil
+}
+
+// streamMigration runs the migration opened by run and forwards its output
+// as devkit:migration:stream events, finishing with devkit:migration:stream:done
+func (a *App) streamMigration(ctx context.Context, stream *activeStream, action string, run func(ctx context.Context) (<-chan string, error)) {
+	defer a.finishStream(stream)
+
+	outputCh, err := run(ctx)
+	if err != nil {
+		stream.emit(a.ctx, "devkit:migration:stream", map[string]interface{}{
+			"action": action,
+			"line":   fmt.Sprintf("[Error] %v", err),
+		})
+		stream.emit(a.ctx, "devkit:migration:stream:done", map[string]interface{}{
+			"action":       action,
+			"success":      false,
+			"error":        err.Error(),
+			"databaseDown": errors.Is(err, service.ErrDatabaseDown),
+		})
+		return
+	}
+
+	stream.emit(a.ctx, "devkit:migration:stream", map[string]interface{}{
+		"action": action,
+		"line":   fmt.Sprintf("[Starting migration %s...]", action),
+	})
+
+	failed := false
+	for {
+		select {
+		case <-ctx.Done():
+			a.emitMigrationCancelled(stream, action, outputCh)
+			return
+		case line, ok := <-outputCh:
+			if !ok {
+				stream.emit(a.ctx, "devkit:migration:stream:done", map[string]interface{}{
+					"action":  action,
+					"success": !failed,
+				})
+				return
+			}
+			if strings.HasPrefix(line, "[error] Migration failed") {
+				failed = true
+			}
+			stream.emit(a.ctx, "devkit:migration:stream", map[string]interface{}{
+				"action": action,
+				"line":   line,
+			})
+		}
+	}
+}
+
+// emitMigrationCancelled waits for a cancelled migration's process to exit (outputCh closes once it
+// has), then re-reads the migration status and emits devkit:migration:stream:done with cancelled set,
+// so the UI can prompt to force-resolve a dirty database.
+func (a *App) emitMigrationCancelled(stream *activeStream, action string, outputCh <-chan string) {
+	for range outputCh {
+	}
+	if a.ctx.Err() != nil {
+		return // app shutting down
+	}
+
+	payload := map[string]interface{}{
+		"action":    action,
+		"success":   false,
+		"cancelled": true,
+	}
+	status, err := a.migrationSvc.GetStatus()
+	if err != nil {
+		payload["error"] = fmt.Sprintf("failed to read migration status: %v", err)
+	} else {
+		payload["status"] = status
+		payload["dirty"] = status.Dirty
+	}
+	stream.emit(a.ctx, "devkit:migration:stream:done", payload)
+}
+
+// StopMigrationStream stops an active migration stream
+func (a *App) StopMigrationStream(action string) {
+	streamID := fmt.Sprintf("migration:%s", action)
+	a.stopStream(streamID)
+}
+
+// ====================
+// Proto (codegen) API
+// ====================
+
+// GetProtoStatus returns whether generated protobuf code is out of date
+func (a *App) GetProtoStatus() (*model.ProtoStatus, error) {
+	return a.protoSvc.GetStatus()
+}
+
+// StartProtoStream runs make proto in wabisaby-protos and streams output
+// Emits: devkit:proto:stream and devkit:proto:stream:done
+func (a *App) StartProtoStream() error {
+	return a.StartProtoStreamTarget(service.ProtoTargetAll)
 }
 
-// StartMigrationStream starts streaming migration output
-// Emits: devkit:migration:stream and devkit:migration:stream:done
-func (a *App) StartMigrationStream(action string) error {
-	if action != "up" && action != "down" {
-		return fmt.Errorf("invalid action (use 'up' or 'down')")
+// StartProtoStreamTarget generates protobuf code for one target (e.g. "plugin" or "node"; see
+// ProtoTargets) and streams output like StartProtoStream. Replaces any running generation.
+func (a *App) StartProtoStreamTarget(target string) error {
+	if err := a.requireCommand(service.CommandProtobuf); err != nil {
+		return err
 	}
-
-	streamID := fmt.Sprintf("migration:%s", action)
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
+	if target == "" {
+		target = service.ProtoTargetAll
+	}
+	if err := a.protoSvc.CheckTarget(target); err != nil {
+		return err
 	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
-
-	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
 
-		var outputCh <-chan string
-		var err error
+	streamID := "proto:generate"
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "proto", Params: []string{target}, At: time.Now()}
 
-		if action == "up" {
-			outputCh, err = a.migrationSvc.UpStream(ctx)
-		} else {
-			outputCh, err = a.migrationSvc.DownStream(ctx)
-		}
+	go func() {
+		defer a.finishStream(stream)
 
+		outputCh, err := a.protoSvc.GenerateTarget(ctx, target)
 		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:migration:stream", map[string]interface{}{
-				"action": action,
-				"line":   fmt.Sprintf("[Error] %v", err),
+			stream.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+				"line": fmt.Sprintf("[Error] %v", err),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:migration:stream:done", map[string]interface{}{
-				"action":  action,
+			stream.emit(a.ctx, "devkit:proto:stream:done", map[string]interface{}{
 				"success": false,
 				"error":   err.Error(),
 			})
 			return
 		}
 
-		runtime.EventsEmit(a.ctx, "devkit:migration:stream", map[string]interface{}{
-			"action": action,
-			"line":   fmt.Sprintf("[Starting migration %s...]", action),
+		startLine := "[Starting protobuf code generation...]"
+		if target != service.ProtoTargetAll {
+			startLine = fmt.Sprintf("[Starting protobuf code generation (%s)...]", target)
+		}
+		stream.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+			"line": startLine,
 		})
 
 		for {
@@ -1277,15 +2443,13 @@ func (a *App) StartMigrationStream(action string) error {
 				return
 			case line, ok := <-outputCh:
 				if !ok {
-					runtime.EventsEmit(a.ctx, "devkit:migration:stream:done", map[string]interface{}{
-						"action":  action,
+					stream.emit(a.ctx, "devkit:proto:stream:done", map[string]interface{}{
 						"success": true,
 					})
 					return
 				}
-				runtime.EventsEmit(a.ctx, "devkit:migration:stream", map[string]interface{}{
-					"action": action,
-					"line":   line,
+				stream.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+					"line": line,
 				})
 			}
 		}
@@ -1294,74 +2458,89 @@ func (a *App) StartMigrationStream(action string) error {
 	return nil
 }
 
-// StopMigrationStream stops an active migration stream
-func (a *App) StopMigrationStream(action string) {
-	streamID := fmt.Sprintf("migration:%s", action)
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
-	}
-	a.streamMu.Unlock()
+// ProtoTargets returns the targets accepted by StartProtoStreamTarget
+func (a *App) ProtoTargets() []string {
+	return a.protoSvc.Targets()
 }
 
-// ====================
-// Proto (codegen) API
-// ====================
+// StopProtoStream stops an active proto generation stream
+func (a *App) StopProtoStream() {
+	streamID := "proto:generate"
+	a.stopStream(streamID)
+}
 
-// GetProtoStatus returns whether generated protobuf code is out of date
-func (a *App) GetProtoStatus() (*model.ProtoStatus, error) {
-	return a.protoSvc.GetStatus()
+// CheckProtoBreaking runs buf breaking in wabisaby-protos against main. Returns available=false
+// with a message when buf is not installed, otherwise the breaking changes found (empty = compatible).
+func (a *App) CheckProtoBreaking() (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(a.ctx, 2*time.Minute)
+	defer cancel()
+	breaks, err := a.protoSvc.CheckBreaking(ctx)
+	if errors.Is(err, service.ErrBufNotInstalled) {
+		return map[string]interface{}{
+			"available": false,
+			"message":   err.Error(),
+			"breaks":    []model.ProtoBreak{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	message := "No breaking changes"
+	if len(breaks) > 0 {
+		message = fmt.Sprintf("%d breaking change(s) against main", len(breaks))
+	}
+	return map[string]interface{}{
+		"available": true,
+		"message":   message,
+		"breaks":    breaks,
+	}, nil
 }
 
-// StartProtoStream runs make proto in wabisaby-protos and streams output
-// Emits: devkit:proto:stream and devkit:proto:stream:done
-func (a *App) StartProtoStream() error {
-	streamID := "proto:generate"
-	ctx, cancel := context.WithCancel(a.ctx)
+// LintProtos runs buf lint in wabisaby-protos and returns the issues found (empty = clean).
+// Fails with an "install buf" error when buf is not on PATH.
+func (a *App) LintProtos() ([]model.ProtoLintIssue, error) {
+	ctx, cancel := context.WithTimeout(a.ctx, 2*time.Minute)
+	defer cancel()
+	return a.protoSvc.Lint(ctx)
+}
 
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
-	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+// StartProtoLintStream runs buf lint in wabisaby-protos and streams issues as they are reported
+// Emits: devkit:proto:lint:stream and devkit:proto:lint:stream:done
+func (a *App) StartProtoLintStream() error {
+	streamID := "proto:lint"
+	ctx, stream := a.startStream(streamID)
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
+		defer a.finishStream(stream)
 
-		outputCh, err := a.protoSvc.RunProtoStream(ctx)
+		outputCh, err := a.protoSvc.LintStream(ctx)
 		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:proto:lint:stream", map[string]interface{}{
 				"line": fmt.Sprintf("[Error] %v", err),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:proto:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:proto:lint:stream:done", map[string]interface{}{
 				"success": false,
 				"error":   err.Error(),
 			})
 			return
 		}
 
-		runtime.EventsEmit(a.ctx, "devkit:proto:stream", map[string]interface{}{
-			"line": "[Starting protobuf code generation...]",
-		})
-
+		success := true
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case line, ok := <-outputCh:
 				if !ok {
-					runtime.EventsEmit(a.ctx, "devkit:proto:stream:done", map[string]interface{}{
-						"success": true,
+					stream.emit(a.ctx, "devkit:proto:lint:stream:done", map[string]interface{}{
+						"success": success,
 					})
 					return
 				}
-				runtime.EventsEmit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+				if strings.HasPrefix(line, "[error]") {
+					success = false
+				}
+				stream.emit(a.ctx, "devkit:proto:lint:stream", map[string]interface{}{
 					"line": line,
 				})
 			}
@@ -1371,45 +2550,76 @@ func (a *App) StartProtoStream() error {
 	return nil
 }
 
-// StopProtoStream stops an active proto generation stream
-func (a *App) StopProtoStream() {
-	streamID := "proto:generate"
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
+// StopProtoLintStream stops an active proto lint stream
+func (a *App) StopProtoLintStream() {
+	a.stopStream("proto:lint")
+}
+
+// StartProtoWatch watches wabisaby-protos for .proto changes and regenerates code automatically
+// until StopProtoWatch. Generation output goes to devkit:proto:stream like StartProtoStream;
+// devkit:proto:watch:done is emitted if watching stops on its own.
+func (a *App) StartProtoWatch() error {
+	if err := a.requireCommand(service.CommandProtobuf); err != nil {
+		return err
+	}
+	streamID := "proto:watch"
+	ctx, stream := a.startStream(streamID)
+
+	outputCh, err := a.protoSvc.WatchStream(ctx)
+	if err != nil {
+		a.finishStream(stream)
+		return err
 	}
-	a.streamMu.Unlock()
+
+	go func() {
+		defer a.finishStream(stream)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case line, ok := <-outputCh:
+				if !ok {
+					if ctx.Err() == nil {
+						stream.emit(a.ctx, "devkit:proto:watch:done", map[string]interface{}{})
+					}
+					return
+				}
+				stream.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{
+					"line":  line,
+					"watch": true,
+				})
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopProtoWatch stops watching proto sources (a regeneration in progress is cancelled)
+func (a *App) StopProtoWatch() {
+	a.stopStream("proto:watch")
 }
 
 // StartReleaseProtosGoStream runs scripts/release-protos-go.sh from DevKit root and streams output.
 // version is optional: empty = generate only (preview); e.g. "v0.0.2" = commit and tag.
 // Emits: devkit:release-protos-go:stream and devkit:release-protos-go:stream:done
 func (a *App) StartReleaseProtosGoStream(version string) error {
-	streamID := "release-protos-go"
-	ctx, cancel := context.WithCancel(a.ctx)
-
-	a.streamMu.Lock()
-	if existing, ok := a.activeStreams[streamID]; ok {
-		existing()
+	if err := a.requireCommand(service.CommandProtobuf); err != nil {
+		return err
 	}
-	a.activeStreams[streamID] = cancel
-	a.streamMu.Unlock()
+	streamID := "release-protos-go"
+	ctx, stream := a.startStream(streamID)
+	stream.op = &operation{Kind: "release-protos-go", Params: []string{version}, At: time.Now()}
 
 	scriptPath := filepath.Join(a.devkitRoot, "scripts", "release-protos-go.sh")
 	if _, err := os.Stat(scriptPath); err != nil {
-		a.streamMu.Lock()
-		delete(a.activeStreams, streamID)
-		a.streamMu.Unlock()
+		a.finishStream(stream)
 		return fmt.Errorf("release script not found at %s: %w", scriptPath, err)
 	}
 
 	go func() {
-		defer func() {
-			a.streamMu.Lock()
-			delete(a.activeStreams, streamID)
-			a.streamMu.Unlock()
-		}()
+		defer a.finishStream(stream)
 
 		args := []string{}
 		if version != "" {
@@ -1417,21 +2627,22 @@ func (a *App) StartReleaseProtosGoStream(version string) error {
 		}
 		cmd := exec.CommandContext(ctx, scriptPath, args...)
 		cmd.Dir = a.devkitRoot
+		service.CancelProcessGroup(cmd)
 
 		stdout, _ := cmd.StdoutPipe()
 		stderr, _ := cmd.StderrPipe()
 		if err := cmd.Start(); err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
 				"line": fmt.Sprintf("[Error] %v", err),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
 				"success": false,
 				"error":   err.Error(),
 			})
 			return
 		}
 
-		runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
 			"line": "[Starting release-protos-go...]",
 		})
 
@@ -1442,7 +2653,7 @@ func (a *App) StartReleaseProtosGoStream(version string) error {
 				case <-ctx.Done():
 					return
 				default:
-					runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
+					stream.emit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
 						"line": scanner.Text(),
 					})
 				}
@@ -1452,24 +2663,27 @@ func (a *App) StartReleaseProtosGoStream(version string) error {
 		emitLine(stderr)
 
 		err := cmd.Wait()
+		if cmd.ProcessState != nil {
+			stream.setExitCode(cmd.ProcessState.ExitCode())
+		}
 		if ctx.Err() != nil {
-			runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
 				"success": false,
 				"error":   "cancelled",
 			})
 			return
 		}
 		if err != nil {
-			runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:release-protos-go:stream", map[string]interface{}{
 				"line": fmt.Sprintf("[error] %v", err),
 			})
-			runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
+			stream.emit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
 				"success": false,
 				"error":   err.Error(),
 			})
 			return
 		}
-		runtime.EventsEmit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
+		stream.emit(a.ctx, "devkit:release-protos-go:stream:done", map[string]interface{}{
 			"success": true,
 		})
 	}()
@@ -1480,21 +2694,29 @@ func (a *App) StartReleaseProtosGoStream(version string) error {
 // StopReleaseProtosGoStream stops an active release-protos-go stream
 func (a *App) StopReleaseProtosGoStream() {
 	streamID := "release-protos-go"
-	a.streamMu.Lock()
-	if cancel, ok := a.activeStreams[streamID]; ok {
-		cancel()
-		delete(a.activeStreams, streamID)
-	}
-	a.streamMu.Unlock()
+	a.stopStream(streamID)
 }
 
 // ====================
 // Environment API
 // ====================
 
-// GetEnvStatus returns the environment configuration status
+// GetEnvStatus returns the environment configuration status, with sensitive values masked
+// (use RevealEnvVar to read one)
 func (a *App) GetEnvStatus() (*model.EnvStatus, error) {
-	return a.envSvc.GetStatus()
+	return a.envSvc.GetStatus(false)
+}
+
+// RevealEnvVar returns the real value of a .env var, e.g. one masked by GetEnvStatus.
+// Requires Backend permissions.
+func (a *App) RevealEnvVar(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("variable name is required")
+	}
+	if err := a.requireCommand(service.CommandBackend); err != nil {
+		return "", err
+	}
+	return a.envSvc.RevealVar(name)
 }
 
 // CopyEnvExample copies env.example to .env
@@ -1505,23 +2727,24 @@ func (a *App) CopyEnvExample() (map[string]string, error) {
 	return map[string]string{"message": "Copied env.example to .env"}, nil
 }
 
-// ValidateEnv validates the environment configuration
+// ValidateEnv validates the environment configuration: "missing" lists unset required vars,
+// "errors" lists set vars whose values don't match their declared type
 func (a *App) ValidateEnv() (map[string]interface{}, error) {
-	missing, err := a.envSvc.Validate()
+	missing, invalid, err := a.envSvc.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
-
-	if len(missing) > 0 {
-		return map[string]interface{}{
-			"valid":   false,
-			"missing": missing,
-		}, nil
+	if missing == nil {
+		missing = []string{}
+	}
+	if invalid == nil {
+		invalid = []model.EnvValidationError{}
 	}
 
 	return map[string]interface{}{
-		"valid":   true,
-		"missing": []string{},
+		"valid":   len(missing) == 0 && len(invalid) == 0,
+		"missing": missing,
+		"errors":  invalid,
 	}, nil
 }
 
@@ -1541,6 +2764,70 @@ func (a *App) DeleteEnvVar(name string) error {
 	return nil
 }
 
+// EnvDiff compares .env with env.example: "added" lists vars in env.example missing from .env,
+// "removed" lists vars in .env that env.example doesn't have
+func (a *App) EnvDiff() (map[string][]string, error) {
+	added, removed, err := a.envSvc.DiffExample()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff env: %w", err)
+	}
+	return map[string][]string{"added": added, "removed": removed}, nil
+}
+
+// SyncMissingEnvVars appends vars from env.example that are missing in .env, using their example values
+func (a *App) SyncMissingEnvVars() (map[string]string, error) {
+	added, err := a.envSvc.SyncMissing()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync env vars: %w", err)
+	}
+	if len(added) == 0 {
+		return map[string]string{"message": ".env already has every var from env.example"}, nil
+	}
+	return map[string]string{"message": fmt.Sprintf("Added %d var(s) from env.example: %s", len(added), strings.Join(added, ", "))}, nil
+}
+
+// ExportEnv returns the current .env for sharing; sensitive values are emptied unless includeSensitive
+// is set, which (like RevealEnvVar) requires Backend permissions
+func (a *App) ExportEnv(includeSensitive bool) (string, error) {
+	if includeSensitive {
+		if err := a.requireCommand(service.CommandBackend); err != nil {
+			return "", err
+		}
+	}
+	return a.envSvc.Export(includeSensitive)
+}
+
+// ImportEnv merges .env-formatted content into .env (replacing existing values only when overwrite
+// is set) and reports which keys were added, updated or skipped
+func (a *App) ImportEnv(content string, overwrite bool) (*model.EnvImportSummary, error) {
+	summary, err := a.envSvc.Import(content, overwrite)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import env: %w", err)
+	}
+	return summary, nil
+}
+
+// ListEnvProfiles returns the available .env profiles (.env.<profile> files next to .env)
+func (a *App) ListEnvProfiles() ([]string, error) {
+	return a.envSvc.ListProfiles()
+}
+
+// ActivateEnvProfile replaces .env with the given profile, backing the current .env up to .env.bak
+func (a *App) ActivateEnvProfile(name string) (map[string]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+	if err := a.envSvc.ActivateProfile(name); err != nil {
+		return nil, fmt.Errorf("failed to activate env profile: %w", err)
+	}
+	return map[string]string{"message": fmt.Sprintf("Activated env profile %s (previous .env saved to .env.bak)", name)}, nil
+}
+
+// CurrentEnvProfile returns the profile .env currently matches, or "" if none
+func (a *App) CurrentEnvProfile() (string, error) {
+	return a.envSvc.CurrentProfile()
+}
+
 // ====================
 // Prerequisites API
 // ====================
@@ -1554,7 +2841,7 @@ func (a *App) GetPrerequisites() ([]model.Prerequisite, error) {
 // Notices API
 // ====================
 
-// GetNotices returns aggregated dashboard notices (sync, proto, migration, env, docker)
+// GetNotices returns aggregated dashboard notices (sync, proto, migration, env, docker, ports)
 func (a *App) GetNotices() ([]model.Notice, error) {
 	var notices []model.Notice
 
@@ -1587,6 +2874,29 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 		})
 	}
 
+	// Breaking proto changes found by the last CheckProtoBreaking
+	if breaks := a.protoSvc.LastBreaking(); len(breaks) > 0 {
+		notices = append(notices, model.Notice{
+			ID:        "proto-breaking",
+			Severity:  "error",
+			Message:   fmt.Sprintf("%d breaking proto change(s) against main", len(breaks)),
+			ActionKey: "proto",
+		})
+	}
+
+	// Sibling projects checked out at a different version than go.mod requires
+	if mismatches, err := service.CheckDependencyVersions(a.projectsDir); err == nil && len(mismatches) > 0 {
+		msgs := make([]string, 0, len(mismatches))
+		for _, m := range mismatches {
+			msgs = append(msgs, fmt.Sprintf("%s needs %s %s (local: %s)", m.Project, m.Dependency, m.Required, m.Local))
+		}
+		notices = append(notices, model.Notice{
+			ID:       "deps",
+			Severity: "warn",
+			Message:  "Dependency version mismatch: " + strings.Join(msgs, "; "),
+		})
+	}
+
 	// Migrations pending or dirty
 	migStatus, err := a.migrationSvc.GetStatus()
 	if err == nil && migStatus != nil {
@@ -1616,7 +2926,7 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 	}
 
 	// Env missing or invalid
-	envStatus, err := a.envSvc.GetStatus()
+	envStatus, err := a.envSvc.GetStatus(false)
 	if err == nil && envStatus != nil {
 		if !envStatus.HasEnvFile {
 			notices = append(notices, model.Notice{
@@ -1626,7 +2936,7 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 				ActionKey: "env",
 			})
 		} else {
-			missing, errVal := a.envSvc.Validate()
+			missing, invalid, errVal := a.envSvc.Validate()
 			if errVal == nil && len(missing) > 0 {
 				notices = append(notices, model.Notice{
 					ID:        "env",
@@ -1635,11 +2945,38 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 					ActionKey: "env",
 				})
 			}
+			if errVal == nil && len(invalid) > 0 {
+				reasons := make([]string, 0, len(invalid))
+				for _, e := range invalid {
+					reasons = append(reasons, e.Reason)
+				}
+				notices = append(notices, model.Notice{
+					ID:        "env-invalid",
+					Severity:  "warn",
+					Message:   "Invalid env var(s): " + strings.Join(reasons, "; "),
+					ActionKey: "env",
+				})
+			}
+			if added, _, errDiff := a.envSvc.DiffExample(); errDiff == nil && len(added) > 0 {
+				notices = append(notices, model.Notice{
+					ID:        "env-diff",
+					Severity:  "info",
+					Message:   fmt.Sprintf("env.example has %d var(s) missing from .env: %s", len(added), strings.Join(added, ", ")),
+					ActionKey: "env",
+				})
+			}
 		}
 	}
 
-	// Docker services not running (check Postgres as representative)
-	if service.CheckServiceStatus("PostgreSQL", 5432, a.devkitRoot) != "running" {
+	// Docker daemon unreachable, or services not running (check Postgres as representative)
+	if ok, reason := service.DockerAvailable(); !ok {
+		notices = append(notices, model.Notice{
+			ID:        "docker",
+			Severity:  "error",
+			Message:   reason,
+			ActionKey: "docker",
+		})
+	} else if service.CheckServiceStatus("PostgreSQL", 5432, a.devkitRoot) != "running" {
 		notices = append(notices, model.Notice{
 			ID:        "docker",
 			Severity:  "info",
@@ -1648,10 +2985,32 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 		})
 	}
 
-	// Stable order: by severity (error > warn > info), then by id
+	// Services configured on the same port
+	if collisions := config.CheckPortCollisions(); len(collisions) > 0 {
+		msgs := make([]string, 0, len(collisions))
+		for _, c := range collisions {
+			msgs = append(msgs, c.String())
+		}
+		notices = append(notices, model.Notice{
+			ID:       "ports",
+			Severity: "warn",
+			Message:  "Port collision: " + strings.Join(msgs, "; "),
+		})
+	}
+
+	sortNotices(notices)
+	return notices, nil
+}
+
+// noticeIDOrder orders notices of the same severity; related notices sit next to each other
+var noticeIDOrder = map[string]int{
+	"sync": 0, "proto": 1, "proto-breaking": 2, "deps": 3, "migration": 4,
+	"env": 5, "env-invalid": 6, "env-diff": 7, "docker": 8, "ports": 9,
+}
+
+// sortNotices puts notices in a stable order: by severity (error > warn > info), then by id
+func sortNotices(notices []model.Notice) {
 	order := map[string]int{"error": 0, "warn": 1, "info": 2}
-	idOrder := map[string]int{"sync": 0, "proto": 1, "migration": 2, "env": 3, "docker": 4}
-	// Sort: first by severity order, then by id order
 	for i := 0; i < len(notices); i++ {
 		for j := i + 1; j < len(notices); j++ {
 			si, oki := order[notices[i].Severity]
@@ -1662,13 +3021,203 @@ func (a *App) GetNotices() ([]model.Notice, error) {
 			if !okj {
 				sj = 99
 			}
-			if si > sj || (si == sj && idOrder[notices[i].ID] > idOrder[notices[j].ID]) {
+			if si > sj || (si == sj && noticeIDOrder[notices[i].ID] > noticeIDOrder[notices[j].ID]) {
 				notices[i], notices[j] = notices[j], notices[i]
 			}
 		}
 	}
+}
 
-	return notices, nil
+// GetDashboardOverview assembles projects, Docker and backend service status, a migration summary,
+// .env validity and notices into one snapshot, computing the sections concurrently. A section that
+// fails is reported in its error field instead of failing the whole call.
+func (a *App) GetDashboardOverview() (*model.Overview, error) {
+	overview := &model.Overview{GeneratedAt: time.Now().Format(time.RFC3339)}
+
+	var g errgroup.Group
+	g.Go(func() error {
+		projects, err := service.GetProjects(a.projectsDir)
+		if err != nil {
+			overview.ProjectsError = err.Error()
+		}
+		overview.Projects = projects
+		return nil
+	})
+	g.Go(func() error {
+		overview.DockerConnected = service.IsDockerConnected()
+		overview.Services = a.ListServices()
+		return nil
+	})
+	g.Go(func() error {
+		overview.Backend = a.ListBackendServices()
+		return nil
+	})
+	g.Go(func() error {
+		status, err := a.migrationSvc.GetStatus()
+		if err != nil {
+			overview.MigrationError = err.Error()
+			return nil
+		}
+		summary := &model.MigrationSummary{CurrentVersion: status.CurrentVersion, Dirty: status.Dirty}
+		for _, m := range status.Migrations {
+			if m.Applied {
+				summary.Applied++
+			} else {
+				summary.Pending++
+			}
+		}
+		overview.Migration = summary
+		overview.MigrationError = status.Error
+		return nil
+	})
+	g.Go(func() error {
+		missing, invalid, err := a.envSvc.Validate()
+		if err != nil {
+			overview.EnvError = err.Error()
+		}
+		if missing == nil {
+			missing = []string{}
+		}
+		if invalid == nil {
+			invalid = []model.EnvValidationError{}
+		}
+		_, statErr := os.Stat(filepath.Join(a.wabisabyCorePath, ".env"))
+		overview.Env = &model.EnvValidity{
+			HasEnvFile: statErr == nil,
+			Valid:      err == nil && len(missing) == 0 && len(invalid) == 0,
+			Missing:    missing,
+			Errors:     invalid,
+		}
+		return nil
+	})
+	g.Go(func() error {
+		notices, err := a.GetNotices()
+		if err != nil {
+			overview.NoticesError = err.Error()
+		}
+		overview.Notices = notices
+		return nil
+	})
+	_ = g.Wait()
+
+	return overview, nil
+}
+
+// ====================
+// Doctor API
+// ====================
+
+// RunDoctor runs every setup check (prerequisites, Docker, .env, database, protos, submodules) and
+// returns one report with pass/warn/fail per check and a hint for each problem
+func (a *App) RunDoctor() (*model.DoctorReport, error) {
+	report := &model.DoctorReport{Checks: []model.DoctorCheck{}, RanAt: time.Now().Format(time.RFC3339)}
+	add := func(id, name, status, message, hint string) {
+		report.Checks = append(report.Checks, model.DoctorCheck{ID: id, Name: name, Status: status, Message: message, Hint: hint})
+	}
+
+	// Prerequisites: missing required tools fail, missing optional ones warn
+	var missingRequired, missingOptional []string
+	for _, p := range service.CheckPrerequisites() {
+		switch {
+		case p.Installed:
+		case p.Required:
+			missingRequired = append(missingRequired, p.Name)
+		default:
+			missingOptional = append(missingOptional, p.Name)
+		}
+	}
+	switch {
+	case len(missingRequired) > 0:
+		add("prerequisites", "Prerequisites", "fail", "Missing required tools: "+strings.Join(missingRequired, ", "),
+			"Install the missing tools and make sure they are on PATH")
+	case len(missingOptional) > 0:
+		add("prerequisites", "Prerequisites", "warn", "Missing optional tools: "+strings.Join(missingOptional, ", "),
+			"Install them to enable the related dashboard features")
+	default:
+		add("prerequisites", "Prerequisites", "pass", "All tools installed", "")
+	}
+
+	// Docker daemon
+	if ok, reason := service.DockerAvailable(); ok {
+		add("docker", "Docker", "pass", "Docker daemon is reachable", "")
+	} else {
+		add("docker", "Docker", "fail", reason, "Start Docker Desktop (or the docker service) and retry")
+	}
+
+	// .env
+	envStatus, err := a.envSvc.GetStatus(false)
+	switch {
+	case err != nil:
+		add("env", ".env", "fail", err.Error(), "")
+	case !envStatus.HasEnvFile:
+		add("env", ".env", "fail", "No .env file", "Copy env.example to .env from the Environment view")
+	default:
+		missing, invalid, errVal := a.envSvc.Validate()
+		switch {
+		case errVal != nil:
+			add("env", ".env", "fail", errVal.Error(), "")
+		case len(missing) > 0:
+			add("env", ".env", "fail", "Missing required env var(s): "+strings.Join(missing, ", "), "Set them in the Environment view")
+		case len(invalid) > 0:
+			reasons := make([]string, 0, len(invalid))
+			for _, e := range invalid {
+				reasons = append(reasons, e.Reason)
+			}
+			add("env", ".env", "warn", "Invalid env var(s): "+strings.Join(reasons, "; "), "Fix the values in the Environment view")
+		default:
+			add("env", ".env", "pass", ".env has every required var", "")
+		}
+	}
+
+	// Database
+	if err := a.migrationSvc.TestConnection(); err != nil {
+		add("database", "Database", "fail", err.Error(), "Start PostgreSQL from the Services view and check DATABASE_URL")
+	} else {
+		add("database", "Database", "pass", "Connected to Postgres", "")
+	}
+
+	// Protos
+	protoStatus, err := a.protoSvc.GetStatus()
+	switch {
+	case err != nil:
+		add("proto", "Protos", "warn", err.Error(), "")
+	case protoStatus.OutOfDate:
+		add("proto", "Protos", "warn", protoStatus.Message, "Run make proto from the Proto view")
+	default:
+		add("proto", "Protos", "pass", "Generated code is up to date", "")
+	}
+
+	// Submodules
+	var needsSync []string
+	projects, err := service.GetProjects(a.projectsDir)
+	if err == nil {
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		needsSync, err = git.SubmoduleSyncStatus(a.devkitRoot, a.projectsDir, names)
+	}
+	switch {
+	case err != nil:
+		add("submodules", "Submodules", "warn", err.Error(), "")
+	case len(needsSync) > 0:
+		add("submodules", "Submodules", "warn", "Submodule commits changed: "+strings.Join(needsSync, ", "), "Sync the submodules to DevKit")
+	default:
+		add("submodules", "Submodules", "pass", "Submodules are in sync", "")
+	}
+
+	// Overall status is the worst check
+	report.Status = "pass"
+	for _, c := range report.Checks {
+		if c.Status == "fail" {
+			report.Status = "fail"
+			break
+		}
+		if c.Status == "warn" {
+			report.Status = "warn"
+		}
+	}
+	return report, nil
 }
 
 // ====================
@@ -1682,9 +3231,44 @@ func (a *App) GitHubStartDeviceFlow() (*service.DeviceFlowResponse, error) {
 }
 
 // GitHubPollAuth polls GitHub until the user completes the device flow.
-// Blocks until success, expiry, or denial. Returns computed permissions.
+// Blocks until success, expiry, denial, or CancelDeviceFlow. Returns computed permissions.
+// A cancelled poll's error starts with "device flow cancelled" (user aborted, not a failure).
 func (a *App) GitHubPollAuth() (*service.Permissions, error) {
-	return a.githubSvc.PollForToken()
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.githubPollMu.Lock()
+	if a.githubPollCancel != nil {
+		a.githubPollCancel() // only one poll at a time
+	}
+	a.githubPollCancel = cancel
+	a.githubPollSeq++
+	seq := a.githubPollSeq
+	a.githubPollMu.Unlock()
+
+	defer func() {
+		cancel()
+		a.githubPollMu.Lock()
+		if a.githubPollSeq == seq {
+			a.githubPollCancel = nil
+		}
+		a.githubPollMu.Unlock()
+	}()
+
+	return a.githubSvc.PollForToken(ctx)
+}
+
+// CancelDeviceFlow aborts the in-flight GitHubPollAuth (which then returns a cancelled error) and
+// discards the pending device flow
+func (a *App) CancelDeviceFlow() {
+	a.githubPollMu.Lock()
+	cancel := a.githubPollCancel
+	a.githubPollCancel = nil
+	a.githubPollMu.Unlock()
+
+	if cancel != nil {
+		cancel() // PollForToken clears the device code itself
+		return
+	}
+	a.githubSvc.CancelDeviceFlow()
 }
 
 // GitHubGetStatus returns the current GitHub auth status and cached permissions.
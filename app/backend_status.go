@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// backendStatusKey is the part of a backend service's state whose change triggers devkit:backend:status
+type backendStatusKey struct {
+	Status string
+	PID    int
+	Error  string
+}
+
+// startBackendStatusPoller polls backend service status (list, normally ListBackendServices) every
+// interval and emits devkit:backend:status (all services plus the names that changed) only when a
+// service's status, PID or error changes. A non-positive interval disables polling. The poller stops
+// when ctx is cancelled.
+func (a *App) startBackendStatusPoller(ctx context.Context, interval time.Duration, list func() []model.BackendService) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last map[string]backendStatusKey
+		for {
+			services := list()
+			var changed []string
+			last, changed = diffBackendStatus(last, services)
+			if len(changed) > 0 && ctx.Err() == nil {
+				a.emitEvent("devkit:backend:status", map[string]interface{}{
+					"services": services,
+					"changed":  changed,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// diffBackendStatus returns the new status snapshot and the names of services whose status, PID or
+// error differ from prev. With a nil prev (first poll) every service counts as changed.
+func diffBackendStatus(prev map[string]backendStatusKey, services []model.BackendService) (map[string]backendStatusKey, []string) {
+	next := make(map[string]backendStatusKey, len(services))
+	var changed []string
+	for _, svc := range services {
+		key := backendStatusKey{Status: svc.Status, PID: svc.PID, Error: svc.Error}
+		next[svc.Name] = key
+		if old, ok := prev[svc.Name]; !ok || old != key {
+			changed = append(changed, svc.Name)
+		}
+	}
+	return next, changed
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// scriptedBackends returns a ListBackendServices stand-in that serves snapshots in order (repeating
+// the last) and a channel receiving the number of each call
+func scriptedBackends(snapshots ...[]model.BackendService) (func() []model.BackendService, <-chan int) {
+	var mu sync.Mutex
+	calls := 0
+	called := make(chan int, 100)
+	return func() []model.BackendService {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		i := calls - 1
+		if i >= len(snapshots) {
+			i = len(snapshots) - 1
+		}
+		select {
+		case called <- calls:
+		default:
+		}
+		return snapshots[i]
+	}, called
+}
+
+// waitForCall waits until the poller has called list n times
+func waitForCall(t *testing.T, called <-chan int, n int) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case c := <-called:
+			if c >= n {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("backend status polled fewer than %d times", n)
+		}
+	}
+}
+
+func TestBackendStatusPollerEmitsOnlyOnChange(t *testing.T) {
+	a := newTestApp(t)
+	events := recordEvents(a)
+
+	worker := model.BackendService{Name: "worker", Status: "stopped"}
+	started := []model.BackendService{{Name: "api", Status: "running", PID: 100}, worker}
+	restarted := []model.BackendService{{Name: "api", Status: "running", PID: 200}, worker}
+	list, called := scriptedBackends(started, started, started, restarted, restarted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.startBackendStatusPoller(ctx, time.Millisecond, list)
+	// Each poll emits before the next one lists, so by call 7 the first six polls have been handled
+	waitForCall(t, called, 7)
+	cancel()
+
+	got := events.named("devkit:backend:status")
+	if len(got) != 2 {
+		t.Fatalf("emitted %d status events over 6 polls with one change, want 2 (initial + change)", len(got))
+	}
+	if changed := got[0].payload["changed"]; !reflect.DeepEqual(changed, []string{"api", "worker"}) {
+		t.Errorf("first event changed = %v, want every service", changed)
+	}
+	if changed := got[1].payload["changed"]; !reflect.DeepEqual(changed, []string{"api"}) {
+		t.Errorf("second event changed = %v, want only the restarted api", changed)
+	}
+	if services := got[1].payload["services"]; !reflect.DeepEqual(services, restarted) {
+		t.Errorf("second event services = %v, want the full new snapshot", services)
+	}
+}
+
+func TestBackendStatusPollerSteadyStateIsSilent(t *testing.T) {
+	a := newTestApp(t)
+	events := recordEvents(a)
+	list, called := scriptedBackends([]model.BackendService{{Name: "api", Status: "stopped"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.startBackendStatusPoller(ctx, time.Millisecond, list)
+	waitForCall(t, called, 10)
+	cancel()
+
+	if got := events.named("devkit:backend:status"); len(got) != 1 {
+		t.Errorf("emitted %d status events with nothing changing, want only the initial one", len(got))
+	}
+}
+
+func TestBackendStatusPollerDisabled(t *testing.T) {
+	a := newTestApp(t)
+	list, called := scriptedBackends([]model.BackendService{{Name: "api", Status: "stopped"}})
+
+	a.startBackendStatusPoller(context.Background(), 0, list)
+	select {
+	case <-called:
+		t.Error("poller with a zero interval listed backend services")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
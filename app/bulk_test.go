@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRunBulkProjectBlocksDontInterleave(t *testing.T) {
+	a := newTestApp(t)
+	a.projectsDir = t.TempDir()
+	events := recordEvents(a)
+	names := []string{"alpha", "beta", "gamma"}
+	for _, name := range names {
+		dir := filepath.Join(a.projectsDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		makefile := "lint:\n\t@for i in 1 2 3 4 5; do echo " + name + " $$i; done\n"
+		if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte(makefile), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, stream := a.startStream("bulk:lint")
+	defer a.finishStream(stream)
+	var blockMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if _, ran := a.runBulkProject(ctx, stream, &blockMu, "lint", name); !ran {
+				t.Errorf("%s did not run", name)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	// After its start notice, each project's output lines arrive together
+	var output []string
+	for _, ev := range events.named("devkit:project:bulk:stream") {
+		if line := ev.payload["line"].(string); !strings.Contains(line, "Running make") {
+			output = append(output, line)
+		}
+	}
+	if len(output) != 15 {
+		t.Fatalf("got %d output lines, want 15: %q", len(output), output)
+	}
+	for block := 0; block < 3; block++ {
+		name := strings.Trim(strings.Fields(output[block*5])[0], "[]")
+		for i := 0; i < 5; i++ {
+			if want := fmt.Sprintf("[%s] %s %d", name, name, i+1); output[block*5+i] != want {
+				t.Fatalf("line %d = %q, want %q (blocks interleaved): %q", block*5+i, output[block*5+i], want, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/activity"
+)
+
+func TestCleanProjectDeletesOnlyConfirmedPaths(t *testing.T) {
+	a := newTestApp(t)
+	a.projectsDir = t.TempDir()
+	a.activity = activity.NewStore(t.TempDir(), activity.DefaultMaxEvents)
+	events := recordEvents(a)
+	projectDir := filepath.Join(a.projectsDir, "web")
+	for _, rel := range []string{"package.json", "dist/app.js", "node_modules/left-pad/index.js", "src/main.js"} {
+		p := filepath.Join(projectDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.CleanProject("web", nil); err == nil {
+		t.Fatal("CleanProject without confirmed paths succeeded, want a confirmation error")
+	}
+	// dist was confirmed, node_modules appeared in the plan but wasn't, src was never in the plan
+	if err := a.CleanProject("web", []string{"dist", "src"}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(events.named("devkit:project:stream:done")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("clean did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "dist")); !os.IsNotExist(err) {
+		t.Error("confirmed dist was not deleted")
+	}
+	for _, rel := range []string{"node_modules", "src"} {
+		if _, err := os.Stat(filepath.Join(projectDir, rel)); err != nil {
+			t.Errorf("unconfirmed or unplanned %s was deleted: %v", rel, err)
+		}
+	}
+	var skipped bool
+	for _, ev := range events.named("devkit:project:stream") {
+		if ev.payload["line"] == "[WARN] Skipped src: no longer safe to delete" {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Error("no skipped line for src")
+	}
+}
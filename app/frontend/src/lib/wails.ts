@@ -16,14 +16,35 @@ export const projects = {
     list: () => callForSuccess(getApp()?.ListProjects()),
     clone: (name) => callForSuccess(getApp()?.ProjectClone(name)),
     update: (name) => callForSuccess(getApp()?.ProjectUpdate(name)),
+    unshallow: (name) => callForSuccess(getApp()?.ProjectUnshallow(name)),
     open: (name) => callForSuccess(getApp()?.ProjectOpen(name)),
     startStream: (name, op) => callForSuccess(getApp()?.StartProjectStream(name, op)),
+    targets: (name) => callForSuccess(getApp()?.ListProjectTargets(name)),
     stopStream: (name, op) => getApp()?.StopProjectStream(name, op),
+    startTestRun: (name, pattern, pkg = '') => callForSuccess(getApp()?.StartProjectTestRun(name, pattern, pkg)),
     startBulkStream: (action) => callForSuccess(getApp()?.StartBulkProjectStream(action)),
     stopBulkStream: (action) => getApp()?.StopBulkProjectStream(action),
-    createTag: (name, tag, msg, push) => callForSuccess(getApp()?.CreateTag(name, tag, msg, push)),
+    createTag: (name, tag, msg, push, commit = '', lightweight = false) => callForSuccess(getApp()?.CreateTag(name, tag, msg, commit, lightweight, push)),
     listTags: (name) => callForSuccess(getApp()?.ListTags(name)),
+    deleteTag: (name, tag, remote = false) => callForSuccess(getApp()?.DeleteTag(name, tag, remote)),
+    suggestNextTag: (name, bump = 'patch') => callForSuccess(getApp()?.SuggestNextTag(name, bump)),
+    diff: (name, staged = false) => callForSuccess(getApp()?.ProjectDiff(name, staged)),
+    commits: (name, n = 20) => callForSuccess(getApp()?.ProjectCommits(name, n)),
+    branches: (name) => callForSuccess(getApp()?.ListProjectBranches(name)),
+    checkoutBranch: (name, branch) => callForSuccess(getApp()?.CheckoutProjectBranch(name, branch)),
+    stash: (name, message = '') => callForSuccess(getApp()?.StashProject(name, message)),
+    unstash: (name) => callForSuccess(getApp()?.UnstashProject(name)),
+    stashes: (name) => callForSuccess(getApp()?.ListProjectStashes(name)),
     dependencies: (name) => callForSuccess(getApp()?.ListProjectDependencies(name)),
+    dependencyGraph: () => callForSuccess(getApp()?.GetDependencyGraph()),
+    dependencyVersions: () => callForSuccess(getApp()?.CheckDependencyVersions()),
+    localReplaces: (name) => callForSuccess(getApp()?.ListLocalReplaces(name)),
+    toggleLocalReplace: (name, dependency, enable) => callForSuccess(getApp()?.ToggleLocalReplace(name, dependency, enable)),
+    artifacts: (name) => callForSuccess(getApp()?.ProjectArtifacts(name)),
+    cleanArtifacts: (name) => callForSuccess(getApp()?.CleanProjectArtifacts(name)),
+    size: (name, refresh = false) => callForSuccess(getApp()?.ProjectSize(name, refresh)),
+    cleanPlan: (name) => callForSuccess(getApp()?.CleanProjectPlan(name)),
+    clean: (name, confirmedPaths = []) => callForSuccess(getApp()?.CleanProject(name, confirmedPaths)),
 };
 
 export const webapp = {
@@ -35,41 +56,85 @@ export const webapp = {
 export const services = {
     list: () => getApp()?.ListServices() ?? Promise.resolve([]),
     isDockerConnected: () => getApp()?.IsDockerConnected() ?? Promise.resolve(false),
+    stats: (name) => callForSuccess(getApp()?.ServiceStats(name)),
     start: (name) => callForSuccess(getApp()?.StartService(name)),
     stop: (name) => callForSuccess(getApp()?.StopService(name)),
+    restart: (name) => callForSuccess(getApp()?.RestartService(name)),
     startAll: () => callForSuccess(getApp()?.StartAllServices()),
     stopAll: () => callForSuccess(getApp()?.StopAllServices()),
-    startLogsStream: (name) => getApp()?.StartServiceLogsStream(name),
+    restartAll: () => callForSuccess(getApp()?.RestartAllServices()),
+    startLogsStream: (name, filter = '', regex = false) => getApp()?.StartServiceLogsStream(name, filter, regex),
     stopLogsStream: (name) => getApp()?.StopServiceLogsStream(name),
+    startAllLogsStream: () => callForSuccess(getApp()?.StartAllServiceLogsStream()),
+    stopAllLogsStream: () => getApp()?.StopAllServiceLogsStream(),
 };
 
 export const backend = {
     list: () => getApp()?.ListBackendServices() ?? Promise.resolve([]),
     health: (name) => callForSuccess(getApp()?.BackendHealth(name)),
+    stats: (name) => callForSuccess(getApp()?.BackendStats(name)),
     start: (name) => callForSuccess(getApp()?.StartBackendService(name)),
     stop: (name) => callForSuccess(getApp()?.StopBackendService(name)),
+    restart: (name) => callForSuccess(getApp()?.RestartBackendService(name)),
+    getBuildMode: () => getApp()?.BackendBuildMode() ?? Promise.resolve('run'),
+    setBuildMode: (mode) => callForSuccess(getApp()?.SetBackendBuildMode(mode)),
     startGroup: (group) => callForSuccess(getApp()?.StartBackendGroup(group)),
     stopGroup: (group) => callForSuccess(getApp()?.StopBackendGroup(group)),
-    startLogsStream: (name) => getApp()?.StartBackendLogsStream(name),
+    startLogsStream: (name, filter = '', regex = false) => getApp()?.StartBackendLogsStream(name, filter, regex),
     stopLogsStream: (name) => getApp()?.StopBackendLogsStream(name),
+    envUsage: (name) => callForSuccess(getApp()?.ServiceEnvUsage(name)),
+    effectiveEnv: (name) => callForSuccess(getApp()?.BackendEffectiveEnv(name)),
+    setEnvOverride: (name, key, value) => callForSuccess(getApp()?.SetServiceEnvOverride(name, key, value)),
+    deleteEnvOverride: (name, key) => callForSuccess(getApp()?.DeleteServiceEnvOverride(name, key)),
 };
 
 export const migration = {
     getStatus: () => getApp()?.GetMigrationStatus() ?? Promise.resolve(null),
+    refreshStatus: () => getApp()?.RefreshMigrationStatus() ?? Promise.resolve(null),
     runUp: () => callForSuccess(getApp()?.RunMigrationUp()),
     runDown: () => callForSuccess(getApp()?.RunMigrationDown()),
+    runDownN: (n) => callForSuccess(getApp()?.RunMigrationDownN(n)),
+    runGoto: (version) => callForSuccess(getApp()?.RunMigrationGoto(version)),
+    create: (name) => callForSuccess(getApp()?.CreateMigration(name)),
     startStream: (action) => getApp()?.StartMigrationStream(action),
+    startDownNStream: (n) => getApp()?.StartMigrationDownNStream(n),
+    startGotoStream: (version) => getApp()?.StartMigrationGotoStream(version),
     stopStream: (action) => getApp()?.StopMigrationStream(action),
+    testConnection: () => getApp()?.TestDatabaseConnection() ?? Promise.resolve({ ok: false, message: 'App not available' }),
+    startDatabase: () => callForSuccess(getApp()?.StartDatabase()),
+    setAutoStartDB: (enabled) => getApp()?.SetAutoStartDB(enabled),
 };
 
 export const proto = {
     getStatus: () => getApp()?.GetProtoStatus() ?? Promise.resolve(null),
     startStream: () => getApp()?.StartProtoStream(),
+    startTargetStream: (target) => callForSuccess(getApp()?.StartProtoStreamTarget(target)),
+    targets: () => getApp()?.ProtoTargets() ?? Promise.resolve([]),
     stopStream: () => getApp()?.StopProtoStream(),
+    checkBreaking: () => callForSuccess(getApp()?.CheckProtoBreaking()),
+    lint: () => callForSuccess(getApp()?.LintProtos()),
+    startLintStream: () => getApp()?.StartProtoLintStream(),
+    stopLintStream: () => getApp()?.StopProtoLintStream(),
+    startWatch: () => callForSuccess(getApp()?.StartProtoWatch()),
+    stopWatch: () => getApp()?.StopProtoWatch(),
     startReleaseProtosGoStream: (version = '') => getApp()?.StartReleaseProtosGoStream(version),
     stopReleaseProtosGoStream: () => getApp()?.StopReleaseProtosGoStream(),
 };
 
+export const streams = {
+    stopAll: () => getApp()?.StopAllStreams() ?? Promise.resolve(0),
+    resume: (token, afterSeq = 0, run = 0) => callForSuccess(getApp()?.ResumeStream(token, run, afterSeq)),
+};
+
+export const operations = {
+    rerunLastFailed: () => callForSuccess(getApp()?.RerunLastFailed()),
+};
+
+export const output = {
+    getKeepAnsi: () => getApp()?.KeepANSI() ?? Promise.resolve(false),
+    setKeepAnsi: (keep) => callForSuccess(getApp()?.SetKeepANSI(keep)),
+};
+
 export const generate = {
     run: () => callForSuccess(getApp()?.RunGenerate()),
 };
@@ -80,28 +145,48 @@ export const env = {
     validate: () => callForSuccess(getApp()?.ValidateEnv()),
     updateVar: (name, value) => callForSuccess(getApp()?.UpdateEnvVar(name, value)),
     deleteVar: (name) => callForSuccess(getApp()?.DeleteEnvVar(name)),
+    revealVar: (name) => callForSuccess(getApp()?.RevealEnvVar(name)),
+    diff: () => callForSuccess(getApp()?.EnvDiff()),
+    exportEnv: (includeSensitive = false) => callForSuccess(getApp()?.ExportEnv(includeSensitive)),
+    importEnv: (content, overwrite = false) => callForSuccess(getApp()?.ImportEnv(content, overwrite)),
+    syncMissing: () => callForSuccess(getApp()?.SyncMissingEnvVars()),
+    profiles: () => callForSuccess(getApp()?.ListEnvProfiles()),
+    activateProfile: (name) => callForSuccess(getApp()?.ActivateEnvProfile(name)),
+    currentProfile: () => getApp()?.CurrentEnvProfile() ?? Promise.resolve(''),
 };
 
 export const prerequisites = {
     list: () => getApp()?.GetPrerequisites() ?? Promise.resolve([]),
 };
 
+export const activity = {
+    query: (query = { limit: 200 }) => callForSuccess(getApp()?.GetActivity(query)),
+    clear: () => callForSuccess(getApp()?.ClearActivity()),
+};
+
+export const doctor = {
+    run: () => callForSuccess(getApp()?.RunDoctor()),
+};
+
 export const notices = {
     list: () => getApp()?.GetNotices() ?? Promise.resolve([]),
 };
 
 export const status = {
     get: () => getApp()?.Status() ?? Promise.resolve({}),
+    overview: () => callForSuccess(getApp()?.GetDashboardOverview()),
 };
 
 export const submodule = {
     getSyncStatus: () => getApp()?.SubmoduleSyncStatus() ?? Promise.resolve({}),
-    sync: (message) => callForSuccess(getApp()?.SubmoduleSync(message)),
+    preview: () => callForSuccess(getApp()?.SubmoduleSyncPreview()),
+    sync: (message, push = false) => callForSuccess(getApp()?.SubmoduleSync(message, push)),
 };
 
 export const github = {
     startDeviceFlow: () => callForSuccess(getApp()?.GitHubStartDeviceFlow()),
     pollAuth: () => callForSuccess(getApp()?.GitHubPollAuth()),
+    cancelDeviceFlow: () => getApp()?.CancelDeviceFlow(),
     getStatus: () => getApp()?.GitHubGetStatus() ?? Promise.resolve({ connected: false }),
     disconnect: () => getApp()?.GitHubDisconnect() ?? Promise.resolve({ connected: false }),
     refreshTeams: () => callForSuccess(getApp()?.GitHubRefreshTeams()),
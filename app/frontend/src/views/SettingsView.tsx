@@ -68,6 +68,7 @@ export function SettingsView({ onBreadcrumbChange }) {
   const [revealedVars, setRevealedVars] = useState({});
   const [editingVar, setEditingVar] = useState(null); // { name, value }
   const [editValue, setEditValue] = useState('');
+  const [editHidden, setEditHidden] = useState(false); // current value couldn't be read; editing replaces it
   const [addingVar, setAddingVar] = useState(false);
   const [newVarName, setNewVarName] = useState('');
   const [newVarValue, setNewVarValue] = useState('');
@@ -76,18 +77,43 @@ export function SettingsView({ onBreadcrumbChange }) {
   const editInputRef = useRef(null);
   const addNameRef = useRef(null);
 
-  const toggleReveal = (name) => setRevealedVars((prev) => ({ ...prev, [name]: !prev[name] }));
+  // GetEnvStatus masks sensitive values, so the real value is fetched on demand (reveal/edit)
+  const fetchRealValue = async (v) => {
+    if (!v.sensitive || !v.isSet) return { success: true, data: v.value ?? '' };
+    return env.revealVar(v.name);
+  };
 
-  const startEditing = (v) => {
-    setEditingVar(v.name);
-    setEditValue(v.value ?? '');
+  const toggleReveal = async (v) => {
+    if (revealedVars[v.name] !== undefined) {
+      setRevealedVars((prev) => {
+        const next = { ...prev };
+        delete next[v.name];
+        return next;
+      });
+      return;
+    }
+    const { success, data, message } = await fetchRealValue(v);
+    if (!success) {
+      setEnvError(message ?? 'Failed to reveal variable');
+      return;
+    }
+    setRevealedVars((prev) => ({ ...prev, [v.name]: data ?? '' }));
+  };
+
+  const startEditing = async (v) => {
     setEnvError(null);
+    // Without permission to reveal a secret it can still be overwritten, starting from an empty value
+    const { success, data } = await fetchRealValue(v);
+    setEditingVar(v.name);
+    setEditValue(success ? data ?? '' : '');
+    setEditHidden(!success);
     setTimeout(() => editInputRef.current?.focus(), 0);
   };
 
   const cancelEditing = () => {
     setEditingVar(null);
     setEditValue('');
+    setEditHidden(false);
     setEnvError(null);
   };
 
@@ -99,6 +125,12 @@ export function SettingsView({ onBreadcrumbChange }) {
     if (success) {
       setEditingVar(null);
       setEditValue('');
+      setEditHidden(false);
+      setRevealedVars((prev) => {
+        const next = { ...prev };
+        delete next[name];
+        return next;
+      });
       fetchAll();
     } else {
       setEnvError(message ?? 'Failed to save variable');
@@ -227,8 +259,6 @@ export function SettingsView({ onBreadcrumbChange }) {
     setGhRefreshing(false);
   };
 
-  const maskValue = (value) => value ? '\u2022'.repeat(Math.min(value.length, 24)) : '';
-
   const renderVarGroup = (title, vars, allowDelete = false) => {
     if (!vars || vars.length === 0) return null;
     return (
@@ -237,8 +267,9 @@ export function SettingsView({ onBreadcrumbChange }) {
         <ul className="env-var-list">
           {vars.map((v) => {
             const isEditing = editingVar === v.name;
-            const isRevealed = revealedVars[v.name];
-            const displayValue = v.sensitive && !isRevealed ? maskValue(v.value) : (v.value || '');
+            const isRevealed = revealedVars[v.name] !== undefined;
+            // Sensitive values arrive masked by the backend ("****wxyz")
+            const displayValue = isRevealed ? revealedVars[v.name] : (v.value || '');
             return (
               <li key={v.name} className={`env-var-row ${!v.isSet ? 'env-var-row--unset' : ''}`}>
                 <div className="env-var-row__name">
@@ -261,6 +292,7 @@ export function SettingsView({ onBreadcrumbChange }) {
                         type={v.sensitive && !isRevealed ? 'password' : 'text'}
                         className="env-var-row__input"
                         value={editValue}
+                        placeholder={editHidden ? 'Enter a new value to replace the hidden one' : undefined}
                         onChange={(e) => setEditValue(e.target.value)}
                         onKeyDown={(e) => {
                           if (e.key === 'Enter') saveVar(v.name, editValue);
@@ -297,7 +329,7 @@ export function SettingsView({ onBreadcrumbChange }) {
                           <button
                             type="button"
                             className="btn btn--icon btn--ghost"
-                            onClick={() => toggleReveal(v.name)}
+                            onClick={() => toggleReveal(v)}
                             title={isRevealed ? 'Hide value' : 'Reveal value'}
                           >
                             {isRevealed ? <EyeOff size={14} /> : <Eye size={14} />}
@@ -2,15 +2,61 @@
 // This file is automatically generated. DO NOT EDIT
 import {model} from '../models';
 import {service} from '../models';
+import {config} from '../models';
+import {git} from '../models';
+
+export function ActivateEnvProfile(arg1:string):Promise<{[key: string]: string}>;
+
+export function AllMakeTargets():Promise<{[key: string]: Array<string>}>;
+
+export function BackendBuildMode():Promise<string>;
+
+export function BackendEffectiveEnv(arg1:string):Promise<Array<string>>;
 
 export function BackendHealth(arg1:string):Promise<{[key: string]: any}>;
 
+export function BackendStats(arg1:string):Promise<model.ProcessStats>;
+
+export function CancelDeviceFlow():Promise<void>;
+
+export function CheckDependencyVersions():Promise<Array<model.DepMismatch>>;
+
+export function CheckProtoBreaking():Promise<{[key: string]: any}>;
+
+export function CheckoutProjectBranch(arg1:string,arg2:string):Promise<{[key: string]: string}>;
+
+export function CleanProject(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function CleanProjectArtifacts(arg1:string):Promise<{[key: string]: string}>;
+
+export function CleanProjectPlan(arg1:string):Promise<model.CleanPlan>;
+
+export function ClearActivity():Promise<void>;
+
 export function CopyEnvExample():Promise<{[key: string]: string}>;
 
-export function CreateTag(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<{[key: string]: string}>;
+export function CreateMigration(arg1:string):Promise<{[key: string]: any}>;
+
+export function CreateTag(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean,arg6:boolean):Promise<{[key: string]: string}>;
+
+export function CurrentEnvProfile():Promise<string>;
 
 export function DeleteEnvVar(arg1:string):Promise<void>;
 
+export function DeleteServiceEnvOverride(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteTag(arg1:string,arg2:string,arg3:boolean):Promise<{[key: string]: string}>;
+
+export function EnvDiff():Promise<{[key: string]: Array<string>}>;
+
+export function ExportEnv(arg1:boolean):Promise<string>;
+
+export function GetActivity(arg1:model.ActivityQuery):Promise<model.ActivityPage>;
+
+export function GetDashboardOverview():Promise<model.Overview>;
+
+export function GetDependencyGraph():Promise<model.DependencyGraph>;
+
 export function GetEnvStatus():Promise<model.EnvStatus>;
 
 export function GetMigrationStatus():Promise<model.MigrationStatus>;
@@ -31,12 +77,28 @@ export function GitHubRefreshTeams():Promise<service.Permissions>;
 
 export function GitHubStartDeviceFlow():Promise<service.DeviceFlowResponse>;
 
+export function ImportEnv(arg1:string,arg2:boolean):Promise<model.EnvImportSummary>;
+
 export function IsDockerConnected():Promise<boolean>;
 
+export function KeepANSI():Promise<boolean>;
+
+export function LintProtos():Promise<Array<model.ProtoLintIssue>>;
+
 export function ListBackendServices():Promise<Array<model.BackendService>>;
 
+export function ListEnvProfiles():Promise<Array<string>>;
+
+export function ListLocalReplaces(arg1:string):Promise<Array<model.LocalReplace>>;
+
+export function ListProjectBranches(arg1:string):Promise<Array<string>>;
+
 export function ListProjectDependencies(arg1:string):Promise<Array<model.Dependency>>;
 
+export function ListProjectStashes(arg1:string):Promise<Array<string>>;
+
+export function ListProjectTargets(arg1:string):Promise<Array<string>>;
+
 export function ListProjects():Promise<Array<model.Project>>;
 
 export function ListServices():Promise<Array<model.Service>>;
@@ -45,44 +107,114 @@ export function ListTags(arg1:string):Promise<{[key: string]: any}>;
 
 export function OpenWebAppURL():Promise<void>;
 
+export function PortCollisions():Promise<Array<config.PortCollision>>;
+
+export function ProjectArtifacts(arg1:string):Promise<Array<model.Artifact>>;
+
 export function ProjectClone(arg1:string):Promise<{[key: string]: string}>;
 
+export function ProjectCommits(arg1:string,arg2:number):Promise<Array<model.Commit>>;
+
+export function ProjectDiff(arg1:string,arg2:boolean):Promise<string>;
+
 export function ProjectOpen(arg1:string):Promise<{[key: string]: string}>;
 
+export function ProjectReadme(arg1:string):Promise<{[key: string]: any}>;
+
+export function ProjectSize(arg1:string,arg2:boolean):Promise<model.ProjectSize>;
+
+export function ProjectUnshallow(arg1:string):Promise<{[key: string]: string}>;
+
 export function ProjectUpdate(arg1:string):Promise<{[key: string]: string}>;
 
+export function ProtoTargets():Promise<Array<string>>;
+
+export function RefreshMigrationStatus():Promise<model.MigrationStatus>;
+
+export function RerunLastFailed():Promise<void>;
+
+export function RestartAllServices():Promise<{[key: string]: string}>;
+
+export function RestartBackendService(arg1:string):Promise<{[key: string]: string}>;
+
+export function RestartService(arg1:string):Promise<{[key: string]: string}>;
+
+export function ResumeStream(arg1:string,arg2:number,arg3:number):Promise<model.StreamReplay>;
+
+export function RevealEnvVar(arg1:string):Promise<string>;
+
+export function RunDoctor():Promise<model.DoctorReport>;
+
 export function RunMigrationDown():Promise<{[key: string]: string}>;
 
+export function RunMigrationDownN(arg1:number):Promise<{[key: string]: string}>;
+
+export function RunMigrationGoto(arg1:number):Promise<{[key: string]: string}>;
+
 export function RunMigrationUp():Promise<{[key: string]: string}>;
 
+export function ServiceEnvUsage(arg1:string):Promise<model.ServiceEnvUsage>;
+
+export function ServiceStats(arg1:string):Promise<model.ContainerStats>;
+
+export function SetAutoStartDB(arg1:boolean):Promise<void>;
+
+export function SetBackendBuildMode(arg1:string):Promise<void>;
+
+export function SetKeepANSI(arg1:boolean):Promise<void>;
+
+export function SetServiceEnvOverride(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function StartAllServiceLogsStream():Promise<void>;
+
 export function StartAllServices():Promise<{[key: string]: string}>;
 
 export function StartBackendGroup(arg1:string):Promise<{[key: string]: string}>;
 
-export function StartBackendLogsStream(arg1:string):Promise<void>;
+export function StartBackendLogsStream(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
 export function StartBackendService(arg1:string):Promise<{[key: string]: string}>;
 
 export function StartBulkProjectStream(arg1:string):Promise<void>;
 
+export function StartDatabase():Promise<{[key: string]: string}>;
+
+export function StartMigrationDownNStream(arg1:number):Promise<void>;
+
+export function StartMigrationGotoStream(arg1:number):Promise<void>;
+
 export function StartMigrationStream(arg1:string):Promise<void>;
 
 export function StartProjectStream(arg1:string,arg2:string):Promise<void>;
 
+export function StartProjectTestRun(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function StartProtoLintStream():Promise<void>;
+
 export function StartProtoStream():Promise<void>;
 
+export function StartProtoStreamTarget(arg1:string):Promise<void>;
+
+export function StartProtoWatch():Promise<void>;
+
 export function StartReleaseProtosGoStream(arg1:string):Promise<void>;
 
 export function StartService(arg1:string):Promise<{[key: string]: string}>;
 
-export function StartServiceLogsStream(arg1:string):Promise<void>;
+export function StartServiceLogsStream(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
 export function StartWebAppDev():Promise<void>;
 
+export function StashProject(arg1:string,arg2:string):Promise<{[key: string]: string}>;
+
 export function Status():Promise<{[key: string]: any}>;
 
+export function StopAllServiceLogsStream():Promise<void>;
+
 export function StopAllServices():Promise<{[key: string]: string}>;
 
+export function StopAllStreams():Promise<number>;
+
 export function StopBackendGroup(arg1:string):Promise<{[key: string]: string}>;
 
 export function StopBackendLogsStream(arg1:string):Promise<void>;
@@ -95,20 +227,42 @@ export function StopMigrationStream(arg1:string):Promise<void>;
 
 export function StopProjectStream(arg1:string,arg2:string):Promise<void>;
 
+export function StopProtoLintStream():Promise<void>;
+
 export function StopProtoStream():Promise<void>;
 
+export function StopProtoWatch():Promise<void>;
+
 export function StopReleaseProtosGoStream():Promise<void>;
 
 export function StopService(arg1:string):Promise<{[key: string]: string}>;
 
 export function StopServiceLogsStream(arg1:string):Promise<void>;
 
+export function StopStream(arg1:string):Promise<model.StreamFinalState>;
+
 export function StopWebAppDev():Promise<void>;
 
-export function SubmoduleSync(arg1:string):Promise<{[key: string]: string}>;
+export function SubmoduleDrift():Promise<Array<git.SubmoduleDriftInfo>>;
+
+export function SubmoduleSync(arg1:string,arg2:boolean):Promise<{[key: string]: string}>;
+
+export function SubmoduleSyncPreview():Promise<Array<model.SubmoduleChange>>;
 
 export function SubmoduleSyncStatus():Promise<{[key: string]: any}>;
 
+export function SuggestNextTag(arg1:string,arg2:string):Promise<string>;
+
+export function SuggestTagName(arg1:string):Promise<{[key: string]: any}>;
+
+export function SyncMissingEnvVars():Promise<{[key: string]: string}>;
+
+export function TestDatabaseConnection():Promise<{[key: string]: any}>;
+
+export function ToggleLocalReplace(arg1:string,arg2:string,arg3:boolean):Promise<{[key: string]: string}>;
+
+export function UnstashProject(arg1:string):Promise<{[key: string]: string}>;
+
 export function UpdateEnvVar(arg1:string,arg2:string):Promise<void>;
 
 export function ValidateEnv():Promise<{[key: string]: any}>;
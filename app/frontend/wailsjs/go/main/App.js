@@ -2,22 +2,110 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ActivateEnvProfile(arg1) {
+  return window['go']['main']['App']['ActivateEnvProfile'](arg1);
+}
+
+export function AllMakeTargets() {
+  return window['go']['main']['App']['AllMakeTargets']();
+}
+
+export function BackendBuildMode() {
+  return window['go']['main']['App']['BackendBuildMode']();
+}
+
+export function BackendEffectiveEnv(arg1) {
+  return window['go']['main']['App']['BackendEffectiveEnv'](arg1);
+}
+
 export function BackendHealth(arg1) {
   return window['go']['main']['App']['BackendHealth'](arg1);
 }
 
+export function BackendStats(arg1) {
+  return window['go']['main']['App']['BackendStats'](arg1);
+}
+
+export function CancelDeviceFlow() {
+  return window['go']['main']['App']['CancelDeviceFlow']();
+}
+
+export function CheckDependencyVersions() {
+  return window['go']['main']['App']['CheckDependencyVersions']();
+}
+
+export function CheckProtoBreaking() {
+  return window['go']['main']['App']['CheckProtoBreaking']();
+}
+
+export function CheckoutProjectBranch(arg1, arg2) {
+  return window['go']['main']['App']['CheckoutProjectBranch'](arg1, arg2);
+}
+
+export function CleanProject(arg1, arg2) {
+  return window['go']['main']['App']['CleanProject'](arg1, arg2);
+}
+
+export function CleanProjectArtifacts(arg1) {
+  return window['go']['main']['App']['CleanProjectArtifacts'](arg1);
+}
+
+export function CleanProjectPlan(arg1) {
+  return window['go']['main']['App']['CleanProjectPlan'](arg1);
+}
+
+export function ClearActivity() {
+  return window['go']['main']['App']['ClearActivity']();
+}
+
 export function CopyEnvExample() {
   return window['go']['main']['App']['CopyEnvExample']();
 }
 
-export function CreateTag(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['CreateTag'](arg1, arg2, arg3, arg4);
+export function CreateMigration(arg1) {
+  return window['go']['main']['App']['CreateMigration'](arg1);
+}
+
+export function CreateTag(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['CreateTag'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function CurrentEnvProfile() {
+  return window['go']['main']['App']['CurrentEnvProfile']();
 }
 
 export function DeleteEnvVar(arg1) {
   return window['go']['main']['App']['DeleteEnvVar'](arg1);
 }
 
+export function DeleteServiceEnvOverride(arg1, arg2) {
+  return window['go']['main']['App']['DeleteServiceEnvOverride'](arg1, arg2);
+}
+
+export function DeleteTag(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteTag'](arg1, arg2, arg3);
+}
+
+export function EnvDiff() {
+  return window['go']['main']['App']['EnvDiff']();
+}
+
+export function ExportEnv(arg1) {
+  return window['go']['main']['App']['ExportEnv'](arg1);
+}
+
+export function GetActivity(arg1) {
+  return window['go']['main']['App']['GetActivity'](arg1);
+}
+
+export function GetDashboardOverview() {
+  return window['go']['main']['App']['GetDashboardOverview']();
+}
+
+export function GetDependencyGraph() {
+  return window['go']['main']['App']['GetDependencyGraph']();
+}
+
 export function GetEnvStatus() {
   return window['go']['main']['App']['GetEnvStatus']();
 }
@@ -58,18 +146,50 @@ export function GitHubStartDeviceFlow() {
   return window['go']['main']['App']['GitHubStartDeviceFlow']();
 }
 
+export function ImportEnv(arg1, arg2) {
+  return window['go']['main']['App']['ImportEnv'](arg1, arg2);
+}
+
 export function IsDockerConnected() {
   return window['go']['main']['App']['IsDockerConnected']();
 }
 
+export function KeepANSI() {
+  return window['go']['main']['App']['KeepANSI']();
+}
+
+export function LintProtos() {
+  return window['go']['main']['App']['LintProtos']();
+}
+
 export function ListBackendServices() {
   return window['go']['main']['App']['ListBackendServices']();
 }
 
+export function ListEnvProfiles() {
+  return window['go']['main']['App']['ListEnvProfiles']();
+}
+
+export function ListLocalReplaces(arg1) {
+  return window['go']['main']['App']['ListLocalReplaces'](arg1);
+}
+
+export function ListProjectBranches(arg1) {
+  return window['go']['main']['App']['ListProjectBranches'](arg1);
+}
+
 export function ListProjectDependencies(arg1) {
   return window['go']['main']['App']['ListProjectDependencies'](arg1);
 }
 
+export function ListProjectStashes(arg1) {
+  return window['go']['main']['App']['ListProjectStashes'](arg1);
+}
+
+export function ListProjectTargets(arg1) {
+  return window['go']['main']['App']['ListProjectTargets'](arg1);
+}
+
 export function ListProjects() {
   return window['go']['main']['App']['ListProjects']();
 }
@@ -86,26 +206,126 @@ export function OpenWebAppURL() {
   return window['go']['main']['App']['OpenWebAppURL']();
 }
 
+export function PortCollisions() {
+  return window['go']['main']['App']['PortCollisions']();
+}
+
+export function ProjectArtifacts(arg1) {
+  return window['go']['main']['App']['ProjectArtifacts'](arg1);
+}
+
 export function ProjectClone(arg1) {
   return window['go']['main']['App']['ProjectClone'](arg1);
 }
 
+export function ProjectCommits(arg1, arg2) {
+  return window['go']['main']['App']['ProjectCommits'](arg1, arg2);
+}
+
+export function ProjectDiff(arg1, arg2) {
+  return window['go']['main']['App']['ProjectDiff'](arg1, arg2);
+}
+
 export function ProjectOpen(arg1) {
   return window['go']['main']['App']['ProjectOpen'](arg1);
 }
 
+export function ProjectReadme(arg1) {
+  return window['go']['main']['App']['ProjectReadme'](arg1);
+}
+
+export function ProjectSize(arg1, arg2) {
+  return window['go']['main']['App']['ProjectSize'](arg1, arg2);
+}
+
+export function ProjectUnshallow(arg1) {
+  return window['go']['main']['App']['ProjectUnshallow'](arg1);
+}
+
 export function ProjectUpdate(arg1) {
   return window['go']['main']['App']['ProjectUpdate'](arg1);
 }
 
+export function ProtoTargets() {
+  return window['go']['main']['App']['ProtoTargets']();
+}
+
+export function RefreshMigrationStatus() {
+  return window['go']['main']['App']['RefreshMigrationStatus']();
+}
+
+export function RerunLastFailed() {
+  return window['go']['main']['App']['RerunLastFailed']();
+}
+
+export function RestartAllServices() {
+  return window['go']['main']['App']['RestartAllServices']();
+}
+
+export function RestartBackendService(arg1) {
+  return window['go']['main']['App']['RestartBackendService'](arg1);
+}
+
+export function RestartService(arg1) {
+  return window['go']['main']['App']['RestartService'](arg1);
+}
+
+export function ResumeStream(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ResumeStream'](arg1, arg2, arg3);
+}
+
+export function RevealEnvVar(arg1) {
+  return window['go']['main']['App']['RevealEnvVar'](arg1);
+}
+
+export function RunDoctor() {
+  return window['go']['main']['App']['RunDoctor']();
+}
+
 export function RunMigrationDown() {
   return window['go']['main']['App']['RunMigrationDown']();
 }
 
+export function RunMigrationDownN(arg1) {
+  return window['go']['main']['App']['RunMigrationDownN'](arg1);
+}
+
+export function RunMigrationGoto(arg1) {
+  return window['go']['main']['App']['RunMigrationGoto'](arg1);
+}
+
 export function RunMigrationUp() {
   return window['go']['main']['App']['RunMigrationUp']();
 }
 
+export function ServiceEnvUsage(arg1) {
+  return window['go']['main']['App']['ServiceEnvUsage'](arg1);
+}
+
+export function ServiceStats(arg1) {
+  return window['go']['main']['App']['ServiceStats'](arg1);
+}
+
+export function SetAutoStartDB(arg1) {
+  return window['go']['main']['App']['SetAutoStartDB'](arg1);
+}
+
+export function SetBackendBuildMode(arg1) {
+  return window['go']['main']['App']['SetBackendBuildMode'](arg1);
+}
+
+export function SetKeepANSI(arg1) {
+  return window['go']['main']['App']['SetKeepANSI'](arg1);
+}
+
+export function SetServiceEnvOverride(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetServiceEnvOverride'](arg1, arg2, arg3);
+}
+
+export function StartAllServiceLogsStream() {
+  return window['go']['main']['App']['StartAllServiceLogsStream']();
+}
+
 export function StartAllServices() {
   return window['go']['main']['App']['StartAllServices']();
 }
@@ -114,8 +334,8 @@ export function StartBackendGroup(arg1) {
   return window['go']['main']['App']['StartBackendGroup'](arg1);
 }
 
-export function StartBackendLogsStream(arg1) {
-  return window['go']['main']['App']['StartBackendLogsStream'](arg1);
+export function StartBackendLogsStream(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartBackendLogsStream'](arg1, arg2, arg3);
 }
 
 export function StartBackendService(arg1) {
@@ -126,6 +346,18 @@ export function StartBulkProjectStream(arg1) {
   return window['go']['main']['App']['StartBulkProjectStream'](arg1);
 }
 
+export function StartDatabase() {
+  return window['go']['main']['App']['StartDatabase']();
+}
+
+export function StartMigrationDownNStream(arg1) {
+  return window['go']['main']['App']['StartMigrationDownNStream'](arg1);
+}
+
+export function StartMigrationGotoStream(arg1) {
+  return window['go']['main']['App']['StartMigrationGotoStream'](arg1);
+}
+
 export function StartMigrationStream(arg1) {
   return window['go']['main']['App']['StartMigrationStream'](arg1);
 }
@@ -134,10 +366,26 @@ export function StartProjectStream(arg1, arg2) {
   return window['go']['main']['App']['StartProjectStream'](arg1, arg2);
 }
 
+export function StartProjectTestRun(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartProjectTestRun'](arg1, arg2, arg3);
+}
+
+export function StartProtoLintStream() {
+  return window['go']['main']['App']['StartProtoLintStream']();
+}
+
 export function StartProtoStream() {
   return window['go']['main']['App']['StartProtoStream']();
 }
 
+export function StartProtoStreamTarget(arg1) {
+  return window['go']['main']['App']['StartProtoStreamTarget'](arg1);
+}
+
+export function StartProtoWatch() {
+  return window['go']['main']['App']['StartProtoWatch']();
+}
+
 export function StartReleaseProtosGoStream(arg1) {
   return window['go']['main']['App']['StartReleaseProtosGoStream'](arg1);
 }
@@ -146,22 +394,34 @@ export function StartService(arg1) {
   return window['go']['main']['App']['StartService'](arg1);
 }
 
-export function StartServiceLogsStream(arg1) {
-  return window['go']['main']['App']['StartServiceLogsStream'](arg1);
+export function StartServiceLogsStream(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartServiceLogsStream'](arg1, arg2, arg3);
 }
 
 export function StartWebAppDev() {
   return window['go']['main']['App']['StartWebAppDev']();
 }
 
+export function StashProject(arg1, arg2) {
+  return window['go']['main']['App']['StashProject'](arg1, arg2);
+}
+
 export function Status() {
   return window['go']['main']['App']['Status']();
 }
 
+export function StopAllServiceLogsStream() {
+  return window['go']['main']['App']['StopAllServiceLogsStream']();
+}
+
 export function StopAllServices() {
   return window['go']['main']['App']['StopAllServices']();
 }
 
+export function StopAllStreams() {
+  return window['go']['main']['App']['StopAllStreams']();
+}
+
 export function StopBackendGroup(arg1) {
   return window['go']['main']['App']['StopBackendGroup'](arg1);
 }
@@ -186,10 +446,18 @@ export function StopProjectStream(arg1, arg2) {
   return window['go']['main']['App']['StopProjectStream'](arg1, arg2);
 }
 
+export function StopProtoLintStream() {
+  return window['go']['main']['App']['StopProtoLintStream']();
+}
+
 export function StopProtoStream() {
   return window['go']['main']['App']['StopProtoStream']();
 }
 
+export function StopProtoWatch() {
+  return window['go']['main']['App']['StopProtoWatch']();
+}
+
 export function StopReleaseProtosGoStream() {
   return window['go']['main']['App']['StopReleaseProtosGoStream']();
 }
@@ -202,18 +470,54 @@ export function StopServiceLogsStream(arg1) {
   return window['go']['main']['App']['StopServiceLogsStream'](arg1);
 }
 
+export function StopStream(arg1) {
+  return window['go']['main']['App']['StopStream'](arg1);
+}
+
 export function StopWebAppDev() {
   return window['go']['main']['App']['StopWebAppDev']();
 }
 
-export function SubmoduleSync(arg1) {
-  return window['go']['main']['App']['SubmoduleSync'](arg1);
+export function SubmoduleDrift() {
+  return window['go']['main']['App']['SubmoduleDrift']();
+}
+
+export function SubmoduleSync(arg1, arg2) {
+  return window['go']['main']['App']['SubmoduleSync'](arg1, arg2);
+}
+
+export function SubmoduleSyncPreview() {
+  return window['go']['main']['App']['SubmoduleSyncPreview']();
 }
 
 export function SubmoduleSyncStatus() {
   return window['go']['main']['App']['SubmoduleSyncStatus']();
 }
 
+export function SuggestNextTag(arg1, arg2) {
+  return window['go']['main']['App']['SuggestNextTag'](arg1, arg2);
+}
+
+export function SuggestTagName(arg1) {
+  return window['go']['main']['App']['SuggestTagName'](arg1);
+}
+
+export function SyncMissingEnvVars() {
+  return window['go']['main']['App']['SyncMissingEnvVars']();
+}
+
+export function TestDatabaseConnection() {
+  return window['go']['main']['App']['TestDatabaseConnection']();
+}
+
+export function ToggleLocalReplace(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ToggleLocalReplace'](arg1, arg2, arg3);
+}
+
+export function UnstashProject(arg1) {
+  return window['go']['main']['App']['UnstashProject'](arg1);
+}
+
 export function UpdateEnvVar(arg1, arg2) {
   return window['go']['main']['App']['UpdateEnvVar'](arg1, arg2);
 }
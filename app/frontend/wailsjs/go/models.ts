@@ -1,5 +1,149 @@
+export namespace config {
+	
+	export class PortCollision {
+	    port: number;
+	    first: string;
+	    second: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortCollision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.port = source["port"];
+	        this.first = source["first"];
+	        this.second = source["second"];
+	    }
+	}
+
+}
+
+export namespace git {
+	
+	export class SubmoduleDriftInfo {
+	    name: string;
+	    recordedCommit: string;
+	    recordedSubject?: string;
+	    currentCommit: string;
+	    currentSubject?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubmoduleDriftInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.recordedCommit = source["recordedCommit"];
+	        this.recordedSubject = source["recordedSubject"];
+	        this.currentCommit = source["currentCommit"];
+	        this.currentSubject = source["currentSubject"];
+	    }
+	}
+
+}
+
 export namespace model {
 	
+	export class ActivityEvent {
+	    time: string;
+	    type: string;
+	    target: string;
+	    success: boolean;
+	    level: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.type = source["type"];
+	        this.target = source["target"];
+	        this.success = source["success"];
+	        this.level = source["level"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ActivityPage {
+	    events: ActivityEvent[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.events = this.convertValues(source["events"], ActivityEvent);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ActivityQuery {
+	    type?: string;
+	    target?: string;
+	    level?: string;
+	    since?: string;
+	    until?: string;
+	    search?: string;
+	    offset?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.target = source["target"];
+	        this.level = source["level"];
+	        this.since = source["since"];
+	        this.until = source["until"];
+	        this.search = source["search"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class Artifact {
+	    name: string;
+	    path: string;
+	    size: number;
+	    modifiedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Artifact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.modifiedAt = source["modifiedAt"];
+	    }
+	}
 	export class BackendService {
 	    name: string;
 	    group: string;
@@ -10,6 +154,9 @@ export namespace model {
 	    docsUrl?: string;
 	    error?: string;
 	    lastOutput?: string[];
+	    uptimeSeconds: number;
+	    restarts: number;
+	    adopted: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new BackendService(source);
@@ -26,6 +173,87 @@ export namespace model {
 	        this.docsUrl = source["docsUrl"];
 	        this.error = source["error"];
 	        this.lastOutput = source["lastOutput"];
+	        this.uptimeSeconds = source["uptimeSeconds"];
+	        this.restarts = source["restarts"];
+	        this.adopted = source["adopted"];
+	    }
+	}
+	export class CleanPlan {
+	    project: string;
+	    useMake: boolean;
+	    paths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CleanPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = source["project"];
+	        this.useMake = source["useMake"];
+	        this.paths = source["paths"];
+	    }
+	}
+	export class Commit {
+	    hash: string;
+	    author: string;
+	    timestamp: string;
+	    subject: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Commit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.author = source["author"];
+	        this.timestamp = source["timestamp"];
+	        this.subject = source["subject"];
+	    }
+	}
+	export class ContainerStats {
+	    container: string;
+	    cpuPercent: number;
+	    memUsageBytes: number;
+	    memLimitBytes: number;
+	    memPercent: number;
+	    netRxBytes: number;
+	    netTxBytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.container = source["container"];
+	        this.cpuPercent = source["cpuPercent"];
+	        this.memUsageBytes = source["memUsageBytes"];
+	        this.memLimitBytes = source["memLimitBytes"];
+	        this.memPercent = source["memPercent"];
+	        this.netRxBytes = source["netRxBytes"];
+	        this.netTxBytes = source["netTxBytes"];
+	    }
+	}
+	export class DepMismatch {
+	    project: string;
+	    dependency: string;
+	    required: string;
+	    local: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DepMismatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = source["project"];
+	        this.dependency = source["dependency"];
+	        this.required = source["required"];
+	        this.local = source["local"];
+	        this.message = source["message"];
 	    }
 	}
 	export class Dependency {
@@ -44,6 +272,147 @@ export namespace model {
 	        this.type = source["type"];
 	    }
 	}
+	export class DependencyEdge {
+	    from: string;
+	    to: string;
+	    version: string;
+	    type: string;
+	    inCycle: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.version = source["version"];
+	        this.type = source["type"];
+	        this.inCycle = source["inCycle"];
+	    }
+	}
+	export class DependencyNode {
+	    name: string;
+	    error?: string;
+	    inCycle: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.error = source["error"];
+	        this.inCycle = source["inCycle"];
+	    }
+	}
+	export class DependencyGraph {
+	    nodes: DependencyNode[];
+	    edges: DependencyEdge[];
+	    cycles: string[][];
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyGraph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodes = this.convertValues(source["nodes"], DependencyNode);
+	        this.edges = this.convertValues(source["edges"], DependencyEdge);
+	        this.cycles = source["cycles"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DoctorCheck {
+	    id: string;
+	    name: string;
+	    status: string;
+	    message: string;
+	    hint?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DoctorCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.hint = source["hint"];
+	    }
+	}
+	export class DoctorReport {
+	    status: string;
+	    checks: DoctorCheck[];
+	    ranAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DoctorReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.checks = this.convertValues(source["checks"], DoctorCheck);
+	        this.ranAt = source["ranAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EnvImportSummary {
+	    added: string[];
+	    updated: string[];
+	    skipped: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvImportSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.updated = source["updated"];
+	        this.skipped = source["skipped"];
+	    }
+	}
 	export class EnvVar {
 	    name: string;
 	    value: string;
@@ -102,7 +471,71 @@ export namespace model {
 		    return a;
 		}
 	}
+	export class EnvValidationError {
+	    name: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvValidationError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class EnvValidity {
+	    hasEnvFile: boolean;
+	    valid: boolean;
+	    missing: string[];
+	    errors: EnvValidationError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvValidity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hasEnvFile = source["hasEnvFile"];
+	        this.valid = source["valid"];
+	        this.missing = source["missing"];
+	        this.errors = this.convertValues(source["errors"], EnvValidationError);
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LocalReplace {
+	    module: string;
+	    path: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocalReplace(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.path = source["path"];
+	    }
+	}
 	export class Migration {
 	    version: number;
 	    name: string;
@@ -155,6 +588,24 @@ export namespace model {
 		    return a;
 		}
 	}
+	export class MigrationSummary {
+	    currentVersion: number;
+	    dirty: boolean;
+	    applied: number;
+	    pending: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MigrationSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currentVersion = source["currentVersion"];
+	        this.dirty = source["dirty"];
+	        this.applied = source["applied"];
+	        this.pending = source["pending"];
+	    }
+	}
 	export class Notice {
 	    id: string;
 	    severity: string;
@@ -173,24 +624,26 @@ export namespace model {
 	        this.actionKey = source["actionKey"];
 	    }
 	}
-	export class Prerequisite {
+	export class Service {
 	    name: string;
-	    installed: boolean;
-	    version?: string;
-	    required: boolean;
-	    message?: string;
+	    port: number;
+	    status: string;
+	    health: string;
+	    url?: string;
+	    containerName?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Prerequisite(source);
+	        return new Service(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.installed = source["installed"];
-	        this.version = source["version"];
-	        this.required = source["required"];
-	        this.message = source["message"];
+	        this.port = source["port"];
+	        this.status = source["status"];
+	        this.health = source["health"];
+	        this.url = source["url"];
+	        this.containerName = source["containerName"];
 	    }
 	}
 	export class Project {
@@ -201,6 +654,14 @@ export namespace model {
 	    status: string;
 	    language?: string;
 	    repoUrl?: string;
+	    shallow: boolean;
+	    sizeBytes?: number;
+	    staged: number;
+	    unstaged: number;
+	    untracked: number;
+	    ahead: number;
+	    behind: number;
+	    hasUpstream: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Project(source);
@@ -215,6 +676,145 @@ export namespace model {
 	        this.status = source["status"];
 	        this.language = source["language"];
 	        this.repoUrl = source["repoUrl"];
+	        this.shallow = source["shallow"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.staged = source["staged"];
+	        this.unstaged = source["unstaged"];
+	        this.untracked = source["untracked"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.hasUpstream = source["hasUpstream"];
+	    }
+	}
+	export class Overview {
+	    generatedAt: string;
+	    projects: Project[];
+	    projectsError?: string;
+	    services: Service[];
+	    backend: BackendService[];
+	    migration?: MigrationSummary;
+	    migrationError?: string;
+	    env?: EnvValidity;
+	    envError?: string;
+	    notices: Notice[];
+	    noticesError?: string;
+	    dockerConnected: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Overview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generatedAt = source["generatedAt"];
+	        this.projects = this.convertValues(source["projects"], Project);
+	        this.projectsError = source["projectsError"];
+	        this.services = this.convertValues(source["services"], Service);
+	        this.backend = this.convertValues(source["backend"], BackendService);
+	        this.migration = this.convertValues(source["migration"], MigrationSummary);
+	        this.migrationError = source["migrationError"];
+	        this.env = this.convertValues(source["env"], EnvValidity);
+	        this.envError = source["envError"];
+	        this.notices = this.convertValues(source["notices"], Notice);
+	        this.noticesError = source["noticesError"];
+	        this.dockerConnected = source["dockerConnected"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Prerequisite {
+	    name: string;
+	    installed: boolean;
+	    version?: string;
+	    required: boolean;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Prerequisite(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.installed = source["installed"];
+	        this.version = source["version"];
+	        this.required = source["required"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ProcessStats {
+	    name: string;
+	    pid: number;
+	    rssBytes: number;
+	    cpuPercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProcessStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pid = source["pid"];
+	        this.rssBytes = source["rssBytes"];
+	        this.cpuPercent = source["cpuPercent"];
+	    }
+	}
+	
+	export class ProjectSize {
+	    name: string;
+	    sizeBytes: number;
+	    gitBytes: number;
+	    measuredAt: string;
+	    gitUnavailable?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectSize(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.gitBytes = source["gitBytes"];
+	        this.measuredAt = source["measuredAt"];
+	        this.gitUnavailable = source["gitUnavailable"];
+	    }
+	}
+	export class ProtoLintIssue {
+	    file: string;
+	    line?: number;
+	    column?: number;
+	    rule?: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProtoLintIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.rule = source["rule"];
+	        this.message = source["message"];
 	    }
 	}
 	export class ProtoStatus {
@@ -233,22 +833,129 @@ export namespace model {
 	        this.protosPath = source["protosPath"];
 	    }
 	}
-	export class Service {
+	
+	export class ServiceEnvUsage {
+	    service: string;
+	    used: string[];
+	    undeclared: string[];
+	    unused: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ServiceEnvUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.used = source["used"];
+	        this.undeclared = source["undeclared"];
+	        this.unused = source["unused"];
+	    }
+	}
+	export class StreamEvent {
+	    seq: number;
+	    event: string;
+	    payload: {[key: string]: any};
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.event = source["event"];
+	        this.payload = source["payload"];
+	    }
+	}
+	export class StreamFinalState {
+	    token: string;
+	    wasActive: boolean;
+	    completed: boolean;
+	    lines: number;
+	    exitCode?: number;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamFinalState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.wasActive = source["wasActive"];
+	        this.completed = source["completed"];
+	        this.lines = source["lines"];
+	        this.exitCode = source["exitCode"];
+	        this.message = source["message"];
+	    }
+	}
+	export class StreamReplay {
+	    token: string;
+	    active: boolean;
+	    reset: boolean;
+	    run: number;
+	    truncated: boolean;
+	    lastSeq: number;
+	    events: StreamEvent[];
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamReplay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.active = source["active"];
+	        this.reset = source["reset"];
+	        this.run = source["run"];
+	        this.truncated = source["truncated"];
+	        this.lastSeq = source["lastSeq"];
+	        this.events = this.convertValues(source["events"], StreamEvent);
+	        this.message = source["message"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SubmoduleChange {
 	    name: string;
-	    port: number;
-	    status: string;
-	    url?: string;
+	    oldCommit: string;
+	    oldSubject?: string;
+	    newCommit: string;
+	    newSubject?: string;
+	    commits: number;
+	    dropped: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Service(source);
+	        return new SubmoduleChange(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.port = source["port"];
-	        this.status = source["status"];
-	        this.url = source["url"];
+	        this.oldCommit = source["oldCommit"];
+	        this.oldSubject = source["oldSubject"];
+	        this.newCommit = source["newCommit"];
+	        this.newSubject = source["newSubject"];
+	        this.commits = source["commits"];
+	        this.dropped = source["dropped"];
 	    }
 	}
 
@@ -279,6 +986,9 @@ export namespace service {
 	    teams: string[];
 	    views: string[];
 	    commands: string[];
+	    scopes: string[];
+	    missingScopes?: string[];
+	    warning?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Permissions(source);
@@ -292,6 +1002,9 @@ export namespace service {
 	        this.teams = source["teams"];
 	        this.views = source["views"];
 	        this.commands = source["commands"];
+	        this.scopes = source["scopes"];
+	        this.missingScopes = source["missingScopes"];
+	        this.warning = source["warning"];
 	    }
 	}
 
@@ -4,7 +4,14 @@ go 1.22.0
 
 toolchain go1.22.4
 
-require github.com/wailsapp/wails/v2 v2.9.1
+require (
+	github.com/fsnotify/fsnotify v1.7.0
+	github.com/lib/pq v1.10.9
+	github.com/wailsapp/wails/v2 v2.9.1
+	golang.org/x/mod v0.17.0
+	golang.org/x/sync v0.11.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/bep/debounce v1.2.1 // indirect
@@ -0,0 +1,223 @@
+// Package activity persists the dashboard's Activity feed (service starts/stops/crashes, migrations,
+// clones, tags, ...) as a bounded JSONL file so it survives restarts.
+package activity
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+const fileName = "activity.jsonl"
+
+// DefaultMaxEvents is how many events a store keeps when none is given
+const DefaultMaxEvents = 1000
+
+// Store appends activity events to activity.jsonl in a directory. The file is compacted to the
+// newest maxEvents events once it grows to twice that, so appends stay cheap.
+type Store struct {
+	mu        sync.Mutex
+	path      string
+	maxEvents int
+	count     int // lines in the file; -1 until counted
+}
+
+// NewStore returns a store writing to dir/activity.jsonl, keeping at most maxEvents events
+// (DefaultMaxEvents when maxEvents <= 0)
+func NewStore(dir string, maxEvents int) *Store {
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxEvents
+	}
+	return &Store{path: filepath.Join(dir, fileName), maxEvents: maxEvents, count: -1}
+}
+
+// Append adds an event to the end of the file
+func (s *Store) Append(e model.ActivityEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count < 0 {
+		events, err := s.readAll()
+		if err != nil {
+			return err
+		}
+		s.count = len(events)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	s.count++
+
+	if s.count >= 2*s.maxEvents {
+		return s.compact()
+	}
+	return nil
+}
+
+// Query returns the kept events matching q, newest first, paged by q.Offset/q.Limit, plus the number
+// of matches before paging. Since/Until must be RFC3339 when set.
+func (s *Store) Query(q model.ActivityQuery) (*model.ActivityPage, error) {
+	var since, until time.Time
+	var err error
+	if q.Since != "" {
+		if since, err = time.Parse(time.RFC3339, q.Since); err != nil {
+			return nil, fmt.Errorf("invalid since time: %w", err)
+		}
+	}
+	if q.Until != "" {
+		if until, err = time.Parse(time.RFC3339, q.Until); err != nil {
+			return nil, fmt.Errorf("invalid until time: %w", err)
+		}
+	}
+	search := strings.ToLower(q.Search)
+
+	s.mu.Lock()
+	events, err := s.readAll()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > s.maxEvents {
+		events = events[len(events)-s.maxEvents:]
+	}
+
+	page := &model.ActivityPage{Events: []model.ActivityEvent{}}
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		if (q.Type != "" && e.Type != q.Type) || (q.Target != "" && e.Target != q.Target) ||
+			(q.Level != "" && e.Level != q.Level) {
+			continue
+		}
+		if !since.IsZero() || !until.IsZero() {
+			t, err := time.Parse(time.RFC3339, e.Time)
+			if err != nil || (!since.IsZero() && t.Before(since)) || (!until.IsZero() && t.After(until)) {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Message), search) &&
+			!strings.Contains(strings.ToLower(e.Target), search) {
+			continue
+		}
+		if page.Total >= q.Offset && (q.Limit <= 0 || len(page.Events) < q.Limit) {
+			page.Events = append(page.Events, e)
+		}
+		page.Total++
+	}
+	return page, nil
+}
+
+// Clear removes every event
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.count = 0
+	return nil
+}
+
+// storedEvent is an event line as read from the file. Events written before the kind/source keys
+// were renamed to type/target (and success was added) still carry the old keys.
+type storedEvent struct {
+	model.ActivityEvent
+	Kind    string `json:"kind"`
+	Source  string `json:"source"`
+	Success *bool  `json:"success"`
+}
+
+// event returns the stored event in the current shape; an old event without success counts as
+// successful unless it was logged at error level
+func (e storedEvent) event() model.ActivityEvent {
+	ev := e.ActivityEvent
+	if ev.Type == "" {
+		ev.Type = e.Kind
+	}
+	if ev.Target == "" {
+		ev.Target = e.Source
+	}
+	if e.Success != nil {
+		ev.Success = *e.Success
+	} else {
+		ev.Success = ev.Level != "error"
+	}
+	return ev
+}
+
+// readAll reads every event in the file, skipping lines that don't parse (e.g. a torn last write).
+// Old-format events are converted (see storedEvent); compaction rewrites them in the current format.
+func (s *Store) readAll() ([]model.ActivityEvent, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return []model.ActivityEvent{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := []model.ActivityEvent{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e storedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		events = append(events, e.event())
+	}
+	return events, scanner.Err()
+}
+
+// compact rewrites the file with only the newest maxEvents events (temp file + rename)
+func (s *Store) compact() error {
+	events, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	if len(events) > s.maxEvents {
+		events = events[len(events)-s.maxEvents:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	s.count = len(events)
+	return nil
+}
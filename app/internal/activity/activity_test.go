@@ -0,0 +1,47 @@
+package activity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+func TestQueryReadsOldEventKeys(t *testing.T) {
+	dir := t.TempDir()
+	old := `{"time":"2026-01-02T03:04:05Z","kind":"backend","source":"api","level":"info","message":"Started"}
+{"time":"2026-01-02T03:05:05Z","kind":"migration","source":"up","level":"error","message":"Migration failed"}
+`
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := NewStore(dir, DefaultMaxEvents)
+	current := model.ActivityEvent{Time: "2026-01-02T03:06:05Z", Type: "project", Target: "core", Success: false, Level: "info", Message: "Clone failed"}
+	if err := s.Append(current); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := s.Query(model.ActivityQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.ActivityEvent{
+		current,
+		{Time: "2026-01-02T03:05:05Z", Type: "migration", Target: "up", Success: false, Level: "error", Message: "Migration failed"},
+		{Time: "2026-01-02T03:04:05Z", Type: "backend", Target: "api", Success: true, Level: "info", Message: "Started"},
+	}
+	if !reflect.DeepEqual(page.Events, want) {
+		t.Errorf("events = %+v, want %+v", page.Events, want)
+	}
+
+	// Filters apply to the converted keys
+	page, err = s.Query(model.ActivityQuery{Type: "backend", Target: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || page.Events[0].Message != "Started" {
+		t.Errorf("query type=backend target=api = %+v, want the old Started event", page.Events)
+	}
+}
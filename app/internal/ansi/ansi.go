@@ -0,0 +1,63 @@
+// Package ansi removes terminal escape sequences (colors, cursor movement, titles) from command output.
+package ansi
+
+import "strings"
+
+// Parser states
+const (
+	stateText = iota
+	stateEsc  // after ESC
+	stateCSI  // ESC [ ... final byte
+	stateOSC  // ESC ] ... BEL or ESC \
+	stateOSCEsc
+)
+
+// Strip returns s without ANSI escape sequences: CSI (ESC [ ... e.g. colors), OSC (ESC ] ... e.g.
+// window titles and hyperlinks) and two-byte escapes. Text without ESC is returned unchanged.
+func Strip(s string) string {
+	if strings.IndexByte(s, 0x1b) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	state := stateText
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch state {
+		case stateText:
+			if c == 0x1b {
+				state = stateEsc
+			} else {
+				b.WriteByte(c)
+			}
+		case stateEsc:
+			switch c {
+			case '[':
+				state = stateCSI
+			case ']':
+				state = stateOSC
+			default:
+				state = stateText // two-byte escape (e.g. ESC c); drop both bytes
+			}
+		case stateCSI:
+			// Parameter and intermediate bytes are 0x20-0x3f; a final byte 0x40-0x7e ends the sequence
+			if c >= 0x40 && c <= 0x7e {
+				state = stateText
+			}
+		case stateOSC:
+			if c == 0x07 {
+				state = stateText
+			} else if c == 0x1b {
+				state = stateOSCEsc
+			}
+		case stateOSCEsc:
+			if c == '\\' {
+				state = stateText
+			} else {
+				state = stateOSC
+			}
+		}
+	}
+	return b.String()
+}
@@ -1,6 +1,14 @@
 package config
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultStartTimeout is how long a service may take to become ready when StartTimeout is not set
+const DefaultStartTimeout = 15 * time.Second
 
 // BackendServiceConfig defines a WabiSaby-Go service
 type BackendServiceConfig struct {
@@ -11,10 +19,83 @@ type BackendServiceConfig struct {
 	Port       int
 	HealthPath string // e.g., "/health"
 	DocsPath   string // e.g., "/docs"
+
+	HealthMethod       string // HTTP method for the health check (empty = GET)
+	HealthExpectStatus int    // exact status the health check must return (0 = any 2xx)
+
+	StartTimeout time.Duration // how long to wait for the service to become ready (0 = DefaultStartTimeout)
+	DependsOn    []string      // services that must be ready before this one starts (any group)
+
+	// EnvOverrides are set for this service only, on top of .env (see SetServiceEnvOverrides).
+	// Precedence when starting: dashboard process env < .env < EnvOverrides.
+	EnvOverrides map[string]string
+}
+
+var (
+	envOverridesMu      sync.RWMutex
+	serviceEnvOverrides map[string]map[string]string
+)
+
+// SetServiceEnvOverrides replaces the per-service env overrides (service name -> var -> value)
+// applied to the services returned by GetBackendServices
+func SetServiceEnvOverrides(overrides map[string]map[string]string) {
+	copied := make(map[string]map[string]string, len(overrides))
+	for name, vars := range overrides {
+		copied[name] = make(map[string]string, len(vars))
+		for k, v := range vars {
+			copied[name][k] = v
+		}
+	}
+	envOverridesMu.Lock()
+	serviceEnvOverrides = copied
+	envOverridesMu.Unlock()
+}
+
+// StartupTimeout returns how long to wait for the service to become ready after starting it
+func (c BackendServiceConfig) StartupTimeout() time.Duration {
+	if c.StartTimeout <= 0 {
+		return DefaultStartTimeout
+	}
+	return c.StartTimeout
+}
+
+// HealthCheckMethod returns the HTTP method used to probe the service's health endpoint
+func (c BackendServiceConfig) HealthCheckMethod() string {
+	if c.HealthMethod == "" {
+		return "GET"
+	}
+	return strings.ToUpper(c.HealthMethod)
 }
 
-// GetBackendServices returns all configured WabiSaby-Go services
+// HealthStatusOK reports whether statusCode counts as healthy for this service
+func (c BackendServiceConfig) HealthStatusOK(statusCode int) bool {
+	if c.HealthExpectStatus != 0 {
+		return statusCode == c.HealthExpectStatus
+	}
+	return statusCode >= 200 && statusCode < 300
+}
+
+// GetBackendServices returns all configured WabiSaby-Go services, with their env overrides
 func GetBackendServices() []BackendServiceConfig {
+	services := defaultBackendServices()
+
+	envOverridesMu.RLock()
+	defer envOverridesMu.RUnlock()
+	for i := range services {
+		vars := serviceEnvOverrides[services[i].Name]
+		if len(vars) == 0 {
+			continue
+		}
+		services[i].EnvOverrides = make(map[string]string, len(vars))
+		for k, v := range vars {
+			services[i].EnvOverrides[k] = v
+		}
+	}
+	return services
+}
+
+// defaultBackendServices returns the built-in WabiSaby-Go service definitions
+func defaultBackendServices() []BackendServiceConfig {
 	return []BackendServiceConfig{
 		// Backend services (core.yaml)
 		{
@@ -24,6 +105,8 @@ func GetBackendServices() []BackendServiceConfig {
 			Port:       8080,
 			HealthPath: "/health",
 			DocsPath:   "/docs",
+			// Connects to the database and runs startup checks before serving
+			StartTimeout: 30 * time.Second,
 		},
 		{
 			Name:    "websocket",
@@ -42,10 +125,11 @@ func GetBackendServices() []BackendServiceConfig {
 
 		// Node (separate repo: wabisaby-node)
 		{
-			Name:     "node",
-			CmdPath:  "./cmd/node",
-			Group:    "mesh",
-			RepoName: "wabisaby-node",
+			Name:      "node",
+			CmdPath:   "./cmd/node",
+			Group:     "mesh",
+			RepoName:  "wabisaby-node",
+			DependsOn: []string{"network-coordinator"},
 		},
 
 		// Plugin infrastructure
@@ -56,14 +140,16 @@ func GetBackendServices() []BackendServiceConfig {
 			Port:    50051,
 		},
 		{
-			Name:    "stateful-plugin-worker",
-			CmdPath: "./cmd/stateful-plugin-worker",
-			Group:   "plugins",
+			Name:      "stateful-plugin-worker",
+			CmdPath:   "./cmd/stateful-plugin-worker",
+			Group:     "plugins",
+			DependsOn: []string{"capabilities-server"},
 		},
 		{
-			Name:    "stateless-plugin-worker",
-			CmdPath: "./cmd/stateless-plugin-worker",
-			Group:   "plugins",
+			Name:      "stateless-plugin-worker",
+			CmdPath:   "./cmd/stateless-plugin-worker",
+			Group:     "plugins",
+			DependsOn: []string{"capabilities-server"},
 		},
 	}
 }
@@ -89,6 +175,56 @@ func GetServicesByGroup(group string) []BackendServiceConfig {
 	return services
 }
 
+// OrderByDependencies returns services in start order: every service comes after the services it
+// depends on, which are added (transitively, from any group) if not already in the list.
+// Returns an error naming the services involved on a dependency cycle or an unknown dependency.
+func OrderByDependencies(services []BackendServiceConfig) ([]BackendServiceConfig, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var ordered []BackendServiceConfig
+
+	var visit func(svc BackendServiceConfig, path []string) error
+	visit = func(svc BackendServiceConfig, path []string) error {
+		switch state[svc.Name] {
+		case visited:
+			return nil
+		case visiting:
+			for i, name := range path {
+				if name == svc.Name {
+					path = path[i:]
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(path, " -> "), svc.Name)
+		}
+
+		state[svc.Name] = visiting
+		path = append(path, svc.Name)
+		for _, depName := range svc.DependsOn {
+			dep := GetServiceByName(depName)
+			if dep == nil {
+				return fmt.Errorf("service %s depends on unknown service %s", svc.Name, depName)
+			}
+			if err := visit(*dep, path); err != nil {
+				return err
+			}
+		}
+		state[svc.Name] = visited
+		ordered = append(ordered, svc)
+		return nil
+	}
+
+	for _, svc := range services {
+		if err := visit(svc, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
 // RequiredEnvVars returns the list of required environment variables
 func RequiredEnvVars() []string {
 	return []string{
@@ -117,6 +253,41 @@ func OptionalEnvVars() []string {
 	}
 }
 
+// EnvVarKind is the type of value an env var must hold
+type EnvVarKind string
+
+const (
+	EnvKindInt  EnvVarKind = "int"  // integer within [Min, Max] when set
+	EnvKindPort EnvVarKind = "port" // integer 1-65535
+	EnvKindBool EnvVarKind = "bool" // true/false/1/0
+	EnvKindURL  EnvVarKind = "url"  // absolute URL, scheme limited to Schemes when set
+	EnvKindEnum EnvVarKind = "enum" // one of Values (case-insensitive)
+)
+
+// EnvVarSpec declares the type and constraints of an env var's value
+type EnvVarSpec struct {
+	Kind     EnvVarKind
+	Min, Max int      // EnvKindInt bounds (both 0 = unbounded)
+	Schemes  []string // EnvKindURL allowed schemes (empty = any)
+	Values   []string // EnvKindEnum allowed values
+}
+
+// EnvVarSchema returns the type declarations used to validate .env values. Vars not listed
+// here are only checked for presence (when required).
+func EnvVarSchema() map[string]EnvVarSpec {
+	return map[string]EnvVarSpec{
+		"DATABASE_URL":               {Kind: EnvKindURL, Schemes: []string{"postgres", "postgresql"}},
+		"REDIS_URL":                  {Kind: EnvKindURL, Schemes: []string{"redis", "rediss"}},
+		"PORT":                       {Kind: EnvKindPort},
+		"WEBSOCKET_PORT":             {Kind: EnvKindPort},
+		"CAPABILITIES_PORT":          {Kind: EnvKindPort},
+		"WABISABY_VAULT_ENABLED":     {Kind: EnvKindBool},
+		"WABISABY_VAULT_ADDRESS":     {Kind: EnvKindURL, Schemes: []string{"http", "https"}},
+		"WABISABY_KEYCLOAK_BASE_URL": {Kind: EnvKindURL, Schemes: []string{"http", "https"}},
+		"LOG_LEVEL":                  {Kind: EnvKindEnum, Values: []string{"debug", "info", "warn", "error"}},
+	}
+}
+
 // SensitiveEnvVars returns a set of known sensitive variable names
 func SensitiveEnvVars() map[string]bool {
 	return map[string]bool{
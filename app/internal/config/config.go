@@ -1,9 +1,13 @@
 package config
 
 import (
+	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds application configuration for the Wails desktop app
@@ -13,9 +17,42 @@ type Config struct {
 	AppDataDir       string // Always Application Support; used for auth, never overridden by workspace
 	WabisabyCorePath string
 	GitHubClientID   string
-	GitHubOrg        string
+	GitHubOrgs       []string          // Orgs whose teams grant permissions; teams are "org/slug" when more than one
+	GitHubScopes     []string          // OAuth scopes for the device flow; empty = read:org
+	AutoStartDB      bool              // Start PostgreSQL automatically when a migration finds it down
+	ArtifactDirs     []string          // Project-relative build output dirs (default bin)
+	CloneDepth       int               // History depth for new project clones; 0 = full history
+	ProtoTargets     map[string]string // Extra/overridden proto generate targets (name -> make target)
+
+	BackendPollInterval time.Duration // Backend status poll interval; 0 disables change events
+	BackendBuildMode    string        // BackendBuildModeRun or BackendBuildModeBuild (from settings.json)
+	KeepANSI            bool          // Keep ANSI escape codes in streamed output (from settings.json)
+	GitHubTeamsTTL      time.Duration // How long cached team memberships are reused; 0 = 10m
+	HTTPTimeout         time.Duration // Overall timeout for outbound GitHub/health requests; 0 = 30s
+	BulkParallelism     int           // Projects a bulk make action runs at once
+	TagPrefix           string        // "v" prefix rule for version tags: "v" (default), "none" or "any"
+	StopTimeout         time.Duration // SIGTERM to SIGKILL when stopping a backend service; 0 = 10s
+	StopAllTimeout      time.Duration // Overall deadline for stopping backend services on quit; 0 = 15s
+
+	CrashWebhookURL    string // POSTed to when a backend service crashes; empty = disabled
+	CrashWebhookFormat string // CrashWebhookFormatJSON, CrashWebhookFormatSlack or CrashWebhookFormatDiscord
 }
 
+const defaultBackendPollInterval = 3 * time.Second
+
+const defaultCloneDepth = 1
+
+const defaultArtifactDir = "bin"
+
+const defaultBulkParallelism = 2
+
+// Crash webhook payload formats
+const (
+	CrashWebhookFormatJSON    = "json"    // {"service", "error", "lastOutput"}
+	CrashWebhookFormatSlack   = "slack"   // Slack incoming webhook ({"text"})
+	CrashWebhookFormatDiscord = "discord" // Discord webhook ({"content"})
+)
+
 const defaultGitHubClientID = "Ov23li37D0pETvomgch9"
 
 const appDataDirName = "wabisaby-devkit"
@@ -101,26 +138,179 @@ func Load() (*Config, error) {
 		}
 	}
 
+	// Dashboard Docker services: defaults plus docker/services.json
+	if err := LoadDockerServices(devkitRoot); err != nil {
+		log.Printf("Using default Docker services: %v", err)
+	}
+	// Dashboard projects: defaults plus projects.json
+	if err := LoadProjects(devkitRoot); err != nil {
+		log.Printf("Using default projects: %v", err)
+	}
+	for _, c := range CheckPortCollisions() {
+		log.Printf("Warning: %s", c)
+	}
+
 	// GitHub integration
 	githubClientID := os.Getenv("WABISABY_GITHUB_CLIENT_ID")
 	if githubClientID == "" {
 		githubClientID = defaultGitHubClientID
 	}
-	githubOrg := os.Getenv("WABISABY_GITHUB_ORG")
-	if githubOrg == "" {
-		githubOrg = "WabiSaby"
+	// Comma- or space-separated, e.g. "WabiSaby,WabiSaby-Plugins"
+	githubOrgs := splitList(os.Getenv("WABISABY_GITHUB_ORG"))
+	if len(githubOrgs) == 0 {
+		githubOrgs = []string{"WabiSaby"}
+	}
+	// Comma- or space-separated, e.g. "read:org,repo" when release/PR features are enabled
+	githubScopes := splitList(os.Getenv("WABISABY_GITHUB_SCOPES"))
+	// Go duration, e.g. "30m"
+	var githubTeamsTTL time.Duration
+	if v := os.Getenv("WABISABY_GITHUB_TEAMS_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			githubTeamsTTL = d
+		} else {
+			log.Printf("Ignoring invalid WABISABY_GITHUB_TEAMS_TTL %q", v)
+		}
+	}
+
+	// Go duration, e.g. "5s"; "0" disables background backend status polling
+	backendPollInterval := defaultBackendPollInterval
+	if v := os.Getenv("WABISABY_BACKEND_POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			backendPollInterval = d
+		} else {
+			log.Printf("Ignoring invalid WABISABY_BACKEND_POLL_INTERVAL %q", v)
+		}
+	}
+
+	// Go duration, e.g. "60s"; raise it when a slow corporate proxy sits in front of GitHub
+	var httpTimeout time.Duration
+	if v := os.Getenv("WABISABY_HTTP_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			httpTimeout = d
+		} else {
+			log.Printf("Ignoring invalid WABISABY_HTTP_TIMEOUT %q", v)
+		}
+	}
+
+	// Go durations, e.g. "20s"; how long a backend service gets to exit after SIGTERM, and how long
+	// stopping all of them on quit may take before the rest are killed outright
+	var stopTimeout, stopAllTimeout time.Duration
+	if v := os.Getenv("WABISABY_STOP_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			stopTimeout = d
+		} else {
+			log.Printf("Ignoring invalid WABISABY_STOP_TIMEOUT %q", v)
+		}
+	}
+	if v := os.Getenv("WABISABY_STOP_ALL_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			stopAllTimeout = d
+		} else {
+			log.Printf("Ignoring invalid WABISABY_STOP_ALL_TIMEOUT %q", v)
+		}
+	}
+
+	// Commits of history for new clones; "0" clones full history
+	cloneDepth := defaultCloneDepth
+	if v := os.Getenv("WABISABY_CLONE_DEPTH"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cloneDepth = n
+		} else {
+			log.Printf("Ignoring invalid WABISABY_CLONE_DEPTH %q", v)
+		}
+	}
+
+	// Projects run concurrently by bulk actions; "1" runs them one at a time
+	bulkParallelism := defaultBulkParallelism
+	if v := os.Getenv("WABISABY_BULK_PARALLELISM"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			bulkParallelism = n
+		} else {
+			log.Printf("Ignoring invalid WABISABY_BULK_PARALLELISM %q", v)
+		}
+	}
+
+	// Comma- or space-separated name=make-target pairs, e.g. "web=proto-ts,plugin=proto-plugin"
+	protoTargets := make(map[string]string)
+	for _, pair := range splitList(os.Getenv("WABISABY_PROTO_TARGETS")) {
+		name, target, ok := strings.Cut(pair, "=")
+		if !ok || name == "" || target == "" || strings.HasPrefix(target, "-") {
+			log.Printf("Ignoring invalid WABISABY_PROTO_TARGETS entry %q", pair)
+			continue
+		}
+		protoTargets[name] = target
+	}
+
+	// Project-relative dirs holding build output, e.g. "bin,out"
+	artifactDirs := splitList(os.Getenv("WABISABY_ARTIFACT_DIRS"))
+	if len(artifactDirs) == 0 {
+		artifactDirs = []string{defaultArtifactDir}
+	}
+
+	// Whether version tags start with "v": v (default, "v1.2.3"), none ("1.2.3") or any
+	tagPrefix := "v"
+	if v := strings.ToLower(os.Getenv("WABISABY_TAG_PREFIX")); v != "" {
+		switch v {
+		case "v", "none", "any":
+			tagPrefix = v
+		default:
+			log.Printf("Ignoring invalid WABISABY_TAG_PREFIX %q", v)
+		}
+	}
+
+	// Payload format for WABISABY_CRASH_WEBHOOK_URL: json (default), slack or discord
+	crashWebhookFormat := CrashWebhookFormatJSON
+	if v := strings.ToLower(os.Getenv("WABISABY_CRASH_WEBHOOK_FORMAT")); v != "" {
+		switch v {
+		case CrashWebhookFormatJSON, CrashWebhookFormatSlack, CrashWebhookFormatDiscord:
+			crashWebhookFormat = v
+		default:
+			log.Printf("Ignoring invalid WABISABY_CRASH_WEBHOOK_FORMAT %q", v)
+		}
 	}
 
+	// User preferences saved from the dashboard
+	settings, err := LoadSettings(appDataPath)
+	if err != nil {
+		log.Printf("Ignoring unreadable settings: %v", err)
+	}
+	SetServiceEnvOverrides(settings.ServiceEnvOverrides)
+
 	return &Config{
 		DevKitRoot:       devkitRoot,
 		ProjectsDir:      projectsDir,
 		AppDataDir:       appDataPath,
 		WabisabyCorePath: wabisabyCorePath,
 		GitHubClientID:   githubClientID,
-		GitHubOrg:        githubOrg,
+		GitHubOrgs:       githubOrgs,
+		GitHubScopes:     githubScopes,
+		AutoStartDB:      os.Getenv("WABISABY_AUTO_START_DB") == "true" || os.Getenv("WABISABY_AUTO_START_DB") == "1",
+		ArtifactDirs:     artifactDirs,
+		CloneDepth:       cloneDepth,
+		ProtoTargets:     protoTargets,
+
+		BackendPollInterval: backendPollInterval,
+		BackendBuildMode:    settings.BackendBuildMode,
+		KeepANSI:            settings.KeepANSI,
+		GitHubTeamsTTL:      githubTeamsTTL,
+		HTTPTimeout:         httpTimeout,
+		BulkParallelism:     bulkParallelism,
+		TagPrefix:           tagPrefix,
+		StopTimeout:         stopTimeout,
+		StopAllTimeout:      stopAllTimeout,
+
+		CrashWebhookURL:    os.Getenv("WABISABY_CRASH_WEBHOOK_URL"),
+		CrashWebhookFormat: crashWebhookFormat,
 	}, nil
 }
 
+// splitList splits a comma- or space-separated env value
+func splitList(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
 func findDevKitRootFromCwd() (string, bool) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DockerServiceConfig defines a docker-compose service shown on the dashboard
+type DockerServiceConfig struct {
+	Name          string   `json:"name"`                    // Display name, e.g. "PostgreSQL"
+	ComposeName   string   `json:"composeName"`             // docker-compose service name (empty = lowercased name)
+	ContainerName string   `json:"containerName"`           // Docker container name used for status checks
+	Port          int      `json:"port"`                    // Host port
+	ContainerPort int      `json:"containerPort,omitempty"` // Container port published as Port (0 = same as Port)
+	UIURL         string   `json:"uiUrl,omitempty"`         // Web UI URL when applicable
+	Companions    []string `json:"companions,omitempty"`    // Compose services started/stopped alongside (e.g. pgadmin)
+}
+
+// ComposeService returns the docker-compose service name
+func (c DockerServiceConfig) ComposeService() string {
+	if c.ComposeName != "" {
+		return c.ComposeName
+	}
+	return strings.ToLower(c.Name)
+}
+
+// TargetPort returns the container-side port that Port publishes
+func (c DockerServiceConfig) TargetPort() int {
+	if c.ContainerPort != 0 {
+		return c.ContainerPort
+	}
+	return c.Port
+}
+
+// dockerServicesFile is the optional devkit-relative file adding or overriding dashboard Docker services
+const dockerServicesFile = "docker/services.json"
+
+var (
+	dockerServicesMu   sync.RWMutex
+	configuredServices = defaultDockerServices() // defaults merged with docker/services.json
+	composeServices    []DockerServiceConfig     // discovered in the compose file (SetComposeServices)
+	dockerServices     = defaultDockerServices() // configuredServices plus the new composeServices
+)
+
+// defaultDockerServices returns the services defined in docker/docker-compose.yml
+func defaultDockerServices() []DockerServiceConfig {
+	return []DockerServiceConfig{
+		{Name: "PostgreSQL", ComposeName: "postgres", ContainerName: "wabisaby-postgres", Port: 5432, Companions: []string{"pgadmin"}},
+		{Name: "Redis", ComposeName: "redis", ContainerName: "wabisaby-redis", Port: 6379, Companions: []string{"redis-commander"}},
+		{Name: "RedisCommander", ComposeName: "redis-commander", ContainerName: "wabisaby-redis-commander", Port: 8081, UIURL: "http://localhost:8081"},
+		{Name: "MinIO", ComposeName: "minio", ContainerName: "wabisaby-minio", Port: 9000, UIURL: "http://localhost:9001"},
+		{Name: "Vault", ComposeName: "vault", ContainerName: "wabisaby-vault", Port: 8200, UIURL: "http://localhost:8200"},
+		{Name: "Keycloak", ComposeName: "keycloak", ContainerName: "wabisaby-keycloak", Port: 8180, UIURL: "http://localhost:8180/admin"},
+		{Name: "pgAdmin", ComposeName: "pgadmin", ContainerName: "wabisaby-pgadmin", Port: 5050, ContainerPort: 80, UIURL: "http://localhost:5050"},
+	}
+}
+
+// LoadDockerServices merges docker/services.json under devkitRoot (a JSON array of DockerServiceConfig)
+// into the default services: entries with an existing name replace it, others are appended.
+// A missing file keeps the defaults.
+func LoadDockerServices(devkitRoot string) error {
+	services := defaultDockerServices()
+	data, err := os.ReadFile(filepath.Join(devkitRoot, dockerServicesFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var extra []DockerServiceConfig
+		if err := json.Unmarshal(data, &extra); err != nil {
+			return fmt.Errorf("invalid %s: %w", dockerServicesFile, err)
+		}
+		services = mergeDockerServices(services, extra)
+	}
+
+	dockerServicesMu.Lock()
+	configuredServices = services
+	dockerServices = withComposeServices(configuredServices, composeServices)
+	dockerServicesMu.Unlock()
+	return nil
+}
+
+// mergeDockerServices overlays extra onto base by name, skipping entries without a name
+func mergeDockerServices(base, extra []DockerServiceConfig) []DockerServiceConfig {
+	for _, svc := range extra {
+		if svc.Name == "" {
+			continue
+		}
+		replaced := false
+		for i := range base {
+			if base[i].Name == svc.Name {
+				base[i] = svc
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, svc)
+		}
+	}
+	return base
+}
+
+// SetComposeServices replaces the services discovered in the compose file. Those whose compose
+// service isn't already configured are listed after the configured ones; configured services
+// (defaults and docker/services.json) keep their settings. A service removed from the compose file
+// disappears on the next call.
+func SetComposeServices(discovered []DockerServiceConfig) {
+	dockerServicesMu.Lock()
+	defer dockerServicesMu.Unlock()
+	composeServices = append([]DockerServiceConfig(nil), discovered...)
+	dockerServices = withComposeServices(configuredServices, composeServices)
+}
+
+// withComposeServices returns configured followed by the discovered services it doesn't cover
+func withComposeServices(configured, discovered []DockerServiceConfig) []DockerServiceConfig {
+	services := append([]DockerServiceConfig(nil), configured...)
+	known := make(map[string]bool, len(configured))
+	for _, svc := range configured {
+		known[svc.ComposeService()] = true
+		known[svc.Name] = true
+	}
+	for _, svc := range discovered {
+		if svc.Name == "" || known[svc.ComposeService()] || known[svc.Name] {
+			continue
+		}
+		known[svc.ComposeService()] = true
+		services = append(services, svc)
+	}
+	return services
+}
+
+// GetDockerServices returns all dashboard Docker services
+func GetDockerServices() []DockerServiceConfig {
+	dockerServicesMu.RLock()
+	defer dockerServicesMu.RUnlock()
+	out := make([]DockerServiceConfig, len(dockerServices))
+	copy(out, dockerServices)
+	return out
+}
+
+// GetDockerServiceByName returns a Docker service config by display name
+func GetDockerServiceByName(name string) *DockerServiceConfig {
+	for _, svc := range GetDockerServices() {
+		if svc.Name == name {
+			return &svc
+		}
+	}
+	return nil
+}
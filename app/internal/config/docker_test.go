@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestSetComposeServicesReplacesDiscovered(t *testing.T) {
+	t.Cleanup(func() { SetComposeServices(nil) })
+
+	SetComposeServices([]DockerServiceConfig{
+		{Name: "postgres", ComposeName: "postgres", ContainerName: "docker-postgres-1", Port: 15432}, // configured as PostgreSQL
+		{Name: "kafka", ComposeName: "kafka", ContainerName: "docker-kafka-1", Port: 9092},
+		{Name: "mailhog", ComposeName: "mailhog", ContainerName: "docker-mailhog-1", Port: 8025},
+	})
+	if svc := GetDockerServiceByName("PostgreSQL"); svc == nil || svc.Port != 5432 || svc.ContainerName != "wabisaby-postgres" {
+		t.Errorf("PostgreSQL = %+v, want the configured definition", svc)
+	}
+	if GetDockerServiceByName("postgres") != nil {
+		t.Error("discovered postgres listed next to the configured PostgreSQL")
+	}
+	if GetDockerServiceByName("kafka") == nil || GetDockerServiceByName("mailhog") == nil {
+		t.Fatal("discovered services not listed")
+	}
+
+	// mailhog was removed from the compose file
+	SetComposeServices([]DockerServiceConfig{
+		{Name: "kafka", ComposeName: "kafka", ContainerName: "docker-kafka-1", Port: 9092},
+	})
+	if GetDockerServiceByName("mailhog") != nil {
+		t.Error("service removed from the compose file still listed")
+	}
+	if GetDockerServiceByName("kafka") == nil || GetDockerServiceByName("PostgreSQL") == nil {
+		t.Error("remaining discovered or configured services lost")
+	}
+	if got, want := len(GetDockerServices()), len(defaultDockerServices())+1; got != want {
+		t.Errorf("%d services listed, want %d", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import "fmt"
+
+// PortCollision is a pair of services configured on the same host port
+type PortCollision struct {
+	Port   int    `json:"port"`
+	First  string `json:"first"`  // e.g. "backend:websocket"
+	Second string `json:"second"` // e.g. "docker:RedisCommander"
+}
+
+// String describes the collision for logs and validation messages
+func (c PortCollision) String() string {
+	return fmt.Sprintf("port %d is used by both %s and %s", c.Port, c.First, c.Second)
+}
+
+// CheckPortCollisions returns every pair of backend and Docker services configured on the same port
+func CheckPortCollisions() []PortCollision {
+	return checkPortCollisions(GetBackendServices(), GetDockerServices())
+}
+
+// checkPortCollisions pairs each service with every earlier service on the same port (backend first, then Docker)
+func checkPortCollisions(backend []BackendServiceConfig, docker []DockerServiceConfig) []PortCollision {
+	type owner struct {
+		port int
+		name string
+	}
+	owners := make([]owner, 0, len(backend)+len(docker))
+	for _, svc := range backend {
+		owners = append(owners, owner{svc.Port, "backend:" + svc.Name})
+	}
+	for _, svc := range docker {
+		owners = append(owners, owner{svc.Port, "docker:" + svc.Name})
+	}
+
+	var collisions []PortCollision
+	for i, a := range owners {
+		if a.port <= 0 {
+			continue
+		}
+		for _, b := range owners[i+1:] {
+			if b.port == a.port {
+				collisions = append(collisions, PortCollision{Port: a.port, First: a.name, Second: b.name})
+			}
+		}
+	}
+	return collisions
+}
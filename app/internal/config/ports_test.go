@@ -0,0 +1,49 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckPortCollisions(t *testing.T) {
+	backend := []BackendServiceConfig{
+		{Name: "api", Port: 8080},
+		{Name: "websocket", Port: 8081},
+		{Name: "node"}, // no port
+		{Name: "worker"},
+		{Name: "admin", Port: 8080},
+	}
+	docker := []DockerServiceConfig{
+		{Name: "PostgreSQL", Port: 5432},
+		{Name: "RedisCommander", Port: 8081},
+		{Name: "Proxy", Port: 8080},
+	}
+
+	got := checkPortCollisions(backend, docker)
+	want := []PortCollision{
+		{Port: 8080, First: "backend:api", Second: "backend:admin"},
+		{Port: 8080, First: "backend:api", Second: "docker:Proxy"},
+		{Port: 8081, First: "backend:websocket", Second: "docker:RedisCommander"},
+		{Port: 8080, First: "backend:admin", Second: "docker:Proxy"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkPortCollisions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckPortCollisionsClean(t *testing.T) {
+	backend := []BackendServiceConfig{
+		{Name: "api", Port: 8080},
+		{Name: "websocket", Port: 8082},
+		{Name: "node"},
+		{Name: "worker"},
+	}
+	docker := []DockerServiceConfig{
+		{Name: "PostgreSQL", Port: 5432},
+		{Name: "pgAdmin", Port: 5050, ContainerPort: 80},
+		{Name: "Sidecar"},
+	}
+	if got := checkPortCollisions(backend, docker); len(got) != 0 {
+		t.Errorf("checkPortCollisions() = %+v, want none", got)
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ProjectConfig defines a repository shown on the Projects page
+type ProjectConfig struct {
+	Name          string `json:"name"`                    // Folder name under the projects dir, e.g. "wabisaby-core"
+	RepoURL       string `json:"repoUrl"`                 // Clone URL, e.g. "https://github.com/WabiSaby/wabisaby-core.git"
+	DefaultBranch string `json:"defaultBranch,omitempty"` // Branch for plain clones (empty = the remote's default)
+	// Clone as a devkit submodule: true/false force it, nil = when the project is declared in the
+	// devkit's .gitmodules. Never a submodule unless the devkit root is a git repo containing the
+	// projects dir.
+	Submodule *bool `json:"submodule,omitempty"`
+}
+
+// projectsFile is the optional devkit-relative file adding or overriding dashboard projects
+const projectsFile = "projects.json"
+
+var (
+	projectsMu sync.RWMutex
+	projects   = defaultProjects()
+)
+
+// defaultProjects returns the Wabi Saby repositories (the devkit's submodules)
+func defaultProjects() []ProjectConfig {
+	names := []string{
+		"wabisaby-core",
+		"wabisaby-node",
+		"wabisaby-protos",
+		"wabisaby-plugin-sdk-go",
+		"wabisaby-plugins",
+		"wabisaby-ui",
+		"wabisaby-web",
+	}
+	out := make([]ProjectConfig, len(names))
+	for i, name := range names {
+		out[i] = ProjectConfig{Name: name, RepoURL: "https://github.com/WabiSaby/" + name + ".git"}
+	}
+	return out
+}
+
+// LoadProjects merges projects.json under devkitRoot (a JSON array of ProjectConfig) into the default
+// projects: entries with an existing name replace it, others are appended. A missing file keeps the
+// defaults.
+func LoadProjects(devkitRoot string) error {
+	list := defaultProjects()
+	data, err := os.ReadFile(filepath.Join(devkitRoot, projectsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var extra []ProjectConfig
+		if err := json.Unmarshal(data, &extra); err != nil {
+			return fmt.Errorf("invalid %s: %w", projectsFile, err)
+		}
+		list = mergeProjects(list, extra)
+	}
+
+	projectsMu.Lock()
+	projects = list
+	projectsMu.Unlock()
+	return nil
+}
+
+// mergeProjects overlays extra onto base by name, skipping entries without a name
+func mergeProjects(base, extra []ProjectConfig) []ProjectConfig {
+	for _, p := range extra {
+		if p.Name == "" {
+			continue
+		}
+		replaced := false
+		for i := range base {
+			if base[i].Name == p.Name {
+				base[i] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, p)
+		}
+	}
+	return base
+}
+
+// GetProjects returns all dashboard projects in display order
+func GetProjects() []ProjectConfig {
+	projectsMu.RLock()
+	defer projectsMu.RUnlock()
+	out := make([]ProjectConfig, len(projects))
+	copy(out, projects)
+	return out
+}
+
+// GetProjectByName returns a project config by folder name
+func GetProjectByName(name string) *ProjectConfig {
+	for _, p := range GetProjects() {
+		if p.Name == name {
+			return &p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const settingsFileName = "settings.json"
+
+// Backend build modes
+const (
+	BackendBuildModeRun   = "run"   // go run on every start (fast iteration)
+	BackendBuildModeBuild = "build" // go build, then exec the binary (fast restart)
+)
+
+// Settings are user preferences changed from the dashboard, persisted in AppDataDir
+type Settings struct {
+	BackendBuildMode string `json:"backendBuildMode,omitempty"` // empty = BackendBuildModeRun
+
+	// KeepANSI leaves ANSI escape codes in streamed output for frontends that render them (default: stripped)
+	KeepANSI bool `json:"keepAnsi,omitempty"`
+
+	// ServiceEnvOverrides maps backend service name -> env var -> value (see BackendServiceConfig.EnvOverrides)
+	ServiceEnvOverrides map[string]map[string]string `json:"serviceEnvOverrides,omitempty"`
+}
+
+// settingsPath returns the path of the settings file in appDataDir
+func settingsPath(appDataDir string) string {
+	return filepath.Join(appDataDir, settingsFileName)
+}
+
+// LoadSettings reads the settings file from appDataDir. A missing file yields zero settings.
+func LoadSettings(appDataDir string) (Settings, error) {
+	var s Settings
+	data, err := os.ReadFile(settingsPath(appDataDir))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// SaveSettings writes the settings file to appDataDir
+func SaveSettings(appDataDir string, s Settings) error {
+	if err := os.MkdirAll(appDataDir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(settingsPath(appDataDir), data, 0644)
+}
@@ -0,0 +1,241 @@
+// Package dotenv parses and edits .env files while keeping everything it doesn't touch
+// (comments, blank lines, ordering, quoting, inline comments) exactly as written.
+package dotenv
+
+import "strings"
+
+// Line is one line of a .env file. Key is empty for blank and comment lines (and lines that
+// aren't assignments), which are kept verbatim.
+type Line struct {
+	Raw    string // original text, re-serialized unchanged unless the assignment is edited
+	Key    string
+	Value  string // unquoted / unescaped value
+	Export bool   // written as "export KEY=..."
+
+	indent  string // leading whitespace
+	quote   byte   // quote character the value was written with (0 = bare)
+	comment string // text after the value (whitespace and inline comment), kept on edit
+}
+
+// File is a parsed .env file
+type File struct {
+	Lines []*Line
+}
+
+// Parse splits data into lines and tokenizes assignments ([export ]KEY=value [# comment]).
+// Values may be bare, 'single-quoted' (literal) or "double-quoted" (\n, \", \\ escapes).
+func Parse(data []byte) *File {
+	f := &File{}
+	for _, raw := range strings.Split(string(data), "\n") {
+		f.Lines = append(f.Lines, parseLine(raw))
+	}
+	return f
+}
+
+// parseLine tokenizes a single line
+func parseLine(raw string) *Line {
+	line := &Line{Raw: raw}
+	rest := strings.TrimLeft(raw, " \t")
+	if strings.TrimSpace(rest) == "" || rest[0] == '#' {
+		return line
+	}
+	indent := raw[:len(raw)-len(rest)]
+
+	export := false
+	if after, ok := strings.CutPrefix(rest, "export"); ok && after != "" && (after[0] == ' ' || after[0] == '\t') {
+		export = true
+		rest = strings.TrimLeft(after, " \t")
+	}
+	key, value, ok := strings.Cut(rest, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		return line
+	}
+
+	line.Key = key
+	line.Export = export
+	line.indent = indent
+	if trimmed := strings.TrimLeft(value, " \t"); len(trimmed) < len(value) && strings.HasPrefix(trimmed, "#") {
+		// "KEY= # comment": an empty value with an inline comment
+		line.comment = value
+		return line
+	}
+	line.Value, line.quote, line.comment = parseValue(strings.TrimLeft(value, " \t"))
+	return line
+}
+
+// parseValue returns the value at the start of s, the quote it used and the text after it
+func parseValue(s string) (value string, quote byte, rest string) {
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		q := s[0]
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			c := s[i]
+			if c == q {
+				return b.String(), q, s[i+1:]
+			}
+			if q == '"' && c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default: // \" \\ and anything else: the character itself
+					b.WriteByte(s[i])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		}
+		// Unterminated quote: treat the whole thing as a bare value
+	}
+
+	// Bare value: an inline comment starts at whitespace followed by #
+	end := len(s)
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			end = i
+			break
+		}
+	}
+	value = strings.TrimRight(s[:end], " \t\r")
+	return value, 0, s[len(value):]
+}
+
+// Invalid returns the 1-based numbers of lines that are neither blank, comments nor assignments
+func (f *File) Invalid() []int {
+	var lines []int
+	for i, line := range f.Lines {
+		trimmed := strings.TrimSpace(line.Raw)
+		if line.Key == "" && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// Keys returns the assigned keys in file order, without duplicates
+func (f *File) Keys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, line := range f.Lines {
+		if line.Key != "" && !seen[line.Key] {
+			seen[line.Key] = true
+			keys = append(keys, line.Key)
+		}
+	}
+	return keys
+}
+
+// Get returns the value of key; when a key is assigned more than once the last assignment wins
+func (f *File) Get(key string) (string, bool) {
+	if line := f.last(key); line != nil {
+		return line.Value, true
+	}
+	return "", false
+}
+
+// Vars returns all assignments as a map (last assignment wins)
+func (f *File) Vars() map[string]string {
+	vars := make(map[string]string)
+	for _, line := range f.Lines {
+		if line.Key != "" {
+			vars[line.Key] = line.Value
+		}
+	}
+	return vars
+}
+
+// Environ returns the assignments as KEY=value pairs in file order, for exec.Cmd.Env
+func (f *File) Environ() []string {
+	var env []string
+	for _, line := range f.Lines {
+		if line.Key != "" {
+			env = append(env, line.Key+"="+line.Value)
+		}
+	}
+	return env
+}
+
+// Set changes the value of key in place (its last assignment, keeping export, quoting style and
+// inline comment) or appends KEY=value when the key isn't assigned yet
+func (f *File) Set(key, value string) {
+	if line := f.last(key); line != nil {
+		line.Value = value
+		line.Raw = line.format()
+		return
+	}
+
+	line := &Line{Key: key, Value: value}
+	line.Raw = line.format()
+	// Keep the file's trailing newline after the new line
+	if n := len(f.Lines); n > 0 && f.Lines[n-1].Raw == "" {
+		f.Lines = append(f.Lines[:n-1], line, f.Lines[n-1])
+		return
+	}
+	f.Lines = append(f.Lines, line)
+}
+
+// Delete removes every assignment of key and reports whether there was one
+func (f *File) Delete(key string) bool {
+	kept := f.Lines[:0]
+	found := false
+	for _, line := range f.Lines {
+		if line.Key == key {
+			found = true
+			continue
+		}
+		kept = append(kept, line)
+	}
+	f.Lines = kept
+	return found
+}
+
+// Bytes serializes the file; lines that weren't edited are returned exactly as parsed
+func (f *File) Bytes() []byte {
+	raws := make([]string, len(f.Lines))
+	for i, line := range f.Lines {
+		raws[i] = line.Raw
+	}
+	return []byte(strings.Join(raws, "\n"))
+}
+
+// last returns the last assignment of key, or nil
+func (f *File) last(key string) *Line {
+	for i := len(f.Lines) - 1; i >= 0; i-- {
+		if f.Lines[i].Key == key {
+			return f.Lines[i]
+		}
+	}
+	return nil
+}
+
+// format renders an assignment line from its fields
+func (l *Line) format() string {
+	var b strings.Builder
+	b.WriteString(l.indent)
+	if l.Export {
+		b.WriteString("export ")
+	}
+	b.WriteString(l.Key)
+	b.WriteByte('=')
+	b.WriteString(quoteValue(l.Value, l.quote))
+	b.WriteString(l.comment)
+	return b.String()
+}
+
+// quoteValue renders value, keeping the preferred quote style when it can represent the value and
+// quoting bare values that would otherwise be misread (spaces, #, quotes, newlines)
+func quoteValue(value string, preferred byte) string {
+	if preferred == '\'' && !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+	if preferred == 0 && !strings.ContainsAny(value, " \t#\"'\\\n\r") {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(value) + `"`
+}
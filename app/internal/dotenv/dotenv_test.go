@@ -0,0 +1,146 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sample = `# WabiSaby local environment
+
+export DATABASE_URL=postgres://localhost:5432/wabisaby
+  LOG_LEVEL=debug   # verbose while developing
+GREETING="hello world" # shown on the landing page
+MOTTO='50% off # not a comment'
+MULTILINE="line one\nline two \"quoted\" \\ done"
+URL=http://example.com/#anchor
+EMPTY=
+EMPTY_QUOTED=""
+EMPTY_COMMENTED= # set me
+not an assignment
+`
+
+func TestParse(t *testing.T) {
+	f := Parse([]byte(sample))
+
+	want := map[string]string{
+		"DATABASE_URL": "postgres://localhost:5432/wabisaby",
+		"LOG_LEVEL":    "debug",
+		"GREETING":     "hello world",
+		"MOTTO":        "50% off # not a comment",
+		"MULTILINE":    "line one\nline two \"quoted\" \\ done",
+		"URL":          "http://example.com/#anchor", // # only starts a comment after whitespace
+		"EMPTY":        "",
+		"EMPTY_QUOTED": "",
+		// Whitespace then # starts a comment even with nothing before it
+		"EMPTY_COMMENTED": "",
+	}
+	if got := f.Vars(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vars() = %q, want %q", got, want)
+	}
+	if !f.Lines[2].Export || f.Lines[3].Export {
+		t.Error("export prefix not recorded on DATABASE_URL only")
+	}
+	if got := f.Invalid(); !reflect.DeepEqual(got, []int{12}) {
+		t.Errorf("Invalid() = %v, want [12]", got)
+	}
+}
+
+func TestRoundTripUnchanged(t *testing.T) {
+	if got := string(Parse([]byte(sample)).Bytes()); got != sample {
+		t.Errorf("Bytes() without edits changed the file:\n%s\nwant:\n%s", got, sample)
+	}
+}
+
+func TestSetKeepsStyle(t *testing.T) {
+	f := Parse([]byte(sample))
+	f.Set("DATABASE_URL", "postgres://db:5432/other")
+	f.Set("LOG_LEVEL", "info")
+	f.Set("GREETING", "hi there")
+	f.Set("MOTTO", "it's fine")   // a single quote can't stay single-quoted
+	f.Set("URL", "http://x/#top") // a value containing # is quoted when written
+	f.Set("EMPTY", "now has # hash and spaces")
+	f.Set("EMPTY_COMMENTED", "filled")
+	f.Set("NEW_VAR", "value")
+
+	want := `# WabiSaby local environment
+
+export DATABASE_URL=postgres://db:5432/other
+  LOG_LEVEL=info   # verbose while developing
+GREETING="hi there" # shown on the landing page
+MOTTO="it's fine"
+MULTILINE="line one\nline two \"quoted\" \\ done"
+URL="http://x/#top"
+EMPTY="now has # hash and spaces"
+EMPTY_QUOTED=""
+EMPTY_COMMENTED=filled # set me
+not an assignment
+NEW_VAR=value
+`
+	got := string(f.Bytes())
+	if got != want {
+		t.Errorf("after Set:\n%s\nwant:\n%s", got, want)
+	}
+
+	// What was written parses back to the values that were set
+	reparsed := Parse([]byte(got))
+	for key, value := range map[string]string{
+		"DATABASE_URL":    "postgres://db:5432/other",
+		"LOG_LEVEL":       "info",
+		"GREETING":        "hi there",
+		"MOTTO":           "it's fine",
+		"URL":             "http://x/#top",
+		"EMPTY":           "now has # hash and spaces",
+		"EMPTY_COMMENTED": "filled",
+		"NEW_VAR":         "value",
+	} {
+		if v, ok := reparsed.Get(key); !ok || v != value {
+			t.Errorf("%s = %q after round trip, want %q", key, v, value)
+		}
+	}
+}
+
+func TestSetRoundTripsSpecialValues(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"plain",
+		"with space",
+		"trailing space ",
+		`back\slash`,
+		`"double" and 'single'`,
+		"multi\nline\r\n",
+		"#leading-hash",
+		"tab\tseparated # and hash",
+	} {
+		for _, quote := range []string{"KEY=x\n", "KEY='x'\n", "KEY=\"x\"\n", "export KEY=x # note\n"} {
+			f := Parse([]byte(quote))
+			f.Set("KEY", value)
+			if got, _ := Parse(f.Bytes()).Get("KEY"); got != value {
+				t.Errorf("Set(%q) on %q wrote %q, which parses back as %q", value, quote, f.Bytes(), got)
+			}
+		}
+	}
+}
+
+func TestSetLastAssignmentWins(t *testing.T) {
+	f := Parse([]byte("KEY=first\nOTHER=1\nKEY=second\n"))
+	if v, _ := f.Get("KEY"); v != "second" {
+		t.Fatalf("Get(KEY) = %q, want the last assignment", v)
+	}
+	f.Set("KEY", "third")
+	if got := string(f.Bytes()); got != "KEY=first\nOTHER=1\nKEY=third\n" {
+		t.Errorf("Set edited the wrong assignment: %q", got)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	f := Parse([]byte("# header\nKEY=1\nOTHER=2 # keep\nexport KEY=3\n"))
+	if !f.Delete("KEY") {
+		t.Fatal("Delete(KEY) reported no assignment")
+	}
+	if got := string(f.Bytes()); got != "# header\nOTHER=2 # keep\n" {
+		t.Errorf("after Delete = %q", got)
+	}
+	if f.Delete("MISSING") {
+		t.Error("Delete(MISSING) reported an assignment")
+	}
+}
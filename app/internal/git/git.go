@@ -1,13 +1,22 @@
 package git
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+	"golang.org/x/mod/semver"
 )
 
 // GetBranch returns the current git branch for a directory
@@ -32,22 +41,271 @@ func GetCommit(dir string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// IsDirty checks if a git directory has uncommitted changes
+// GetFullCommit returns the full commit hash of HEAD for a directory
+func GetFullCommit(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsDirty checks if a git directory has uncommitted changes (staged or unstaged; untracked files don't count)
 func IsDirty(dir string) bool {
-	cmd := exec.Command("git", "diff", "--quiet")
+	staged, unstaged, _, err := StatusCounts(dir)
+	if err != nil {
+		return false
+	}
+	return staged > 0 || unstaged > 0
+}
+
+// StatusCounts returns the number of files with staged changes, with unstaged changes to tracked
+// files, and untracked files, from `git status --porcelain=v1`. A file changed in both the index and
+// the worktree counts toward staged and unstaged.
+func StatusCounts(dir string) (staged, unstaged, untracked int, err error) {
+	cmd := exec.Command("git", "status", "--porcelain=v1", "-z")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("git status: %w", err)
+	}
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 3 {
+			continue
+		}
+		x, y := entry[0], entry[1]
+		if x == '?' && y == '?' {
+			untracked++
+			continue
+		}
+		if x != ' ' {
+			staged++
+		}
+		if y != ' ' {
+			unstaged++
+		}
+		if x == 'R' || x == 'C' {
+			i++ // -z puts the rename/copy source path in its own entry
+		}
+	}
+	return staged, unstaged, untracked, nil
+}
+
+// DefaultCommitCount is how many commits RecentCommits returns when n <= 0
+const DefaultCommitCount = 20
+
+// RecentCommits returns the last n commits reachable from HEAD in dir, newest first (n <= 0 means
+// DefaultCommitCount). Records that don't parse are skipped.
+func RecentCommits(dir string, n int) ([]model.Commit, error) {
+	if n <= 0 {
+		n = DefaultCommitCount
+	}
+	// Fields are NUL-separated and each record ends with RS, so subjects and names can't break parsing
+	cmd := exec.Command("git", "log", "-n", strconv.Itoa(n), "--no-color", "--pretty=format:%H%x00%an%x00%at%x00%s%x1e")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	commits := make([]model.Commit, 0, n)
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x00")
+		if len(fields) != 4 || fields[0] == "" {
+			continue
+		}
+		commit := model.Commit{Hash: fields[0], Author: fields[1], Subject: fields[3]}
+		if sec, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			commit.Timestamp = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// MaxDiffBytes caps the diff text returned by Diff
+const MaxDiffBytes = 1 << 20
+
+// Diff returns the unified diff of unstaged changes in dir, or of staged changes (git diff --cached)
+// when staged is true. Output beyond MaxDiffBytes is cut off and followed by a truncation notice.
+// Returns an empty string when there are no changes.
+func Diff(dir string, staged bool) (string, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	output, readErr := io.ReadAll(io.LimitReader(stdout, MaxDiffBytes+1))
+	truncated := len(output) > MaxDiffBytes
+	if truncated {
+		_ = cmd.Process.Kill() // rest of the diff is discarded
+	}
+	waitErr := cmd.Wait()
+	if readErr != nil {
+		return "", readErr
+	}
+	if waitErr != nil && !truncated {
+		return "", fmt.Errorf("git diff: %w (%s)", waitErr, strings.TrimSpace(stderr.String()))
+	}
+	if truncated {
+		return string(output[:MaxDiffBytes]) + fmt.Sprintf("\n... diff truncated at %d KB ...\n", MaxDiffBytes/1024), nil
+	}
+	return string(output), nil
+}
+
+// AheadBehind returns how many commits HEAD is ahead of and behind its upstream branch (compared
+// against the last fetch; nothing is fetched). hasUpstream is false, with a nil error, when the
+// current branch tracks no upstream or HEAD is detached.
+func AheadBehind(dir string) (ahead, behind int, hasUpstream bool, err error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		return 0, 0, false, nil
+	}
+
+	cmd = exec.Command("git", "rev-list", "--left-right", "--count", "@{u}...HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("rev-list: %w", err)
+	}
+	// Left side is upstream-only commits (behind), right side is HEAD-only commits (ahead)
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &behind, &ahead); err != nil {
+		return 0, 0, true, fmt.Errorf("parse rev-list output %q: %w", strings.TrimSpace(string(output)), err)
+	}
+	return ahead, behind, true, nil
+}
+
+// ListBranches returns local branches plus remote-tracking branches (with the remote name stripped,
+// e.g. "origin/feature" -> "feature"), deduplicated and sorted. Checkout accepts any of them.
+func ListBranches(dir string) ([]string, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	seen := make(map[string]bool)
+	var branches []string
+	for _, ref := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var name string
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			name = strings.TrimPrefix(ref, "refs/heads/")
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			// refs/remotes/<remote>/<branch>
+			parts := strings.SplitN(strings.TrimPrefix(ref, "refs/remotes/"), "/", 2)
+			if len(parts) != 2 || parts[1] == "HEAD" {
+				continue
+			}
+			name = parts[1]
+		}
+		if name != "" && !seen[name] {
+			seen[name] = true
+			branches = append(branches, name)
+		}
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// Checkout switches dir to branch. A branch that only exists on a remote is checked out as a new local
+// branch tracking it. Callers should check IsDirty first; git may carry uncommitted changes across.
+func Checkout(dir, branch string) error {
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return errors.New("branch name is required")
+	}
+	if strings.HasPrefix(branch, "-") {
+		return errors.New("invalid branch name")
+	}
+	cmd := exec.Command("git", "checkout", branch, "--")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git checkout: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ErrNothingToStash is returned by Stash when the working tree has no tracked changes
+var ErrNothingToStash = errors.New("no local changes to stash")
+
+// ErrNoStash is returned by StashPop when the stash is empty
+var ErrNoStash = errors.New("no stash to pop")
+
+// Stash stashes staged and unstaged changes to tracked files in dir (untracked files are left alone).
+// message labels the entry; empty uses git's default.
+func Stash(dir, message string) error {
+	if !IsDirty(dir) {
+		return ErrNothingToStash
+	}
+	args := []string{"stash", "push"}
+	if message = strings.TrimSpace(message); message != "" {
+		args = append(args, "-m", message)
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
-	err1 := cmd.Run()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git stash: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
 
-	cmd = exec.Command("git", "diff", "--cached", "--quiet")
+// StashPop applies the most recent stash entry in dir and drops it. On conflicts git keeps the entry
+// and the error includes its output.
+func StashPop(dir string) error {
+	entries, err := StashList(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return ErrNoStash
+	}
+	cmd := exec.Command("git", "stash", "pop")
 	cmd.Dir = dir
-	err2 := cmd.Run()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git stash pop: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
 
-	return err1 != nil || err2 != nil
+// StashList returns the stash entries in dir, newest first, as "stash@{N}: <message>"
+func StashList(dir string) ([]string, error) {
+	cmd := exec.Command("git", "stash", "list")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git stash list: %w", err)
+	}
+	var entries []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, nil
 }
 
 // InitializeSubmodule initializes a git submodule. projectsDir is the path to the projects
 // directory (may be under devkitRoot or a custom path). Submodule path is computed relative to devkitRoot.
-func InitializeSubmodule(devkitRoot, projectsDir, projectName string) error {
+// depth > 0 makes a shallow clone with that many commits of history.
+func InitializeSubmodule(devkitRoot, projectsDir, projectName string, depth int) error {
 	projectDir := filepath.Join(projectsDir, projectName)
 
 	// Check if we're in a git repository
@@ -65,12 +323,16 @@ func InitializeSubmodule(devkitRoot, projectsDir, projectName string) error {
 	}
 	submodulePath := filepath.ToSlash(filepath.Join(rel, projectName))
 
-	cmd := exec.Command("git", "submodule", "update", "--init", submodulePath)
+	args := []string{"submodule", "update", "--init"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	cmd := exec.Command("git", append(args, submodulePath)...)
 	cmd.Dir = devkitRoot
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Fall back to initializing all submodules
-		cmd = exec.Command("git", "submodule", "update", "--init", "--recursive")
+		cmd = exec.Command("git", append(args, "--recursive")...)
 		cmd.Dir = devkitRoot
 		output2, err2 := cmd.CombinedOutput()
 		if err2 != nil {
@@ -88,9 +350,17 @@ func InitializeSubmodule(devkitRoot, projectsDir, projectName string) error {
 	return nil
 }
 
-// CloneRepo clones a repository by URL into dir (plain clone, not submodule).
-func CloneRepo(url, dir string) error {
-	cmd := exec.Command("git", "clone", url, dir)
+// CloneRepo clones a repository by URL into dir (plain clone, not submodule). depth > 0 makes a
+// shallow, single-branch clone with that many commits of history.
+func CloneRepo(url, dir, branch string, depth int) error {
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth), "--single-branch")
+	}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	cmd := exec.Command("git", append(args, url, dir)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git clone: %w (%s)", err, strings.TrimSpace(string(output)))
@@ -98,6 +368,34 @@ func CloneRepo(url, dir string) error {
 	return nil
 }
 
+// IsShallow reports whether the repository in dir is a shallow clone
+func IsShallow(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--is-shallow-repository")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// Unshallow fetches the full history of a shallow clone in dir. It is a no-op for complete repositories.
+// Shallow clones are single-branch, so origin's fetch refspec is widened to every branch first;
+// otherwise the other branches would stay missing after the fetch.
+func Unshallow(dir string) error {
+	if !IsShallow(dir) {
+		return nil
+	}
+	for _, args := range [][]string{
+		{"config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"},
+		{"fetch", "--unshallow", "origin"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %w (%s)", strings.Join(args[:2], " "), err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
 // ValidateTagName checks that tagName is a valid Git ref name (git check-ref-format).
 // Rejects empty, "..", refs containing "..", ending with "." or "/", and invalid characters.
 func ValidateTagName(tagName string) error {
@@ -120,12 +418,198 @@ func ValidateTagName(tagName string) error {
 	return nil
 }
 
-// CreateTag creates an annotated tag at HEAD in dir. Fails if tag already exists (no -f).
-func CreateTag(dir, tagName, message string) error {
+// TagPrefixPolicy controls how SuggestTagName treats the "v" prefix on version-like tag names.
+type TagPrefixPolicy int
+
+const (
+	// TagPrefixAny leaves the prefix as typed.
+	TagPrefixAny TagPrefixPolicy = iota
+	// TagPrefixRequireV expects version tags like "v1.2.0" and suggests adding a missing "v".
+	TagPrefixRequireV
+	// TagPrefixForbidV expects bare versions like "1.2.0" and suggests dropping the "v".
+	TagPrefixForbidV
+)
+
+// ParseTagPrefixPolicy maps a configured prefix policy name to a TagPrefixPolicy: "none" forbids the
+// "v", "any" leaves it alone and anything else (the default; WabiSaby release tags are "v1.2.3")
+// requires it
+func ParseTagPrefixPolicy(name string) TagPrefixPolicy {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "none":
+		return TagPrefixForbidV
+	case "any":
+		return TagPrefixAny
+	default:
+		return TagPrefixRequireV
+	}
+}
+
+var (
+	versionTagRegex   = regexp.MustCompile(`^[vV]?\d+(\.\d+)*([-+].*)?$`)
+	spacedVPrefix     = regexp.MustCompile(`^[vV]\s+(\d)`)
+	whitespaceRun     = regexp.MustCompile(`\s+`)
+	repeatedDotsRegex = regexp.MustCompile(`\.{2,}`)
+)
+
+// SuggestTagName checks raw like ValidateTagName and also applies policy. When raw has a problem it
+// returns the validation error together with a normalized suggestion (e.g. "v1.2.0" for " 1.2.0. "
+// under TagPrefixRequireV), or an empty suggestion when nothing sensible can be derived. Valid input
+// is returned as-is.
+func SuggestTagName(raw string, policy TagPrefixPolicy) (suggestion string, err error) {
+	err = ValidateTagName(raw)
+	if err == nil && strings.TrimSpace(raw) != raw {
+		err = errors.New("tag name has leading or trailing whitespace")
+	}
+	if err == nil {
+		err = checkTagPrefix(raw, policy)
+	}
+	if err == nil {
+		return raw, nil
+	}
+
+	s := strings.TrimSpace(raw)
+	s = spacedVPrefix.ReplaceAllString(s, "v$1")
+	s = whitespaceRun.ReplaceAllString(s, "-")
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune("~^:?*[\\\x00", r) {
+			return -1
+		}
+		return r
+	}, s)
+	s = repeatedDotsRegex.ReplaceAllString(s, ".")
+	s = strings.TrimRight(s, "./-")
+	if versionTagRegex.MatchString(s) {
+		digits := strings.TrimLeft(s, "vV")
+		switch policy {
+		case TagPrefixRequireV:
+			s = "v" + digits
+		case TagPrefixForbidV:
+			s = digits
+		default:
+			if strings.HasPrefix(s, "V") {
+				s = "v" + digits
+			}
+		}
+	}
+
+	if s == "" || s == raw || ValidateTagName(s) != nil {
+		return "", err
+	}
+	return s, err
+}
+
+var semverTagRegex = regexp.MustCompile(`^([vV]?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// semverTag is a parsed version tag ("v1.2.3-rc.1")
+type semverTag struct {
+	prefix              string
+	major, minor, patch int
+	pre                 string
+	canonical           string // "v1.2.3-rc.1", without prefix case or build metadata
+}
+
+// less orders versions by semver precedence: a pre-release sorts before its release, and
+// pre-releases compare identifier by identifier (rc.2 < rc.10 < rc.a)
+func (v semverTag) less(o semverTag) bool {
+	return semver.Compare(v.canonical, o.canonical) < 0
+}
+
+// NextVersionTag returns the version after the highest semver tag in tags, bumped by bump ("patch"
+// (default), "minor" or "major") and keeping that tag's "v" prefix. Tags that aren't semver are
+// ignored; the highest is chosen by semver precedence. A pre-release highest tag is released rather than skipped (1.3.0-rc.1 -> 1.3.0 for a
+// minor bump). With no semver tags it returns v0.1.0 (0.1.0 under the TagPrefixForbidV policy).
+func NextVersionTag(tags []string, bump string, policy TagPrefixPolicy) (string, error) {
+	if bump == "" {
+		bump = "patch"
+	}
+	if bump != "patch" && bump != "minor" && bump != "major" {
+		return "", fmt.Errorf("invalid bump %q (use patch, minor or major)", bump)
+	}
+
+	var highest *semverTag
+	for _, tag := range tags {
+		m := semverTagRegex.FindStringSubmatch(strings.TrimSpace(tag))
+		if m == nil {
+			continue
+		}
+		v := semverTag{prefix: m[1], pre: m[5]}
+		v.major, _ = strconv.Atoi(m[2])
+		v.minor, _ = strconv.Atoi(m[3])
+		v.patch, _ = strconv.Atoi(m[4])
+		v.canonical = semver.Canonical("v" + strings.TrimLeft(strings.TrimSpace(tag), "vV"))
+		if v.canonical == "" {
+			continue // e.g. leading zeros ("01.2.0", "1.2.0-rc.01")
+		}
+		if highest == nil || highest.less(v) {
+			highest = &v
+		}
+	}
+	if highest == nil {
+		if policy == TagPrefixForbidV {
+			return "0.1.0", nil
+		}
+		return "v0.1.0", nil
+	}
+
+	next := *highest
+	pre := next.pre != ""
+	next.pre = ""
+	switch bump {
+	case "major":
+		if !pre || next.minor != 0 || next.patch != 0 {
+			next.major, next.minor, next.patch = next.major+1, 0, 0
+		}
+	case "minor":
+		if !pre || next.patch != 0 {
+			next.minor, next.patch = next.minor+1, 0
+		}
+	default:
+		if !pre {
+			next.patch++
+		}
+	}
+	return fmt.Sprintf("%s%d.%d.%d", strings.ToLower(next.prefix), next.major, next.minor, next.patch), nil
+}
+
+// checkTagPrefix reports whether a version-like tag name violates policy.
+func checkTagPrefix(tagName string, policy TagPrefixPolicy) error {
+	if !versionTagRegex.MatchString(tagName) {
+		return nil
+	}
+	hasV := strings.HasPrefix(tagName, "v") || strings.HasPrefix(tagName, "V")
+	switch {
+	case policy == TagPrefixRequireV && !strings.HasPrefix(tagName, "v"):
+		return errors.New("version tags should start with 'v'")
+	case policy == TagPrefixForbidV && hasV:
+		return errors.New("version tags should not start with 'v'")
+	}
+	return nil
+}
+
+// CommitExists reports whether commit resolves to a commit object in the repository in dir.
+func CommitExists(dir, commit string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", commit+"^{commit}")
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// CreateTag creates a tag at commit (any commit-ish; HEAD when empty) in dir: annotated with message,
+// or a lightweight tag (message ignored) when lightweight is set. Fails if tag already exists (no -f).
+func CreateTag(dir, tagName, message, commit string, lightweight bool) error {
 	if message == "" {
 		message = "Release " + tagName
 	}
-	cmd := exec.Command("git", "tag", "-a", tagName, "-m", message)
+	commit = strings.TrimSpace(commit)
+	if commit == "" {
+		commit = "HEAD"
+	} else if !CommitExists(dir, commit) {
+		return fmt.Errorf("commit %s not found", commit)
+	}
+	args := []string{"tag", "-a", tagName, "-m", message, commit}
+	if lightweight {
+		args = []string{"tag", tagName, commit}
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -156,6 +640,19 @@ func ListTags(dir string) ([]string, error) {
 	return tags, nil
 }
 
+// HeadTags returns the sorted tags pointing at HEAD (empty when HEAD isn't tagged)
+func HeadTags(dir string) ([]string, error) {
+	cmd := exec.Command("git", "tag", "--points-at", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list head tags: %w", err)
+	}
+	tags := strings.Fields(string(output))
+	sort.Strings(tags)
+	return tags, nil
+}
+
 // PushTag pushes the tag to origin.
 func PushTag(dir, tagName string) error {
 	cmd := exec.Command("git", "push", "origin", tagName)
@@ -167,6 +664,42 @@ func PushTag(dir, tagName string) error {
 	return nil
 }
 
+// DeleteTag deletes a local tag (git tag -d)
+func DeleteTag(dir, tagName string) error {
+	cmd := exec.Command("git", "tag", "-d", tagName)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "not found") {
+			return fmt.Errorf("tag %s not found", tagName)
+		}
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// DeleteRemoteTag deletes a tag from origin (git push origin :refs/tags/<tag>)
+func DeleteRemoteTag(dir, tagName string) error {
+	// Recent git only warns when deleting a ref the remote doesn't have; check first
+	lsCmd := exec.Command("git", "ls-remote", "--tags", "origin", "refs/tags/"+tagName)
+	lsCmd.Dir = dir
+	output, err := lsCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ls-remote failed: %s", strings.TrimSpace(string(output)))
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return fmt.Errorf("tag %s not found on remote", tagName)
+	}
+
+	cmd := exec.Command("git", "push", "origin", ":refs/tags/"+tagName)
+	cmd.Dir = dir
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("push failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // SubmoduleSyncStatus returns project names whose HEAD differs from the commit recorded in devkitRoot.
 // When devkitRoot is not a git repo, returns empty (no sync needed).
 func SubmoduleSyncStatus(devkitRoot, projectsDir string, projectNames []string) (needsSync []string, err error) {
@@ -211,9 +744,219 @@ func SubmoduleSyncStatus(devkitRoot, projectsDir string, projectNames []string)
 	return needsSync, nil
 }
 
-// SubmoduleSync stages submodule refs in devkitRoot and commits with the given message.
+// SubmoduleDriftInfo describes how a submodule's checked-out HEAD differs from the commit recorded in DevKit.
+type SubmoduleDriftInfo struct {
+	Name            string `json:"name"`
+	RecordedCommit  string `json:"recordedCommit"`
+	RecordedSubject string `json:"recordedSubject,omitempty"`
+	CurrentCommit   string `json:"currentCommit"`
+	CurrentSubject  string `json:"currentSubject,omitempty"`
+}
+
+// SubmoduleDrift returns recorded vs current commit details for each project that needs sync.
+// Subjects are left empty when the commit is not available locally (e.g. recorded commit not fetched).
+func SubmoduleDrift(devkitRoot, projectsDir string, projectNames []string) ([]SubmoduleDriftInfo, error) {
+	needsSync, err := SubmoduleSyncStatus(devkitRoot, projectsDir, projectNames)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(devkitRoot, projectsDir)
+	if err != nil {
+		return nil, err
+	}
+	drifts := make([]SubmoduleDriftInfo, 0, len(needsSync))
+	for _, name := range needsSync {
+		projectDir := filepath.Join(projectsDir, name)
+		submodulePath := filepath.ToSlash(filepath.Join(rel, name))
+		drift := SubmoduleDriftInfo{Name: name}
+
+		if recorded := recordedSubmoduleCommit(devkitRoot, submodulePath); recorded != "" {
+			drift.RecordedCommit, drift.RecordedSubject = commitSummary(projectDir, recorded)
+		}
+		drift.CurrentCommit, drift.CurrentSubject = commitSummary(projectDir, "HEAD")
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// SubmoduleSyncPreview returns, for each project that needs sync, the commit DevKit records now and
+// the HEAD SubmoduleSync would record instead, with how many commits that adds and drops. Counts are
+// -1 when the recorded commit is not available locally (e.g. not fetched).
+func SubmoduleSyncPreview(devkitRoot, projectsDir string, projectNames []string) ([]model.SubmoduleChange, error) {
+	needsSync, err := SubmoduleSyncStatus(devkitRoot, projectsDir, projectNames)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(devkitRoot, projectsDir)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]model.SubmoduleChange, 0, len(needsSync))
+	for _, name := range needsSync {
+		projectDir := filepath.Join(projectsDir, name)
+		change := model.SubmoduleChange{Name: name, Commits: -1, Dropped: -1}
+		change.NewCommit, change.NewSubject = commitSummary(projectDir, "HEAD")
+
+		recorded := recordedSubmoduleCommit(devkitRoot, filepath.ToSlash(filepath.Join(rel, name)))
+		if recorded == "" {
+			// Not recorded yet (new submodule): everything is new
+			change.Dropped = 0
+			changes = append(changes, change)
+			continue
+		}
+		change.OldCommit, change.OldSubject = commitSummary(projectDir, recorded)
+
+		cmd := exec.Command("git", "rev-list", "--left-right", "--count", recorded+"...HEAD")
+		cmd.Dir = projectDir
+		if output, err := cmd.Output(); err == nil {
+			if fields := strings.Fields(string(output)); len(fields) == 2 {
+				change.Dropped, _ = strconv.Atoi(fields[0])
+				change.Commits, _ = strconv.Atoi(fields[1])
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// recordedSubmoduleCommit returns the commit devkitRoot's HEAD records for the submodule at
+// submodulePath, or "" when it records none
+func recordedSubmoduleCommit(devkitRoot, submodulePath string) string {
+	cmd := exec.Command("git", "ls-tree", "HEAD", submodulePath)
+	cmd.Dir = devkitRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	if fields := strings.Fields(string(output)); len(fields) >= 3 {
+		return fields[2]
+	}
+	return ""
+}
+
+// commitSummary returns the short hash and subject of rev in dir. When rev cannot be resolved,
+// the short form of rev itself is returned with an empty subject.
+func commitSummary(dir, rev string) (shortHash, subject string) {
+	cmd := exec.Command("git", "log", "-1", "--format=%h%x00%s", rev)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if len(rev) > 7 {
+			return rev[:7], ""
+		}
+		return rev, ""
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(output)), "\x00", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// ErrPushRejected is returned by PushUpstream when the remote has commits the local branch lacks
+var ErrPushRejected = errors.New("push rejected: the remote has commits you don't have locally; pull (with rebase) and push again")
+
+// CommittedPushError is returned by SubmoduleSync when the submodule commit was made but pushing it failed
+type CommittedPushError struct {
+	Err error
+}
+
+func (e *CommittedPushError) Error() string {
+	return "committed, but " + e.Err.Error()
+}
+
+func (e *CommittedPushError) Unwrap() error {
+	return e.Err
+}
+
+// SubmoduleSync stages submodule refs in devkitRoot and commits with the given message (nothing is
+// committed when projectNames is empty). With push set, the branch is then pushed to its upstream
+// whenever it is ahead, including commits from earlier syncs (see PushUpstream), passing each line of
+// git push output to pushOutput (may be nil); pushed reports whether a push ran. When the commit was
+// made but the push fails the error is a *CommittedPushError. When devkitRoot is not a git repo,
+// returns false, nil (no-op).
+func SubmoduleSync(devkitRoot, projectsDir string, projectNames []string, commitMessage string, push bool, pushOutput func(line string)) (pushed bool, err error) {
+	if err := commitSubmodules(devkitRoot, projectsDir, projectNames, commitMessage); err != nil {
+		return false, err
+	}
+	if !push {
+		return false, nil
+	}
+	if _, err := os.Stat(filepath.Join(devkitRoot, ".git")); err != nil {
+		return false, nil
+	}
+	committed := len(projectNames) > 0
+	ahead, _, hasUpstream, err := AheadBehind(devkitRoot)
+	if err == nil && hasUpstream && ahead == 0 {
+		return false, nil
+	}
+	if err == nil && !hasUpstream && !committed {
+		return false, nil
+	}
+	if err := PushUpstream(devkitRoot, pushOutput); err != nil {
+		if committed {
+			return false, &CommittedPushError{Err: err}
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// pushTimeout bounds PushUpstream, so a hung remote or credential helper can't block forever
+const pushTimeout = 2 * time.Minute
+
+// PushUpstream runs git push in dir (to the current branch's upstream), passing each output line to
+// onLine (may be nil). A non-fast-forward rejection returns ErrPushRejected; a branch without an
+// upstream gets a clear error rather than git's suggestion. Git never prompts for credentials and the
+// push is stopped after pushTimeout.
+func PushUpstream(dir string, onLine func(line string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "push")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.WaitDelay = 5 * time.Second // ssh children may keep the output pipe open after git is killed
+	pr, pw := io.Pipe()
+	cmd.Stdout, cmd.Stderr = pw, pw
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		waitErr <- err
+	}()
+
+	var output strings.Builder
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		output.WriteString(line + "\n")
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+	io.Copy(io.Discard, pr) // keep git from blocking if the scanner stopped early
+	err := <-waitErr
+	text := strings.TrimSpace(output.String())
+	if err != nil {
+		switch {
+		case ctx.Err() == context.DeadlineExceeded:
+			return fmt.Errorf("push failed: timed out after %s", pushTimeout)
+		case strings.Contains(text, "non-fast-forward") || strings.Contains(text, "fetch first") || strings.Contains(text, "[rejected]"):
+			return ErrPushRejected
+		case strings.Contains(text, "has no upstream branch"):
+			return errors.New("push failed: the current branch has no upstream; push it once with git push -u")
+		}
+		return fmt.Errorf("push failed: %s", text)
+	}
+	return nil
+}
+
+// commitSubmodules stages submodule refs in devkitRoot and commits with the given message.
 // When devkitRoot is not a git repo, returns nil (no-op).
-func SubmoduleSync(devkitRoot, projectsDir string, projectNames []string, commitMessage string) error {
+func commitSubmodules(devkitRoot, projectsDir string, projectNames []string, commitMessage string) error {
 	if len(projectNames) == 0 {
 		return nil
 	}
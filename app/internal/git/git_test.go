@@ -0,0 +1,300 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs git in dir and returns its trimmed output, failing the test on error
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newRepo creates a repository in a temp dir with one commit per message
+func newRepo(t *testing.T, messages ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+		{"GIT_CONFIG_GLOBAL", os.DevNull}, {"GIT_CONFIG_NOSYSTEM", "1"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	for _, msg := range messages {
+		commitFile(t, dir, msg)
+	}
+	return dir
+}
+
+// commitFile commits a change to file.txt with the given message
+func commitFile(t *testing.T, dir, msg string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(msg+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "-q", "-m", msg)
+}
+
+func TestCreateTagAtCommit(t *testing.T) {
+	dir := newRepo(t, "first", "second")
+	first := runGit(t, dir, "rev-parse", "HEAD~1")
+
+	if err := CreateTag(dir, "v0.1.0", "", first, false); err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+	if got := runGit(t, dir, "rev-parse", "v0.1.0^{commit}"); got != first {
+		t.Fatalf("v0.1.0 points at %s, want %s", got, first)
+	}
+	if kind := runGit(t, dir, "cat-file", "-t", "v0.1.0"); kind != "tag" {
+		t.Fatalf("v0.1.0 is a %s, want an annotated tag", kind)
+	}
+
+	if err := CreateTag(dir, "v0.2.0", "", "", true); err != nil {
+		t.Fatalf("CreateTag at HEAD: %v", err)
+	}
+	if got, head := runGit(t, dir, "rev-parse", "v0.2.0"), runGit(t, dir, "rev-parse", "HEAD"); got != head {
+		t.Fatalf("lightweight v0.2.0 = %s, want HEAD %s", got, head)
+	}
+}
+
+func TestCreateTagRejectsUnknownCommit(t *testing.T) {
+	dir := newRepo(t, "first")
+
+	if CommitExists(dir, "0123456789abcdef0123456789abcdef01234567") {
+		t.Fatal("CommitExists reported a bogus SHA")
+	}
+	if err := CreateTag(dir, "v1.0.0", "", "0123456789abcdef0123456789abcdef01234567", false); err == nil {
+		t.Fatal("CreateTag accepted a bogus SHA")
+	}
+	if tags, _ := ListTags(dir); len(tags) != 0 {
+		t.Fatalf("tags = %v, want none", tags)
+	}
+}
+
+// newSuperproject creates a DevKit-like repository with project (a repository with one commit per
+// message) added as a submodule under projects/
+func newSuperproject(t *testing.T, project string, messages ...string) (devkitRoot, projectsDir string) {
+	t.Helper()
+	upstream := newRepo(t, messages...)
+	devkitRoot = newRepo(t, "devkit")
+	runGit(t, devkitRoot, "-c", "protocol.file.allow=always", "submodule", "add", "-q", upstream, "projects/"+project)
+	runGit(t, devkitRoot, "commit", "-q", "-m", "Add "+project)
+	return devkitRoot, filepath.Join(devkitRoot, "projects")
+}
+
+func TestSubmoduleDrift(t *testing.T) {
+	devkitRoot, projectsDir := newSuperproject(t, "svc", "one")
+	projectDir := filepath.Join(projectsDir, "svc")
+	recorded := runGit(t, projectDir, "rev-parse", "--short", "HEAD")
+
+	if drifts, err := SubmoduleDrift(devkitRoot, projectsDir, []string{"svc"}); err != nil || len(drifts) != 0 {
+		t.Fatalf("in sync: SubmoduleDrift = %v, %v; want none", drifts, err)
+	}
+
+	commitFile(t, projectDir, "two")
+	current := runGit(t, projectDir, "rev-parse", "--short", "HEAD")
+
+	drifts, err := SubmoduleDrift(devkitRoot, projectsDir, []string{"svc", "not-cloned"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SubmoduleDriftInfo{
+		Name:            "svc",
+		RecordedCommit:  recorded,
+		RecordedSubject: "one",
+		CurrentCommit:   current,
+		CurrentSubject:  "two",
+	}
+	if len(drifts) != 1 || drifts[0] != want {
+		t.Fatalf("SubmoduleDrift = %+v, want [%+v]", drifts, want)
+	}
+}
+
+func TestSuggestTagName(t *testing.T) {
+	tests := []struct {
+		raw        string
+		policy     TagPrefixPolicy
+		suggestion string
+		valid      bool
+	}{
+		{"v1.2.0", TagPrefixRequireV, "v1.2.0", true},
+		{"1.2.0", TagPrefixRequireV, "v1.2.0", false},
+		{" 1.2.0. ", TagPrefixRequireV, "v1.2.0", false},
+		{"V 1.2.0", TagPrefixRequireV, "v1.2.0", false},
+		{"v1..2.0", TagPrefixRequireV, "v1.2.0", false},
+		{"v1.2.0~rc:1", TagPrefixRequireV, "v1.2.0rc1", false},
+		{"release candidate", TagPrefixRequireV, "release-candidate", false},
+		{"v1.2.0", TagPrefixForbidV, "1.2.0", false},
+		{"1.2.0", TagPrefixForbidV, "1.2.0", true},
+		{"V1.2.0", TagPrefixAny, "V1.2.0", true},
+		{"V1.2.0.", TagPrefixAny, "v1.2.0", false},
+		{"1.2.0", TagPrefixAny, "1.2.0", true},
+		{"nightly", TagPrefixRequireV, "nightly", true},
+		{"  ", TagPrefixRequireV, "", false},
+		{"~^:", TagPrefixRequireV, "", false},
+	}
+	for _, tt := range tests {
+		suggestion, err := SuggestTagName(tt.raw, tt.policy)
+		if (err == nil) != tt.valid || suggestion != tt.suggestion {
+			t.Errorf("SuggestTagName(%q, %d) = %q, %v; want %q, valid=%v", tt.raw, tt.policy, suggestion, err, tt.suggestion, tt.valid)
+		}
+	}
+}
+
+func TestNextVersionTag(t *testing.T) {
+	tests := []struct {
+		tags   []string
+		bump   string
+		policy TagPrefixPolicy
+		want   string
+	}{
+		{nil, "", TagPrefixRequireV, "v0.1.0"},
+		{nil, "minor", TagPrefixForbidV, "0.1.0"},
+		{[]string{"v1.2.3", "v1.10.0", "nightly", "v1.9.9"}, "", TagPrefixRequireV, "v1.10.1"},
+		{[]string{"v1.2.3"}, "major", TagPrefixRequireV, "v2.0.0"},
+		{[]string{"v1.3.0-rc.1", "v1.2.0"}, "minor", TagPrefixRequireV, "v1.3.0"},
+		// rc.10 outranks rc.2 by semver precedence, so its (missing) prefix is kept
+		{[]string{"1.2.0-rc.10", "v1.2.0-rc.2"}, "patch", TagPrefixAny, "1.2.0"},
+		{[]string{"1.2.0-beta", "v1.2.0-alpha.5"}, "patch", TagPrefixAny, "1.2.0"},
+		{[]string{"1.2.0-rc.1", "v1.2.0-rc.1.1"}, "patch", TagPrefixAny, "v1.2.0"},
+		// Build metadata doesn't count; leading zeros aren't semver
+		{[]string{"v1.2.0+build.9", "1.2.0"}, "patch", TagPrefixAny, "v1.2.1"},
+		{[]string{"v1.2.0", "v1.3.0-rc.01", "01.4.0"}, "patch", TagPrefixRequireV, "v1.2.1"},
+	}
+	for _, tt := range tests {
+		got, err := NextVersionTag(tt.tags, tt.bump, tt.policy)
+		if err != nil || got != tt.want {
+			t.Errorf("NextVersionTag(%q, %q, %d) = %q, %v; want %q", tt.tags, tt.bump, tt.policy, got, err, tt.want)
+		}
+	}
+	if _, err := NextVersionTag(nil, "huge", TagPrefixRequireV); err == nil {
+		t.Error("NextVersionTag with an invalid bump succeeded")
+	}
+}
+
+func TestCheckTagPrefix(t *testing.T) {
+	tests := []struct {
+		tag    string
+		policy TagPrefixPolicy
+		ok     bool
+	}{
+		{"v1.0.0", TagPrefixRequireV, true},
+		{"1.0.0", TagPrefixRequireV, false},
+		{"V1.0.0", TagPrefixRequireV, false},
+		{"1.0.0", TagPrefixForbidV, true},
+		{"v1.0.0", TagPrefixForbidV, false},
+		{"V1.0.0", TagPrefixAny, true},
+		{"release-2024", TagPrefixRequireV, true}, // not version-like
+	}
+	for _, tt := range tests {
+		if err := checkTagPrefix(tt.tag, tt.policy); (err == nil) != tt.ok {
+			t.Errorf("checkTagPrefix(%q, %d) = %v, want ok=%v", tt.tag, tt.policy, err, tt.ok)
+		}
+	}
+}
+
+func TestParseTagPrefixPolicy(t *testing.T) {
+	for name, want := range map[string]TagPrefixPolicy{"": TagPrefixRequireV, "v": TagPrefixRequireV, "none": TagPrefixForbidV, "ANY": TagPrefixAny} {
+		if got := ParseTagPrefixPolicy(name); got != want {
+			t.Errorf("ParseTagPrefixPolicy(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestUnshallowFetchesAllBranches(t *testing.T) {
+	upstream := newRepo(t, "one", "two", "three")
+	runGit(t, upstream, "checkout", "-q", "-b", "feature")
+	commitFile(t, upstream, "feature work")
+	runGit(t, upstream, "checkout", "-q", "main")
+
+	dir := filepath.Join(t.TempDir(), "clone")
+	// file:// so the local clone honours --depth
+	if err := CloneRepo("file://"+upstream, dir, "main", 1); err != nil {
+		t.Fatal(err)
+	}
+	if !IsShallow(dir) {
+		t.Fatal("depth 1 clone is not shallow")
+	}
+
+	if err := Unshallow(dir); err != nil {
+		t.Fatalf("Unshallow: %v", err)
+	}
+	if IsShallow(dir) {
+		t.Error("still shallow after Unshallow")
+	}
+	if n := runGit(t, dir, "rev-list", "--count", "HEAD"); n != "3" {
+		t.Errorf("main has %s commits after Unshallow, want 3", n)
+	}
+	if got, want := runGit(t, dir, "rev-parse", "origin/feature"), runGit(t, upstream, "rev-parse", "feature"); got != want {
+		t.Errorf("origin/feature = %s, want %s", got, want)
+	}
+	if refspec := runGit(t, dir, "config", "remote.origin.fetch"); refspec != "+refs/heads/*:refs/remotes/origin/*" {
+		t.Errorf("remote.origin.fetch = %q, want every branch", refspec)
+	}
+
+	// A complete repository is left alone
+	if err := Unshallow(dir); err != nil {
+		t.Errorf("Unshallow on a complete clone: %v", err)
+	}
+}
+
+func TestSubmoduleSyncPushesWhenAhead(t *testing.T) {
+	devkitRoot, projectsDir := newSuperproject(t, "svc", "one")
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	runGit(t, devkitRoot, "remote", "add", "origin", remote)
+	runGit(t, devkitRoot, "push", "-q", "-u", "origin", "main")
+
+	// Nothing to commit and nothing to push
+	if pushed, err := SubmoduleSync(devkitRoot, projectsDir, nil, "sync", true, nil); err != nil || pushed {
+		t.Fatalf("in sync: SubmoduleSync = %v, %v; want no push", pushed, err)
+	}
+
+	// An earlier sync committed without pushing: the next sync pushes it even with no changes
+	commitFile(t, devkitRoot, "unpushed")
+	pushed, err := SubmoduleSync(devkitRoot, projectsDir, nil, "sync", true, nil)
+	if err != nil || !pushed {
+		t.Fatalf("ahead: SubmoduleSync = %v, %v; want a push", pushed, err)
+	}
+	if got, want := runGit(t, remote, "rev-parse", "main"), runGit(t, devkitRoot, "rev-parse", "HEAD"); got != want {
+		t.Errorf("remote main = %s, want %s", got, want)
+	}
+}
+
+func TestSubmoduleSyncCommittedPushFailed(t *testing.T) {
+	devkitRoot, projectsDir := newSuperproject(t, "svc", "one")
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	runGit(t, devkitRoot, "remote", "add", "origin", remote)
+	runGit(t, devkitRoot, "push", "-q", "-u", "origin", "main")
+	commitFile(t, filepath.Join(projectsDir, "svc"), "two")
+	if err := os.RemoveAll(remote); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := SubmoduleSync(devkitRoot, projectsDir, []string{"svc"}, "Sync svc", true, nil)
+	var pushErr *CommittedPushError
+	if !errors.As(err, &pushErr) {
+		t.Fatalf("SubmoduleSync with an unreachable remote = %v, want a CommittedPushError", err)
+	}
+	if subject := runGit(t, devkitRoot, "log", "-1", "--format=%s"); subject != "Sync svc" {
+		t.Errorf("last commit = %q, want the sync commit", subject)
+	}
+}
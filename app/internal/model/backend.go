@@ -11,6 +11,28 @@ type BackendService struct {
 	DocsURL    string   `json:"docsUrl,omitempty"`
 	Error      string   `json:"error,omitempty"`
 	LastOutput []string `json:"lastOutput,omitempty"` // last stdout/stderr lines when in error state
+
+	UptimeSeconds int64 `json:"uptimeSeconds"` // 0 when stopped or unknown (Adopted)
+	Restarts      int   `json:"restarts"`      // crashes since the dashboard started; a manual stop/start doesn't count
+	Adopted       bool  `json:"adopted"`       // running but not started by us, so uptime is unknown
+}
+
+// LogLine is a line of backend service output
+type LogLine struct {
+	Service   string `json:"service"`
+	Text      string `json:"text"`
+	Level     string `json:"level,omitempty"`    // "debug", "info", "warn", "error"; empty if not detected
+	Timestamp string `json:"timestamp"`          // When the dashboard received the line (RFC 3339)
+	Stream    string `json:"stream"`             // "stdout", "stderr" or "system"
+	Replayed  bool   `json:"replayed,omitempty"` // Sent from recent history when subscribing
+}
+
+// ProcessStats is a snapshot of a backend service process's resource usage
+type ProcessStats struct {
+	Name       string  `json:"name"`
+	PID        int     `json:"pid"`
+	RSSBytes   int64   `json:"rssBytes"`   // Resident memory
+	CPUPercent float64 `json:"cpuPercent"` // Average over the process lifetime (100 = one core)
 }
 
 // MigrationStatus represents database migration state
@@ -45,3 +67,24 @@ type EnvVar struct {
 	Required  bool   `json:"required"`
 	Sensitive bool   `json:"sensitive"`
 }
+
+// EnvValidationError is a .env var whose value doesn't match its declared type or constraints
+type EnvValidationError struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"` // e.g. "PORT=abc is not a valid integer"
+}
+
+// EnvImportSummary reports what ImportEnv did with each imported key
+type EnvImportSummary struct {
+	Added   []string `json:"added"`
+	Updated []string `json:"updated"`
+	Skipped []string `json:"skipped"` // Unchanged, kept because overwrite was off, or empty (redacted) values
+}
+
+// ServiceEnvUsage lists env vars a service's code reads, cross-referenced with the known vars
+type ServiceEnvUsage struct {
+	Service    string   `json:"service"`
+	Used       []string `json:"used"`       // Read by the service's code
+	Undeclared []string `json:"undeclared"` // Read by the code but not a known required/optional var
+	Unused     []string `json:"unused"`     // Known vars the service's code never reads
+}
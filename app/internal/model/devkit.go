@@ -7,7 +7,25 @@ type ProtoStatus struct {
 	ProtosPath string `json:"protosPath,omitempty"`
 }
 
-// Notice represents a dashboard notice (sync, proto, migration, env, docker)
+// ProtoBreak is one wire-incompatible change reported by buf breaking
+type ProtoBreak struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Rule    string `json:"rule,omitempty"` // buf rule ID, e.g. "FIELD_NO_DELETE"
+	Message string `json:"message"`
+}
+
+// ProtoLintIssue is one style/consistency problem reported by buf lint
+type ProtoLintIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Rule    string `json:"rule,omitempty"` // buf rule ID, e.g. "FIELD_LOWER_SNAKE_CASE"
+	Message string `json:"message"`
+}
+
+// Notice represents a dashboard notice (sync, proto, migration, env, docker, ports)
 type Notice struct {
 	ID        string `json:"id"`
 	Severity  string `json:"severity"` // "info", "warn", "error"
@@ -15,6 +33,51 @@ type Notice struct {
 	ActionKey string `json:"actionKey,omitempty"` // "sync", "proto", "env", "migration", "docker"
 }
 
+// ActivityEvent is a notable dashboard event (service start/stop, clone, migration, tag, ...) kept in
+// the persistent Activity feed
+type ActivityEvent struct {
+	Time    string `json:"time"`   // RFC3339
+	Type    string `json:"type"`   // "backend", "service", "project", "submodule", "migration", "proto", "tag", ...
+	Target  string `json:"target"` // Service, project or operation the event is about
+	Success bool   `json:"success"`
+	Level   string `json:"level"` // "info", "warn", "error"
+	Message string `json:"message"`
+}
+
+// ActivityQuery filters GetActivity; empty fields match everything
+type ActivityQuery struct {
+	Type   string `json:"type,omitempty"`   // Exact event type, e.g. "backend"
+	Target string `json:"target,omitempty"` // Exact service/project name
+	Level  string `json:"level,omitempty"`  // "info", "warn" or "error"
+	Since  string `json:"since,omitempty"`  // RFC3339, inclusive
+	Until  string `json:"until,omitempty"`  // RFC3339, inclusive
+	Search string `json:"search,omitempty"` // Case-insensitive substring of the message or target
+	Offset int    `json:"offset,omitempty"` // Matches to skip (newest first), for pagination
+	Limit  int    `json:"limit,omitempty"`  // Max events returned (0 = all)
+}
+
+// ActivityPage is one page of GetActivity results, newest first
+type ActivityPage struct {
+	Events []ActivityEvent `json:"events"`
+	Total  int             `json:"total"` // Matches before Offset/Limit
+}
+
+// DoctorCheck is one check in a DoctorReport
+type DoctorCheck struct {
+	ID      string `json:"id"` // "prerequisites", "docker", "env", "database", "proto", "submodules"
+	Name    string `json:"name"`
+	Status  string `json:"status"` // "pass", "warn", "fail"
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"` // How to fix a warn/fail
+}
+
+// DoctorReport is the result of RunDoctor: every setup check plus the worst status among them
+type DoctorReport struct {
+	Status string        `json:"status"` // "pass", "warn", "fail"
+	Checks []DoctorCheck `json:"checks"`
+	RanAt  string        `json:"ranAt"` // RFC3339
+}
+
 // Prerequisite represents a required or optional tool
 type Prerequisite struct {
 	Name      string `json:"name"`
@@ -23,3 +86,76 @@ type Prerequisite struct {
 	Required  bool   `json:"required"`
 	Message   string `json:"message,omitempty"`
 }
+
+// StreamEvent is an event emitted by an output stream, as kept for ResumeStream
+type StreamEvent struct {
+	Seq     int                    `json:"seq"`
+	Event   string                 `json:"event"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// StreamReplay is the result of ResumeStream: the buffered events after the requested sequence number
+type StreamReplay struct {
+	Token     string        `json:"token"`
+	Active    bool          `json:"active"`    // the stream is still running
+	Reset     bool          `json:"reset"`     // the panel saw another run of this stream: Events starts over
+	Run       uint64        `json:"run"`       // run the buffered events belong to
+	Truncated bool          `json:"truncated"` // events between afterSeq and the first returned one were evicted
+	LastSeq   int           `json:"lastSeq"`
+	Events    []StreamEvent `json:"events"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// StreamFinalState reports the state of an output stream when it was stopped
+type StreamFinalState struct {
+	Token     string `json:"token"`
+	WasActive bool   `json:"wasActive"` // false if the token was unknown or the stream had already finished
+	Completed bool   `json:"completed"` // stream finished on its own before the stop took effect
+	Lines     int    `json:"lines"`
+	ExitCode  *int   `json:"exitCode,omitempty"` // set when the underlying command's exit code is known
+	Message   string `json:"message,omitempty"`
+}
+
+// SubmoduleChange previews what SubmoduleSync records for one project
+type SubmoduleChange struct {
+	Name       string `json:"name"`
+	OldCommit  string `json:"oldCommit"` // Short hash recorded in DevKit now; empty for a new submodule
+	OldSubject string `json:"oldSubject,omitempty"`
+	NewCommit  string `json:"newCommit"` // Short hash of the project's HEAD
+	NewSubject string `json:"newSubject,omitempty"`
+	Commits    int    `json:"commits"` // Commits in NewCommit that OldCommit lacks; -1 when unknown
+	Dropped    int    `json:"dropped"` // Commits in OldCommit that NewCommit lacks (rewind); -1 when unknown
+}
+
+// Overview is a one-call snapshot of the dashboard for its initial load. Sections are assembled
+// independently: one that failed is left empty and its *Error field says why.
+type Overview struct {
+	GeneratedAt     string            `json:"generatedAt"` // RFC3339
+	Projects        []Project         `json:"projects"`
+	ProjectsError   string            `json:"projectsError,omitempty"`
+	Services        []Service         `json:"services"`
+	Backend         []BackendService  `json:"backend"`
+	Migration       *MigrationSummary `json:"migration,omitempty"`
+	MigrationError  string            `json:"migrationError,omitempty"`
+	Env             *EnvValidity      `json:"env,omitempty"`
+	EnvError        string            `json:"envError,omitempty"`
+	Notices         []Notice          `json:"notices"`
+	NoticesError    string            `json:"noticesError,omitempty"`
+	DockerConnected bool              `json:"dockerConnected"`
+}
+
+// MigrationSummary condenses MigrationStatus for the overview
+type MigrationSummary struct {
+	CurrentVersion uint `json:"currentVersion"`
+	Dirty          bool `json:"dirty"`
+	Applied        int  `json:"applied"`
+	Pending        int  `json:"pending"`
+}
+
+// EnvValidity reports whether .env has every required var and well-typed values
+type EnvValidity struct {
+	HasEnvFile bool                 `json:"hasEnvFile"`
+	Valid      bool                 `json:"valid"`
+	Missing    []string             `json:"missing"`
+	Errors     []EnvValidationError `json:"errors"`
+}
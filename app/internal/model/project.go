@@ -9,13 +9,100 @@ type Project struct {
 	Status   string `json:"status"`
 	Language string `json:"language,omitempty"`
 	RepoURL  string `json:"repoUrl,omitempty"` // GitHub repo URL for the project card link
+	Shallow  bool   `json:"shallow"`           // Cloned with limited history; ProjectUnshallow fetches the rest
+
+	// Disk usage including .git, once measured by ProjectSize (0 = not measured yet)
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// Changed file counts from git status; Dirty is Staged+Unstaged > 0
+	Staged    int `json:"staged"`
+	Unstaged  int `json:"unstaged"`
+	Untracked int `json:"untracked"`
+
+	// Commits ahead of / behind the upstream branch as of the last fetch; both 0 when HasUpstream is false
+	Ahead       int  `json:"ahead"`
+	Behind      int  `json:"behind"`
+	HasUpstream bool `json:"hasUpstream"`
+}
+
+// ProjectSize is the disk usage of a cloned project
+type ProjectSize struct {
+	Name       string `json:"name"`
+	SizeBytes  int64  `json:"sizeBytes"`  // Working tree plus GitBytes
+	GitBytes   int64  `json:"gitBytes"`   // The git dir alone (.git, or the dir a submodule's .git file points to)
+	MeasuredAt string `json:"measuredAt"` // RFC 3339; results are cached until the project is cloned/updated
+	// GitUnavailable is set when the project's .git file points to a git dir that can't be read;
+	// GitBytes is then 0 and SizeBytes covers the working tree only
+	GitUnavailable bool `json:"gitUnavailable,omitempty"`
+}
+
+// CleanPlan describes how CleanProject cleans a project
+type CleanPlan struct {
+	Project string   `json:"project"`
+	UseMake bool     `json:"useMake"` // Runs "make clean"; Paths is empty
+	Paths   []string `json:"paths"`   // Project-relative dirs/files removed otherwise (needs confirmation)
+}
+
+// Commit is one entry of a project's git log
+type Commit struct {
+	Hash      string `json:"hash"`
+	Author    string `json:"author"`
+	Timestamp string `json:"timestamp"` // Author date (RFC 3339)
+	Subject   string `json:"subject"`
+}
+
+// BulkProjectResult is one project's outcome in a bulk make run
+type BulkProjectResult struct {
+	Project    string `json:"project"`
+	Success    bool   `json:"success"`
+	ExitCode   int    `json:"exitCode"` // -1 when make could not be run
+	DurationMs int64  `json:"durationMs"`
 }
 
 // Dependency represents a project dependency
 type Dependency struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
-	Type    string `json:"type"` // "direct", "indirect", "production", "dev"
+	Type    string `json:"type"` // "direct", "indirect", "production", "dev", "build"
+}
+
+// DependencyGraph shows how the cloned Wabi Saby projects depend on each other
+type DependencyGraph struct {
+	Nodes  []DependencyNode `json:"nodes"`
+	Edges  []DependencyEdge `json:"edges"`
+	Cycles [][]string       `json:"cycles"` // Projects that (transitively) depend on each other, one group per cycle
+}
+
+// DependencyNode is a project in a DependencyGraph
+type DependencyNode struct {
+	Name    string `json:"name"`
+	Error   string `json:"error,omitempty"` // Why its dependencies couldn't be read
+	InCycle bool   `json:"inCycle"`
+}
+
+// DependencyEdge means From depends on To
+type DependencyEdge struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Version string `json:"version"`
+	Type    string `json:"type"` // As in Dependency.Type
+	InCycle bool   `json:"inCycle"`
+}
+
+// DepMismatch is a Go dependency on a sibling project whose go.mod version differs from what the
+// local clone has checked out
+type DepMismatch struct {
+	Project    string `json:"project"`    // Project whose go.mod pins the dependency
+	Dependency string `json:"dependency"` // Sibling project depended on
+	Required   string `json:"required"`   // Version in go.mod, e.g. "v1.2.0" or a pseudo-version
+	Local      string `json:"local"`      // Tag(s) at the clone's HEAD, or its short commit when untagged
+	Message    string `json:"message"`
+}
+
+// LocalReplace is a go.mod replace directive pointing a module at a local directory
+type LocalReplace struct {
+	Module string `json:"module"` // e.g. github.com/WabiSaby/wabisaby-protos
+	Path   string `json:"path"`   // e.g. ../wabisaby-protos
 }
 
 // Response represents a generic API response
@@ -24,3 +111,11 @@ type Response struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+// Artifact represents a build output file of a project
+type Artifact struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"` // Relative to the project root
+	Size       int64  `json:"size"`
+	ModifiedAt string `json:"modifiedAt"` // RFC 3339
+}
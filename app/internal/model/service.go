@@ -5,5 +5,19 @@ type Service struct {
 	Name   string `json:"name"`
 	Port   int    `json:"port"`
 	Status string `json:"status"`
+	Health string `json:"health"`        // "healthy", "unhealthy" or "starting" from the container healthcheck; else Status
 	URL    string `json:"url,omitempty"` // Web UI URL when applicable (pgAdmin, MinIO Console, Vault UI)
+
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// ContainerStats is a snapshot of a Docker container's resource usage (docker stats)
+type ContainerStats struct {
+	Container     string  `json:"container"`
+	CPUPercent    float64 `json:"cpuPercent"` // 100 = one core
+	MemUsageBytes int64   `json:"memUsageBytes"`
+	MemLimitBytes int64   `json:"memLimitBytes"`
+	MemPercent    float64 `json:"memPercent"`
+	NetRxBytes    int64   `json:"netRxBytes"` // Received since the container started
+	NetTxBytes    int64   `json:"netTxBytes"` // Sent since the container started
 }
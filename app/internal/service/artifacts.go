@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// ListBuildArtifacts returns the files under the project's artifact dirs (project-relative, e.g. "bin")
+// plus the binary "go build" writes to the module root (named after the module path), sorted by path.
+func ListBuildArtifacts(projectDir string, artifactDirs []string) ([]model.Artifact, error) {
+	if _, err := os.Stat(projectDir); err != nil {
+		return nil, fmt.Errorf("project not cloned: clone the project first")
+	}
+
+	paths, err := artifactPaths(projectDir, artifactDirs)
+	if err != nil {
+		return nil, err
+	}
+	if p := moduleBinaryPath(projectDir); p != "" {
+		paths = append(paths, p)
+		sort.Strings(paths)
+	}
+	artifacts := make([]model.Artifact, 0, len(paths))
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		rel, _ := filepath.Rel(projectDir, p)
+		artifacts = append(artifacts, model.Artifact{
+			Name:       info.Name(),
+			Path:       filepath.ToSlash(rel),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime().Format(time.RFC3339),
+		})
+	}
+	return artifacts, nil
+}
+
+// CleanBuildArtifacts deletes the files in the project's artifact dirs and returns how many were removed.
+// Nothing outside the artifact dirs is deleted; the module-root binary listed by ListBuildArtifacts is kept.
+func CleanBuildArtifacts(projectDir string, artifactDirs []string) (int, error) {
+	defer invalidateDiskUsage(projectDir)
+	if _, err := os.Stat(projectDir); err != nil {
+		return 0, fmt.Errorf("project not cloned: clone the project first")
+	}
+
+	paths, err := artifactPaths(projectDir, artifactDirs)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, p := range paths {
+		if !insideArtifactDir(projectDir, artifactDirs, p) {
+			return removed, fmt.Errorf("refusing to delete %s: outside artifact directories", p)
+		}
+		if err := os.Remove(p); err != nil {
+			return removed, fmt.Errorf("failed to delete %s: %w", p, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// artifactPaths returns absolute paths of regular files in the artifact dirs, sorted
+func artifactPaths(projectDir string, artifactDirs []string) ([]string, error) {
+	var paths []string
+	for _, dir := range artifactDirs {
+		root := filepath.Join(projectDir, filepath.FromSlash(dir))
+		if !insideProject(projectDir, root) {
+			continue
+		}
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			// Regular files only: symlinks may point outside the project
+			if d.Type().IsRegular() {
+				paths = append(paths, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// moduleBinaryPath returns the path of the executable "go build" leaves in the module root, or "" if absent
+func moduleBinaryPath(projectDir string) string {
+	module := goModModulePath(projectDir)
+	name := path.Base(module)
+	if module == "" || name == "." || name == "/" {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	p := filepath.Join(projectDir, name)
+	info, err := os.Lstat(p)
+	if err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+		return ""
+	}
+	return p
+}
+
+// insideArtifactDir reports whether p lies inside one of the project's artifact dirs
+func insideArtifactDir(projectDir string, artifactDirs []string, p string) bool {
+	for _, dir := range artifactDirs {
+		root := filepath.Join(projectDir, filepath.FromSlash(dir))
+		if !insideProject(projectDir, root) {
+			continue
+		}
+		if rel, err := filepath.Rel(root, p); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// insideProject reports whether dir is a strict subdirectory of projectDir
+func insideProject(projectDir, dir string) bool {
+	rel, err := filepath.Rel(projectDir, dir)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
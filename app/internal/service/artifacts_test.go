@@ -0,0 +1,126 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// newArtifactProject returns a project with a module-root binary, two binaries under bin and one
+// under out, plus source files that must never be touched
+func newArtifactProject(t *testing.T) string {
+	t.Helper()
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "go.mod", "module github.com/wabisaby/core\n\ngo 1.22\n")
+	writeProjectFile(t, projectsDir, "core", "main.go", "package main\n")
+	writeProjectFile(t, projectsDir, "core", "bin/api", "\x7fELF api")
+	writeProjectFile(t, projectsDir, "core", "bin/tools/migrate", "\x7fELF migrate")
+	writeProjectFile(t, projectsDir, "core", "out/worker", "\x7fELF worker")
+	projectDir := filepath.Join(projectsDir, "core")
+	binary := filepath.Join(projectDir, "core")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	if err := os.WriteFile(binary, []byte("\x7fELF core"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir
+}
+
+func TestListBuildArtifacts(t *testing.T) {
+	projectDir := newArtifactProject(t)
+	moduleBinary := "core"
+	if runtime.GOOS == "windows" {
+		moduleBinary += ".exe"
+	}
+
+	artifacts, err := ListBuildArtifacts(projectDir, []string{"bin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, a := range artifacts {
+		paths = append(paths, a.Path)
+		if a.Size == 0 || a.ModifiedAt == "" {
+			t.Errorf("artifact %s has size %d, modified %q", a.Path, a.Size, a.ModifiedAt)
+		}
+	}
+	want := []string{"bin/api", "bin/tools/migrate", moduleBinary}
+	if len(paths) != len(want) {
+		t.Fatalf("artifacts = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("artifacts = %v, want %v", paths, want)
+		}
+	}
+
+	// Configured dirs replace the default; dirs outside the project are ignored
+	artifacts, err = ListBuildArtifacts(projectDir, []string{"out", "..", "../elsewhere"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 2 || artifacts[0].Path != moduleBinary || artifacts[1].Path != "out/worker" {
+		t.Errorf("artifacts with dirs [out ..] = %+v", artifacts)
+	}
+}
+
+func TestCleanBuildArtifacts(t *testing.T) {
+	projectDir := newArtifactProject(t)
+	moduleBinary := filepath.Join(projectDir, "core")
+	if runtime.GOOS == "windows" {
+		moduleBinary += ".exe"
+	}
+
+	removed, err := CleanBuildArtifacts(projectDir, []string{"bin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+	for _, rel := range []string{"bin/api", "bin/tools/migrate"} {
+		if _, err := os.Stat(filepath.Join(projectDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after clean", rel)
+		}
+	}
+	// Only the artifact dirs are cleaned: the module-root binary, other dirs and sources stay
+	for _, p := range []string{moduleBinary, filepath.Join(projectDir, "out", "worker"), filepath.Join(projectDir, "main.go"), filepath.Join(projectDir, "go.mod")} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("clean removed %s: %v", p, err)
+		}
+	}
+
+	// Nothing left to remove
+	if removed, err := CleanBuildArtifacts(projectDir, []string{"bin"}); err != nil || removed != 0 {
+		t.Errorf("second clean = %d, %v; want 0, nil", removed, err)
+	}
+}
+
+func TestCleanBuildArtifactsIgnoresDirsOutsideProject(t *testing.T) {
+	projectDir := newArtifactProject(t)
+	outside := filepath.Join(filepath.Dir(projectDir), "keep.txt")
+	if err := os.WriteFile(outside, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := CleanBuildArtifacts(projectDir, []string{"..", ".", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 {
+		t.Errorf("removed = %d, want 0", removed)
+	}
+	for _, p := range []string{outside, filepath.Join(projectDir, "main.go"), filepath.Join(projectDir, "bin", "api")} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("clean with unsafe dirs removed %s: %v", p, err)
+		}
+	}
+}
+
+func TestListBuildArtifactsNotCloned(t *testing.T) {
+	if _, err := ListBuildArtifacts(filepath.Join(t.TempDir(), "missing"), []string{"bin"}); err == nil {
+		t.Error("ListBuildArtifacts on a missing project succeeded, want an error")
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// cleanDirs are the well-known project-relative build/dependency dirs CleanProject removes when the
+// project has no "make clean" target (in addition to the configured artifact dirs)
+var cleanDirs = []string{"dist", "target", "node_modules", "bin"}
+
+// PlanClean returns how CleanProject would clean a project: "make clean" when its Makefile defines
+// a clean target, otherwise the existing well-known artifact dirs (and, for protobuf projects, the
+// generated *.pb.go files). artifactDirs are the configured build output dirs (see ListBuildArtifacts).
+// Paths tracked by git, or when git can't tell, are never included.
+func PlanClean(projectsDir, projectName string, artifactDirs []string) (*model.CleanPlan, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); err != nil {
+		return nil, fmt.Errorf("project not cloned: clone the project first")
+	}
+
+	plan := &model.CleanPlan{Project: projectName, Paths: []string{}}
+	if targets, err := ListMakeTargets(projectsDir, projectName); err == nil {
+		for _, t := range targets {
+			if t == "clean" {
+				plan.UseMake = true
+				return plan, nil
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, dir := range append(append([]string{}, cleanDirs...), artifactDirs...) {
+		root := filepath.Join(projectDir, filepath.FromSlash(dir))
+		info, err := os.Lstat(root)
+		if err != nil || !info.IsDir() || !insideProject(projectDir, root) || seen[root] || gitTracked(projectDir, root) {
+			continue
+		}
+		seen[root] = true
+		plan.Paths = append(plan.Paths, filepath.ToSlash(dir))
+	}
+
+	if detectProjectLanguage(projectDir, projectName) == "Protobuf" {
+		err := filepath.WalkDir(projectDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if name := d.Name(); p != projectDir && (name == ".git" || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.Type().IsRegular() && strings.HasSuffix(d.Name(), ".pb.go") && !gitTracked(projectDir, p) {
+				rel, _ := filepath.Rel(projectDir, p)
+				plan.Paths = append(plan.Paths, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(plan.Paths)
+	return plan, nil
+}
+
+// RemoveCleanPath deletes one path from a PlanClean result (relative to the project), refusing
+// anything outside the project
+func RemoveCleanPath(projectDir, rel string) error {
+	p := filepath.Join(projectDir, filepath.FromSlash(rel))
+	if !insideProject(projectDir, p) {
+		return fmt.Errorf("refusing to delete %s: outside the project", rel)
+	}
+	if err := os.RemoveAll(p); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", rel, err)
+	}
+	return nil
+}
+
+// gitTracked reports whether path (a file or dir in the project) contains files tracked by git. It
+// fails closed: when git can't answer (not installed, a broken repository) the path counts as tracked.
+// A project that isn't a git repository tracks nothing.
+func gitTracked(projectDir, path string) bool {
+	if _, err := os.Stat(filepath.Join(projectDir, ".git")); os.IsNotExist(err) {
+		return false
+	}
+	cmd := exec.Command("git", "ls-files", "--", path)
+	cmd.Dir = projectDir
+	output, err := cmd.Output()
+	return err != nil || strings.TrimSpace(string(output)) != ""
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPlanCleanSkipsTrackedPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "web", "package.json", "{}\n")
+	writeProjectFile(t, projectsDir, "web", "dist/app.js", "built\n")
+	writeProjectFile(t, projectsDir, "web", "node_modules/left-pad/index.js", "module.exports = 1\n")
+	writeProjectFile(t, projectsDir, "web", "bin/run.sh", "#!/bin/sh\n")
+	projectDir := filepath.Join(projectsDir, "web")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	for _, args := range [][]string{{"init", "-q"}, {"add", "bin/run.sh"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = projectDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	plan, err := PlanClean(projectsDir, "web", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"dist", "node_modules"}; !reflect.DeepEqual(plan.Paths, want) {
+		t.Errorf("plan = %v, want %v (bin is tracked)", plan.Paths, want)
+	}
+}
+
+func TestPlanCleanFailsClosedWhenGitCantAnswer(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "web", "dist/app.js", "built\n")
+	// A submodule whose gitdir is gone: git ls-files errors, so nothing counts as safe to delete
+	writeProjectFile(t, projectsDir, "web", ".git", "gitdir: ../../missing/modules/web\n")
+
+	plan, err := PlanClean(projectsDir, "web", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Paths) != 0 {
+		t.Errorf("plan = %v with a broken repository, want nothing", plan.Paths)
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+)
+
+// composePort is a published port mapping from the merged compose config
+type composePort struct {
+	Target    int
+	Published int
+}
+
+// composePortsCacheEntry holds parsed port mappings (or the failure) for a given compose/override mtime signature
+type composePortsCacheEntry struct {
+	signature string
+	ports     map[string][]composePort
+	err       error
+}
+
+var (
+	composePortsMu    sync.Mutex
+	composePortsCache = make(map[string]composePortsCacheEntry)
+)
+
+// ResolveServicePorts returns the published host port per dashboard Docker service from the merged
+// compose config (docker-compose.yml plus docker-compose.override.yml). Services whose mapping is not
+// found are omitted, so callers keep their configured default.
+func ResolveServicePorts(devkitRoot string) (map[string]int, error) {
+	mappings, err := composePublishedPorts(devkitRoot)
+	if err != nil {
+		return nil, err
+	}
+	ports := make(map[string]int)
+	for _, svc := range config.GetDockerServices() {
+		if p := publishedPort(mappings[svc.ComposeService()], svc.TargetPort()); p > 0 {
+			ports[svc.Name] = p
+		}
+	}
+	return ports, nil
+}
+
+// ResolveServiceURL returns svc.UIURL with its port replaced by the published port from the merged
+// compose config. A URL on the service's main port follows that port; any other port (e.g. the MinIO
+// console) is matched by container port. Returns svc.UIURL unchanged when nothing can be resolved.
+func ResolveServiceURL(devkitRoot string, svc config.DockerServiceConfig) string {
+	if svc.UIURL == "" {
+		return ""
+	}
+	u, err := url.Parse(svc.UIURL)
+	if err != nil || u.Port() == "" {
+		return svc.UIURL
+	}
+	mappings, err := composePublishedPorts(devkitRoot)
+	if err != nil {
+		return svc.UIURL
+	}
+	uiPort, _ := strconv.Atoi(u.Port())
+	target := uiPort
+	if uiPort == svc.Port {
+		target = svc.TargetPort()
+	}
+	p := publishedPort(mappings[svc.ComposeService()], target)
+	if p <= 0 || p == uiPort {
+		return svc.UIURL
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(p))
+	return u.String()
+}
+
+// publishedPort returns the host port publishing target, or 0
+func publishedPort(mappings []composePort, target int) int {
+	for _, m := range mappings {
+		if m.Target == target {
+			return m.Published
+		}
+	}
+	return 0
+}
+
+// composeFiles returns the compose file and, if present, its local override
+func composeFiles(devkitRoot string) []string {
+	files := []string{filepath.Join(devkitRoot, "docker/docker-compose.yml")}
+	override := filepath.Join(devkitRoot, "docker/docker-compose.override.yml")
+	if _, err := os.Stat(override); err == nil {
+		files = append(files, override)
+	}
+	return files
+}
+
+// composePublishedPorts runs "docker-compose config" over the compose files and returns the published
+// port mappings per compose service. Results are cached until a compose file changes.
+func composePublishedPorts(devkitRoot string) (map[string][]composePort, error) {
+	files := composeFiles(devkitRoot)
+	var sig strings.Builder
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, fmt.Errorf("compose file not found: %w", err)
+		}
+		fmt.Fprintf(&sig, "%s@%d;", f, info.ModTime().UnixNano())
+	}
+
+	composePortsMu.Lock()
+	if entry, ok := composePortsCache[devkitRoot]; ok && entry.signature == sig.String() {
+		composePortsMu.Unlock()
+		return entry.ports, entry.err
+	}
+	composePortsMu.Unlock()
+
+	args := make([]string, 0, 2*len(files)+3)
+	for _, f := range files {
+		args = append(args, "-f", f)
+	}
+	args = append(args, "config", "--format", "json")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var ports map[string][]composePort
+	output, err := ComposeCmd(ctx, args...).Output()
+	if err != nil {
+		err = fmt.Errorf("compose config failed: %w", err)
+	} else {
+		ports, err = parseComposePorts(output)
+	}
+
+	// Failures are cached too so polling callers don't re-run compose until a file changes
+	composePortsMu.Lock()
+	composePortsCache[devkitRoot] = composePortsCacheEntry{signature: sig.String(), ports: ports, err: err}
+	composePortsMu.Unlock()
+	return ports, err
+}
+
+// parseComposePorts extracts published port mappings from "docker-compose config --format json" output.
+// "published" is a string in newer compose versions and a number in older ones.
+func parseComposePorts(data []byte) (map[string][]composePort, error) {
+	var cfg struct {
+		Services map[string]struct {
+			Ports []struct {
+				Target    int         `json:"target"`
+				Published interface{} `json:"published"`
+			} `json:"ports"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid compose config: %w", err)
+	}
+
+	result := make(map[string][]composePort, len(cfg.Services))
+	for name, svc := range cfg.Services {
+		for _, p := range svc.Ports {
+			var published int
+			switch v := p.Published.(type) {
+			case float64:
+				published = int(v)
+			case string:
+				// Ranges ("8000-8001") are not mapped to a single port
+				published, _ = strconv.Atoi(v)
+			}
+			if p.Target > 0 && published > 0 {
+				result[name] = append(result[name], composePort{Target: p.Target, Published: published})
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+// mergedComposeConfig is "docker compose config --format json" output for docker-compose.yml merged
+// with an override remapping postgres to 15432 and the MinIO console to 19001
+const mergedComposeConfig = `{
+  "name": "docker",
+  "services": {
+    "postgres": {"ports": [{"mode": "ingress", "target": 5432, "published": "15432", "protocol": "tcp"}]},
+    "minio": {"ports": [
+      {"mode": "ingress", "target": 9000, "published": "9000", "protocol": "tcp"},
+      {"mode": "ingress", "target": 9001, "published": "19001", "protocol": "tcp"}
+    ]},
+    "pgadmin": {"ports": [{"target": 80, "published": 5050}]},
+    "kafka": {"ports": [{"target": 9092, "published": "9092-9093"}, {"target": 9094}]},
+    "redis": {}
+  }
+}`
+
+func TestParseComposePorts(t *testing.T) {
+	got, err := parseComposePorts([]byte(mergedComposeConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]composePort{
+		"postgres": {{Target: 5432, Published: 15432}},
+		"minio":    {{Target: 9000, Published: 9000}, {Target: 9001, Published: 19001}},
+		// Older compose versions print published as a number
+		"pgadmin": {{Target: 80, Published: 5050}},
+		// Ranges and unpublished ports are skipped, as are services without ports
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseComposePorts() = %+v, want %+v", got, want)
+	}
+
+	if p := publishedPort(got["postgres"], 5432); p != 15432 {
+		t.Errorf("published postgres port = %d, want the override's 15432", p)
+	}
+	if p := publishedPort(got["postgres"], 80); p != 0 {
+		t.Errorf("published port for an unmapped target = %d, want 0", p)
+	}
+}
+
+func TestParseComposePortsInvalid(t *testing.T) {
+	if _, err := parseComposePorts([]byte("services:\n  postgres: {}\n")); err == nil {
+		t.Error("parseComposePorts on YAML output succeeded, want an error")
+	}
+}
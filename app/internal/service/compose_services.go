@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// composeServicesMtime is the compose file mtime SyncComposeServices last synced from
+var (
+	composeServicesMu    sync.Mutex
+	composeServicesMtime int64
+)
+
+// ParseComposeServices reads a docker-compose file and returns its services in file order, with
+// the first published host port (0 when nothing is published) and container name. Services without
+// container_name get compose's default "<project>-<service>-1" name (see composeProjectName).
+func ParseComposeServices(composeFile string) ([]model.Service, error) {
+	data, err := os.ReadFile(composeFile)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Name     string    `yaml:"name"`
+		Services yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+	if doc.Services.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("compose file has no services")
+	}
+
+	project := composeProjectName(doc.Name, composeFile)
+	services := make([]model.Service, 0, len(doc.Services.Content)/2)
+	// Mapping node content alternates key, value
+	for i := 0; i+1 < len(doc.Services.Content); i += 2 {
+		name := doc.Services.Content[i].Value
+		var def struct {
+			ContainerName string      `yaml:"container_name"`
+			Ports         []yaml.Node `yaml:"ports"`
+		}
+		if err := doc.Services.Content[i+1].Decode(&def); err != nil {
+			return nil, fmt.Errorf("invalid compose service %s: %w", name, err)
+		}
+		svc := model.Service{Name: name, ContainerName: def.ContainerName}
+		if svc.ContainerName == "" {
+			svc.ContainerName = project + "-" + name + "-1"
+		}
+		for _, p := range def.Ports {
+			if published := composePublishedPort(p); published > 0 {
+				svc.Port = published
+				break
+			}
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// composeProjectName returns the project name compose uses for composeFile: the file's top-level name,
+// else $COMPOSE_PROJECT_NAME, else the file's directory, normalized like compose does (lowercase,
+// only letters, digits, "-" and "_")
+func composeProjectName(name, composeFile string) string {
+	if name == "" {
+		name = os.Getenv("COMPOSE_PROJECT_NAME")
+	}
+	if name == "" {
+		name = filepath.Base(filepath.Dir(composeFile))
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(expandComposeVars(name)))
+}
+
+// composePublishedPort returns the host port of a compose ports entry, in short ("8080:80",
+// "127.0.0.1:8080:80/tcp", "${PORT:-8080}:80") or long ({target, published}) syntax. Returns 0 for
+// container-only ports and ranges.
+func composePublishedPort(node yaml.Node) int {
+	var published string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		spec := strings.SplitN(expandComposeVars(node.Value), "/", 2)[0]
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			return 0
+		}
+		published = parts[len(parts)-2]
+	case yaml.MappingNode:
+		var long struct {
+			Published string `yaml:"published"`
+		}
+		if err := node.Decode(&long); err != nil {
+			return 0
+		}
+		published = expandComposeVars(long.Published)
+	}
+	port, err := strconv.Atoi(published)
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
+// expandComposeVars substitutes ${VAR}, ${VAR:-default} and $VAR from the environment like compose does
+func expandComposeVars(s string) string {
+	return os.Expand(s, func(key string) string {
+		name, def, hasDefault := strings.Cut(key, ":-")
+		if v := os.Getenv(name); v != "" || !hasDefault {
+			return v
+		}
+		return def
+	})
+}
+
+// SyncComposeServices lists the services from docker/docker-compose.yml that the dashboard doesn't know
+// yet (see config.SetComposeServices), so new compose services show up without code changes and removed
+// ones disappear. The file is only re-read when it changes, even after a parse error (the known
+// services are then left as they are).
+func SyncComposeServices(devkitRoot string) error {
+	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
+	info, err := os.Stat(composeFile)
+	if err != nil {
+		return err
+	}
+
+	composeServicesMu.Lock()
+	defer composeServicesMu.Unlock()
+	if info.ModTime().UnixNano() == composeServicesMtime {
+		return nil
+	}
+	composeServicesMtime = info.ModTime().UnixNano()
+
+	parsed, err := ParseComposeServices(composeFile)
+	if err != nil {
+		return err
+	}
+	configs := make([]config.DockerServiceConfig, 0, len(parsed))
+	for _, svc := range parsed {
+		configs = append(configs, config.DockerServiceConfig{
+			Name:          svc.Name,
+			ComposeName:   svc.Name,
+			ContainerName: svc.ContainerName,
+			Port:          svc.Port,
+		})
+	}
+	config.SetComposeServices(configs)
+	return nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseComposeServicesContainerNames(t *testing.T) {
+	devkitRoot := t.TempDir()
+	writeProjectFile(t, devkitRoot, "docker", "docker-compose.yml", `services:
+  postgres:
+    container_name: wabisaby-postgres
+    ports: ["5432:5432"]
+  kafka:
+    ports:
+      - target: 9092
+        published: 9092
+  worker: {}
+`)
+	writeProjectFile(t, devkitRoot, "My.Stack", "docker-compose.yml", "name: Local Stack\nservices:\n  kafka: {}\n")
+
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+	services, err := ParseComposeServices(filepath.Join(devkitRoot, "docker", "docker-compose.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		name, container string
+		port            int
+	}{
+		{"postgres", "wabisaby-postgres", 5432},
+		{"kafka", "docker-kafka-1", 9092},
+		{"worker", "docker-worker-1", 0},
+	}
+	if len(services) != len(want) {
+		t.Fatalf("services = %+v, want %d", services, len(want))
+	}
+	for i, w := range want {
+		if s := services[i]; s.Name != w.name || s.ContainerName != w.container || s.Port != w.port {
+			t.Errorf("service %d = %+v, want %s %s %d", i, s, w.name, w.container, w.port)
+		}
+	}
+
+	// The project comes from COMPOSE_PROJECT_NAME, or the file's name, normalized like compose does
+	t.Setenv("COMPOSE_PROJECT_NAME", "WabiSaby")
+	services, err = ParseComposeServices(filepath.Join(devkitRoot, "docker", "docker-compose.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := services[1].ContainerName; got != "wabisaby-kafka-1" {
+		t.Errorf("kafka container with COMPOSE_PROJECT_NAME = %q, want wabisaby-kafka-1", got)
+	}
+	services, err = ParseComposeServices(filepath.Join(devkitRoot, "My.Stack", "docker-compose.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := services[0].ContainerName; got != "localstack-kafka-1" {
+		t.Errorf("kafka container with a top-level name = %q, want localstack-kafka-1", got)
+	}
+}
@@ -1,13 +1,18 @@
 package service
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/wabisaby/devkit-dashboard/internal/git"
 	"github.com/wabisaby/devkit-dashboard/internal/model"
 )
 
@@ -63,6 +68,20 @@ func GetProjectDependencies(projectsDir, projectName string) ([]model.Dependency
 		if err != nil {
 			return nil, err
 		}
+	} else if _, err := os.Stat(filepath.Join(projectDir, "Cargo.toml")); err == nil {
+		// Rust (Cargo.toml)
+		var err error
+		all, err = getCargoDependencies(projectDir)
+		if err != nil {
+			return nil, err
+		}
+	} else if hasPythonManifest(projectDir) {
+		// Python (pyproject.toml / requirements.txt)
+		var err error
+		all, err = getPythonDependencies(projectDir)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		return []model.Dependency{}, nil
 	}
@@ -77,6 +96,294 @@ func GetProjectDependencies(projectsDir, projectName string) ([]model.Dependency
 	return filtered, nil
 }
 
+// GetDependencyGraph returns which projects under projectsDir depend on which (edges point from
+// dependent to dependency) and flags dependency cycles. A project whose dependencies can't be read
+// is kept as a node with Error set.
+func GetDependencyGraph(projectsDir string) (*model.DependencyGraph, error) {
+	projectNames := wabisabyProjectNames(projectsDir)
+	names := make([]string, 0, len(projectNames))
+	for name := range projectNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	graph := &model.DependencyGraph{
+		Nodes:  make([]model.DependencyNode, 0, len(names)),
+		Edges:  []model.DependencyEdge{},
+		Cycles: [][]string{},
+	}
+	adjacency := make(map[string][]string)
+	for _, name := range names {
+		node := model.DependencyNode{Name: name}
+		deps, err := GetProjectDependencies(projectsDir, name)
+		if err != nil {
+			node.Error = err.Error()
+		}
+		for _, d := range deps {
+			to := d.Name
+			if idx := strings.LastIndex(to, "/"); idx >= 0 && !projectNames[to] {
+				to = to[idx+1:]
+			}
+			if to == name {
+				continue
+			}
+			graph.Edges = append(graph.Edges, model.DependencyEdge{From: name, To: to, Version: d.Version, Type: d.Type})
+			adjacency[name] = append(adjacency[name], to)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	inCycle := make(map[string]bool)
+	for _, cycle := range dependencyCycles(names, adjacency) {
+		for _, name := range cycle {
+			inCycle[name] = true
+		}
+		graph.Cycles = append(graph.Cycles, cycle)
+	}
+	for i := range graph.Nodes {
+		graph.Nodes[i].InCycle = inCycle[graph.Nodes[i].Name]
+	}
+	// An edge is part of a cycle when both ends are in the same cycle group
+	group := make(map[string]int)
+	for i, cycle := range graph.Cycles {
+		for _, name := range cycle {
+			group[name] = i + 1
+		}
+	}
+	for i, e := range graph.Edges {
+		graph.Edges[i].InCycle = group[e.From] != 0 && group[e.From] == group[e.To]
+	}
+	return graph, nil
+}
+
+// dependencyVersionsTTL bounds how long a CheckDependencyVersions result is reused: a tag can be
+// added at a clone's HEAD without touching anything in its signature
+const dependencyVersionsTTL = time.Minute
+
+// dependencyVersionsCacheEntry holds CheckDependencyVersions mismatches for a go.mod/checkout signature
+type dependencyVersionsCacheEntry struct {
+	signature  string
+	at         time.Time
+	mismatches []model.DepMismatch
+}
+
+var (
+	dependencyVersionsMu    sync.Mutex
+	dependencyVersionsCache = make(map[string]dependencyVersionsCacheEntry)
+)
+
+// CheckDependencyVersions compares, for every Go project under projectsDir, the go.mod versions of
+// sibling Wabi Saby projects with what their local clones have checked out: a tagged version must be
+// a tag at the clone's HEAD and a pseudo-version's commit must be HEAD. Siblings are matched by the
+// module path in their own go.mod (so major-version paths like .../foo/v2 are found). Dependencies
+// replaced by local paths in go.mod are skipped since the clone is used directly. Results are cached
+// until a go.mod or a clone's checkout changes, or for at most dependencyVersionsTTL.
+func CheckDependencyVersions(projectsDir string) ([]model.DepMismatch, error) {
+	projectNames := wabisabyProjectNames(projectsDir)
+	names := make([]string, 0, len(projectNames))
+	modules := make(map[string]string) // module path -> project name
+	for name := range projectNames {
+		names = append(names, name)
+		if module := goModModulePath(filepath.Join(projectsDir, name)); module != "" {
+			modules[module] = name
+		}
+	}
+	sort.Strings(names)
+
+	signature := dependencyVersionsSignature(projectsDir, names)
+	dependencyVersionsMu.Lock()
+	if entry, ok := dependencyVersionsCache[projectsDir]; ok && entry.signature == signature && time.Since(entry.at) < dependencyVersionsTTL {
+		dependencyVersionsMu.Unlock()
+		return append([]model.DepMismatch{}, entry.mismatches...), nil
+	}
+	dependencyVersionsMu.Unlock()
+
+	mismatches := []model.DepMismatch{}
+	for _, name := range names {
+		projectDir := filepath.Join(projectsDir, name)
+		deps, err := parseGoMod(projectDir)
+		if err != nil {
+			continue // not a Go project
+		}
+		replaced := goModLocalReplaces(projectDir)
+		for _, d := range deps {
+			dep, ok := modules[d.Name]
+			if !ok || dep == name || replaced[d.Name] {
+				continue
+			}
+			if m := checkDependencyVersion(filepath.Join(projectsDir, dep), d.Version); m != nil {
+				m.Project, m.Dependency = name, dep
+				mismatches = append(mismatches, *m)
+			}
+		}
+	}
+
+	dependencyVersionsMu.Lock()
+	dependencyVersionsCache[projectsDir] = dependencyVersionsCacheEntry{signature: signature, at: time.Now(), mismatches: mismatches}
+	dependencyVersionsMu.Unlock()
+	return append([]model.DepMismatch{}, mismatches...), nil
+}
+
+// dependencyVersionsSignature fingerprints what CheckDependencyVersions reads: each project's go.mod
+// and, for its clone, HEAD and the index (rewritten by checkouts, commits and pulls)
+func dependencyVersionsSignature(projectsDir string, names []string) string {
+	var sig strings.Builder
+	for _, name := range names {
+		projectDir := filepath.Join(projectsDir, name)
+		gitDir := filepath.Join(projectDir, ".git")
+		if info, err := os.Stat(gitDir); err == nil && !info.IsDir() {
+			gitDir, _ = submoduleGitDir(projectDir)
+		}
+		sig.WriteString(name)
+		for _, p := range []string{filepath.Join(projectDir, "go.mod"), filepath.Join(gitDir, "HEAD"), filepath.Join(gitDir, "index")} {
+			if info, err := os.Stat(p); err == nil {
+				fmt.Fprintf(&sig, "@%d", info.ModTime().UnixNano())
+			} else {
+				sig.WriteString("@-")
+			}
+		}
+		sig.WriteString(";")
+	}
+	return sig.String()
+}
+
+// checkDependencyVersion returns a mismatch (without Project/Dependency) when the clone in depDir
+// isn't at version, or nil when it is or its state can't be read
+func checkDependencyVersion(depDir, version string) *model.DepMismatch {
+	head, err := git.GetFullCommit(depDir)
+	if err != nil {
+		return nil
+	}
+	tags, err := git.HeadTags(depDir)
+	if err != nil {
+		return nil
+	}
+	local := strings.Join(tags, ", ")
+	if local == "" && len(head) >= 7 {
+		local = head[:7]
+	}
+
+	version = strings.TrimSuffix(version, "+incompatible")
+	// Pseudo-versions end in -<timestamp>-<12-char commit hash>
+	if parts := strings.Split(version, "-"); len(parts) >= 3 && len(parts[len(parts)-1]) == 12 {
+		if strings.HasPrefix(head, parts[len(parts)-1]) {
+			return nil
+		}
+		return &model.DepMismatch{Required: version, Local: local,
+			Message: fmt.Sprintf("go.mod requires commit %s but the clone is at %s", parts[len(parts)-1], local)}
+	}
+	for _, tag := range tags {
+		if tag == version {
+			return nil
+		}
+	}
+	return &model.DepMismatch{Required: version, Local: local,
+		Message: fmt.Sprintf("go.mod requires %s but the clone is at %s", version, local)}
+}
+
+// goModModulePath returns the module path declared in dir's go.mod, or "" if there is none
+func goModModulePath(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// goModLocalReplaces returns the module paths that go.mod in dir replaces with a local directory
+func goModLocalReplaces(dir string) map[string]bool {
+	replaced := make(map[string]bool)
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return replaced
+	}
+	inReplace := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "replace (":
+			inReplace = true
+			continue
+		case line == ")":
+			inReplace = false
+			continue
+		case strings.HasPrefix(line, "replace "):
+			line = strings.TrimPrefix(line, "replace ")
+		case !inReplace:
+			continue
+		}
+		from, to, ok := strings.Cut(line, "=>")
+		target := strings.Fields(to)
+		if !ok || len(target) == 0 {
+			continue
+		}
+		if strings.HasPrefix(target[0], ".") || filepath.IsAbs(target[0]) {
+			if fields := strings.Fields(from); len(fields) > 0 {
+				replaced[fields[0]] = true
+			}
+		}
+	}
+	return replaced
+}
+
+// dependencyCycles returns the strongly connected components with more than one project (Tarjan's
+// algorithm), each sorted by name
+func dependencyCycles(names []string, adjacency map[string][]string) [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var visit func(v string)
+	visit = func(v string) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adjacency[v] {
+			if _, seen := index[w]; !seen {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var component []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, name := range names {
+		if _, seen := index[name]; !seen {
+			visit(name)
+		}
+	}
+	return cycles
+}
+
 func getGoDependencies(dir string) ([]model.Dependency, error) {
 	cmd := exec.Command("go", "list", "-m", "-json", "all")
 	cmd.Dir = dir
@@ -199,3 +506,322 @@ func getNodeDependencies(dir string) ([]model.Dependency, error) {
 	}
 	return deps, nil
 }
+
+// hasPythonManifest reports whether dir has a pyproject.toml or requirements.txt
+func hasPythonManifest(dir string) bool {
+	for _, name := range []string{"pyproject.toml", "requirements.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getCargoDependencies reads [dependencies], [dev-dependencies] and [build-dependencies] (including
+// target-specific and [dependencies.<name>] tables) from Cargo.toml
+func getCargoDependencies(dir string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Cargo.toml: %w", err)
+	}
+
+	var deps []model.Dependency
+	for _, e := range parseTOMLEntries(data) {
+		// target.'cfg(unix)'.dependencies is the same as dependencies for our purposes
+		section := e.section
+		if idx := strings.LastIndex(section, "."); idx >= 0 && strings.HasPrefix(section, "target.") {
+			section = section[idx+1:]
+		}
+		kind, name, table := "", e.key, false
+		for prefix, t := range map[string]string{"dependencies": "production", "dev-dependencies": "dev", "build-dependencies": "build"} {
+			if section == prefix {
+				kind = t
+			} else if n, ok := strings.CutPrefix(section, prefix+"."); ok {
+				kind, name, table = t, n, true
+			}
+		}
+		if kind == "" {
+			continue
+		}
+		if table {
+			// [dependencies.foo] with version = "1.0" (or path/git) as separate keys
+			if e.key == "version" || ((e.key == "path" || e.key == "git") && !hasDependency(deps, name)) {
+				deps = setDependency(deps, model.Dependency{Name: name, Version: tomlString(e.value), Type: kind})
+			}
+			continue
+		}
+		deps = setDependency(deps, model.Dependency{Name: name, Version: cargoVersion(e.value), Type: kind})
+	}
+	return deps, nil
+}
+
+// cargoVersion returns the version of a Cargo dependency value: "1.0" or { version = "1.0", ... },
+// falling back to the path or git source for local/git dependencies
+func cargoVersion(value string) string {
+	if !strings.HasPrefix(value, "{") {
+		return tomlString(value)
+	}
+	fields := make(map[string]string)
+	for _, part := range splitTOMLList(strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")) {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			fields[strings.TrimSpace(k)] = tomlString(strings.TrimSpace(v))
+		}
+	}
+	for _, key := range []string{"version", "path", "git"} {
+		if fields[key] != "" {
+			return fields[key]
+		}
+	}
+	return ""
+}
+
+// getPythonDependencies reads PEP 621 ([project] dependencies / optional-dependencies) and Poetry
+// tables from pyproject.toml, plus requirements.txt
+func getPythonDependencies(dir string) ([]model.Dependency, error) {
+	var deps []model.Dependency
+	pyproject, err := os.ReadFile(filepath.Join(dir, "pyproject.toml"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read pyproject.toml: %w", err)
+	}
+	for _, e := range parseTOMLEntries(pyproject) {
+		switch {
+		case e.section == "project" && e.key == "dependencies":
+			for _, req := range splitTOMLArray(e.value) {
+				if d, ok := parsePythonRequirement(req, "production"); ok {
+					deps = setDependency(deps, d)
+				}
+			}
+		case e.section == "project.optional-dependencies":
+			for _, req := range splitTOMLArray(e.value) {
+				if d, ok := parsePythonRequirement(req, "dev"); ok && !hasDependency(deps, d.Name) {
+					deps = setDependency(deps, d)
+				}
+			}
+		case e.section == "tool.poetry.dependencies" && e.key != "python":
+			deps = setDependency(deps, model.Dependency{Name: normalizePythonName(e.key), Version: cargoVersion(e.value), Type: "production"})
+		case e.section == "tool.poetry.dev-dependencies" ||
+			(strings.HasPrefix(e.section, "tool.poetry.group.") && strings.HasSuffix(e.section, ".dependencies")):
+			if name := normalizePythonName(e.key); !hasDependency(deps, name) {
+				deps = setDependency(deps, model.Dependency{Name: name, Version: cargoVersion(e.value), Type: "dev"})
+			}
+		}
+	}
+
+	requirements, err := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read requirements.txt: %w", err)
+	}
+	for _, line := range strings.Split(string(requirements), "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "-") {
+			// -e git+https://...#egg=name is the only option line that names a package
+			if _, egg, ok := strings.Cut(line, "#egg="); ok && (strings.HasPrefix(line, "-e ") || strings.HasPrefix(line, "--editable")) {
+				deps = setDependency(deps, model.Dependency{Name: normalizePythonName(egg), Version: "editable", Type: "production"})
+			}
+			continue
+		}
+		if d, ok := parsePythonRequirement(line, "production"); ok && !hasDependency(deps, d.Name) {
+			deps = setDependency(deps, d)
+		}
+	}
+	return deps, nil
+}
+
+// parsePythonRequirement parses a PEP 508 requirement ("name[extra]>=1.0; marker") into a dependency
+// whose Version is the specifier (or the URL of "name @ url")
+func parsePythonRequirement(req, kind string) (model.Dependency, bool) {
+	req, _, _ = strings.Cut(req, ";")
+	req = strings.TrimSpace(req)
+	end := strings.IndexFunc(req, func(r rune) bool {
+		return !(r == '-' || r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		end = len(req)
+	}
+	if end == 0 {
+		return model.Dependency{}, false
+	}
+	name, rest := req[:end], strings.TrimSpace(req[end:])
+	if strings.HasPrefix(rest, "[") {
+		if idx := strings.Index(rest, "]"); idx >= 0 {
+			rest = strings.TrimSpace(rest[idx+1:])
+		}
+	}
+	rest = strings.TrimSpace(strings.TrimPrefix(rest, "@"))
+	return model.Dependency{Name: normalizePythonName(name), Version: rest, Type: kind}, true
+}
+
+// normalizePythonName returns the PEP 503 normalized form of a package name (my_Pkg.x -> my-pkg-x)
+func normalizePythonName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }), "-")
+}
+
+// hasDependency reports whether deps contains name
+func hasDependency(deps []model.Dependency, name string) bool {
+	for _, d := range deps {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setDependency replaces the dependency with the same name in deps, or appends d
+func setDependency(deps []model.Dependency, d model.Dependency) []model.Dependency {
+	for i := range deps {
+		if deps[i].Name == d.Name {
+			deps[i] = d
+			return deps
+		}
+	}
+	return append(deps, d)
+}
+
+// tomlEntry is a key = value line of a TOML file, with the [table] it appears in
+type tomlEntry struct {
+	section string
+	key     string
+	value   string // raw TOML value; multi-line arrays are joined onto one line
+}
+
+// parseTOMLEntries is a minimal TOML reader for dependency manifests: it returns the key = value
+// pairs of every [table] in order, stripping comments and joining multi-line arrays. [[array
+// tables]] are skipped and quoted keys are unquoted.
+func parseTOMLEntries(data []byte) []tomlEntry {
+	var entries []tomlEntry
+	section := ""
+	skip := false
+	var pending *tomlEntry
+	depth := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if pending != nil {
+			pending.value += " " + line
+			depth += tomlBracketDepth(line)
+			if depth <= 0 {
+				entries = append(entries, *pending)
+				pending = nil
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[[") {
+			skip = true
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[] ")
+			section = strings.NewReplacer(`"`, "", "'", "", " ", "").Replace(section)
+			skip = false
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if skip || !ok {
+			continue
+		}
+		e := tomlEntry{section: section, key: strings.Trim(strings.TrimSpace(key), `"'`), value: strings.TrimSpace(value)}
+		if depth = tomlBracketDepth(e.value); depth > 0 {
+			pending = &e
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// stripTOMLComment removes a # comment that isn't inside a string
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\' && quote == '"':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// tomlBracketDepth returns how many more [ than ] appear outside strings in s
+func tomlBracketDepth(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '[':
+			depth++
+		case quote == 0 && c == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+// splitTOMLList splits s on commas that aren't inside strings or nested brackets/braces
+func splitTOMLList(s string) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+// splitTOMLArray returns the strings of a TOML array of strings
+func splitTOMLArray(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil
+	}
+	var items []string
+	for _, part := range splitTOMLList(value[1 : len(value)-1]) {
+		if item := tomlString(part); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// tomlString unquotes a TOML string value ("..." or '...'); other values are returned trimmed
+func tomlString(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
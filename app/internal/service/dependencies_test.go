@@ -0,0 +1,97 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// gitIn runs git in dir, failing the test on error
+func gitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// newTaggedClone makes projectsDir/name a git repo with go.mod declaring module, tagged tag
+func newTaggedClone(t *testing.T, projectsDir, name, module, tag string) {
+	t.Helper()
+	writeProjectFile(t, projectsDir, name, "go.mod", "module "+module+"\n\ngo 1.22\n")
+	dir := filepath.Join(projectsDir, name)
+	gitIn(t, dir, "init", "-q")
+	gitIn(t, dir, "add", ".")
+	gitIn(t, dir, "commit", "-q", "-m", "init")
+	gitIn(t, dir, "tag", tag)
+}
+
+func TestCheckDependencyVersionsMatchesModulePaths(t *testing.T) {
+	projectsDir := t.TempDir()
+	newTaggedClone(t, projectsDir, "wabisaby-protos", "github.com/wabisaby/wabisaby-protos/v2", "v2.1.0")
+	newTaggedClone(t, projectsDir, "wabisaby-lib", "github.com/wabisaby/lib", "v1.4.0")
+	writeProjectFile(t, projectsDir, "wabisaby-core", "go.mod", `module github.com/wabisaby/wabisaby-core
+
+go 1.22
+
+require (
+	github.com/wabisaby/wabisaby-protos/v2 v2.0.0
+	github.com/wabisaby/lib v1.4.0
+	github.com/other/wabisaby-lib v0.1.0
+)
+`)
+
+	mismatches, err := CheckDependencyVersions(projectsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The /v2 module is found by its go.mod path; lib matches despite the folder name, and the
+	// unrelated module ending in a project name does not
+	if len(mismatches) != 1 {
+		t.Fatalf("mismatches = %+v, want only wabisaby-protos", mismatches)
+	}
+	m := mismatches[0]
+	if m.Project != "wabisaby-core" || m.Dependency != "wabisaby-protos" || m.Required != "v2.0.0" || m.Local != "v2.1.0" {
+		t.Errorf("mismatch = %+v", m)
+	}
+}
+
+func TestCheckDependencyVersionsCache(t *testing.T) {
+	projectsDir := t.TempDir()
+	protosDir := filepath.Join(projectsDir, "wabisaby-protos")
+	newTaggedClone(t, projectsDir, "wabisaby-protos", "github.com/wabisaby/wabisaby-protos", "v1.0.0")
+	gitIn(t, protosDir, "commit", "-q", "--allow-empty", "-m", "next")
+	gitIn(t, protosDir, "tag", "v1.1.0")
+	coreGoMod := func(version string, mtime time.Time) {
+		writeProjectFile(t, projectsDir, "wabisaby-core", "go.mod",
+			"module github.com/wabisaby/wabisaby-core\n\nrequire github.com/wabisaby/wabisaby-protos "+version+"\n")
+		if err := os.Chtimes(filepath.Join(projectsDir, "wabisaby-core", "go.mod"), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(want int) {
+		t.Helper()
+		mismatches, err := CheckDependencyVersions(projectsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mismatches) != want {
+			t.Errorf("mismatches = %+v, want %d", mismatches, want)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	coreGoMod("v1.0.0", start)
+	check(1) // the clone is at v1.1.0
+
+	// Bumping go.mod invalidates the cached result
+	coreGoMod("v1.1.0", start.Add(time.Second))
+	check(0)
+
+	// So does checking out another version in the clone
+	gitIn(t, protosDir, "checkout", "-q", "v1.0.0")
+	check(1)
+}
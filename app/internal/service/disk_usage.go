@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// diskUsageCache holds the last ProjectSize result per project dir. Entries are dropped when the
+// project is cloned, updated, unshallowed, switched to another branch or has its artifacts cleaned;
+// other changes (builds, edits) show up on the next ProjectSize with refresh.
+var diskUsageCache = struct {
+	sync.Mutex
+	sizes map[string]model.ProjectSize
+}{sizes: make(map[string]model.ProjectSize)}
+
+// ProjectDiskUsage walks projectDir once and returns the total size in bytes of its regular files
+// and, of that, the size under its top-level .git directory. Symlinks are not followed.
+func ProjectDiskUsage(projectDir string) (total, gitBytes int64, err error) {
+	gitPrefix := filepath.Join(projectDir, ".git") + string(filepath.Separator)
+	err = filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == projectDir {
+				return err
+			}
+			return nil // unreadable entry: count what we can
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		if strings.HasPrefix(path, gitPrefix) {
+			gitBytes += info.Size()
+		}
+		return nil
+	})
+	return total, gitBytes, err
+}
+
+// submoduleGitDir returns the git dir a ".git" file points to ("gitdir: <path>", relative to the
+// project), as git writes for submodules and worktrees
+func submoduleGitDir(projectDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".git"))
+	if err != nil {
+		return "", err
+	}
+	dir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("%s/.git is not a gitdir file", projectDir)
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectDir, dir)
+	}
+	return dir, nil
+}
+
+// ProjectSize returns the disk usage of a cloned project (working tree and .git), from the cache
+// unless refresh is set or nothing is cached yet
+func ProjectSize(projectsDir, projectName string, refresh bool) (model.ProjectSize, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if !refresh {
+		if size, ok := cachedProjectSize(projectDir); ok {
+			return size, nil
+		}
+	}
+	if _, err := os.Stat(projectDir); err != nil {
+		return model.ProjectSize{}, err
+	}
+
+	total, gitBytes, err := ProjectDiskUsage(projectDir)
+	if err != nil {
+		return model.ProjectSize{}, err
+	}
+	size := model.ProjectSize{
+		Name:       projectName,
+		SizeBytes:  total,
+		GitBytes:   gitBytes,
+		MeasuredAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	// A submodule's .git is a file pointing at its git dir (usually under the superproject's .git/modules)
+	if info, err := os.Lstat(filepath.Join(projectDir, ".git")); err == nil && info.Mode().IsRegular() {
+		size.GitBytes = 0
+		gitDir, err := submoduleGitDir(projectDir)
+		if err == nil {
+			size.GitBytes, _, err = ProjectDiskUsage(gitDir)
+		}
+		if err != nil {
+			size.GitUnavailable = true
+		}
+		size.SizeBytes = total - info.Size() + size.GitBytes
+	}
+
+	diskUsageCache.Lock()
+	diskUsageCache.sizes[filepath.Clean(projectDir)] = size
+	diskUsageCache.Unlock()
+	return size, nil
+}
+
+// cachedProjectSize returns the cached size of projectDir, if measured since its last invalidation
+func cachedProjectSize(projectDir string) (model.ProjectSize, bool) {
+	diskUsageCache.Lock()
+	defer diskUsageCache.Unlock()
+	size, ok := diskUsageCache.sizes[filepath.Clean(projectDir)]
+	return size, ok
+}
+
+// invalidateDiskUsage drops the cached size of projectDir
+func invalidateDiskUsage(projectDir string) {
+	diskUsageCache.Lock()
+	delete(diskUsageCache.sizes, filepath.Clean(projectDir))
+	diskUsageCache.Unlock()
+}
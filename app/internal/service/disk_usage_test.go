@@ -0,0 +1,54 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectSize(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "main.go", "0123456789")  // 10
+	writeProjectFile(t, projectsDir, "core", "docs/readme", "01234")   // 5
+	writeProjectFile(t, projectsDir, "core", ".git/HEAD", "0123456")   // 7
+	writeProjectFile(t, projectsDir, "core", ".git/objects/ab", "012") // 3
+	writeProjectFile(t, projectsDir, "core", ".github/ci.yml", "01")   // 2, not git data
+
+	size, err := ProjectSize(projectsDir, "core", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size.SizeBytes != 27 || size.GitBytes != 10 || size.GitUnavailable {
+		t.Errorf("size = %+v, want 27 bytes of which 10 git", size)
+	}
+}
+
+func TestProjectSizeSubmoduleGitDir(t *testing.T) {
+	root := t.TempDir()
+	projectsDir := filepath.Join(root, "projects")
+	gitdir := "gitdir: ../../.git/modules/core\n"
+	writeProjectFile(t, projectsDir, "core", "main.go", "0123456789")
+	writeProjectFile(t, projectsDir, "core", ".git", gitdir)
+	writeProjectFile(t, root, ".git/modules/core", "HEAD", "0123456")
+	writeProjectFile(t, root, ".git/modules/core", "objects/ab", "012")
+
+	size, err := ProjectSize(projectsDir, "core", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size.SizeBytes != 20 || size.GitBytes != 10 || size.GitUnavailable {
+		t.Errorf("size = %+v, want 20 bytes of which 10 in the resolved git dir", size)
+	}
+
+	// A gitdir that doesn't exist is reported as unavailable rather than as a tiny .git
+	if err := os.RemoveAll(filepath.Join(root, ".git")); err != nil {
+		t.Fatal(err)
+	}
+	size, err = ProjectSize(projectsDir, "core", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !size.GitUnavailable || size.GitBytes != 0 || size.SizeBytes != 10 {
+		t.Errorf("size with a missing git dir = %+v, want the working tree only, git unavailable", size)
+	}
+}
@@ -1,38 +1,101 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/ansi"
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+)
+
+var (
+	composeOnce sync.Once
+	composeArgv []string
 )
 
+// composeCommand returns the compose invocation to use: "docker compose" (v2 plugin) when it works,
+// else the legacy standalone "docker-compose". Detected once per run.
+func composeCommand() []string {
+	composeOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dockerInfoTimeout)
+		defer cancel()
+		if exec.CommandContext(ctx, "docker", "compose", "version").Run() == nil {
+			composeArgv = []string{"docker", "compose"}
+		} else {
+			composeArgv = []string{"docker-compose"}
+		}
+	})
+	return composeArgv
+}
+
+// ComposeCmd builds a compose command (docker compose or docker-compose, see composeCommand) with args
+func ComposeCmd(ctx context.Context, args ...string) *exec.Cmd {
+	argv := composeCommand()
+	return exec.CommandContext(ctx, argv[0], append(argv[1:len(argv):len(argv)], args...)...)
+}
+
+// dockerInfoTimeout bounds the docker info probe in DockerAvailable
+const dockerInfoTimeout = 5 * time.Second
+
+// ErrDockerUnavailable is wrapped by RequireDocker's error when the Docker daemon can't be used
+var ErrDockerUnavailable = errors.New("docker unavailable")
+
+// DockerAvailable runs docker info (with a short timeout) and reports whether the daemon is
+// reachable; when it isn't, reason says why (not installed, not running, permission denied).
+func DockerAvailable() (bool, string) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return false, "Docker is not installed"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerInfoTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "docker", "info").CombinedOutput()
+	if err == nil {
+		return true, ""
+	}
+	if ctx.Err() != nil {
+		return false, "Docker daemon did not respond"
+	}
+	out := strings.ToLower(string(output))
+	switch {
+	case strings.Contains(out, "permission denied"):
+		return false, "Permission denied connecting to the Docker daemon (is your user in the docker group?)"
+	case strings.Contains(out, "cannot connect"), strings.Contains(out, "is the docker daemon running"),
+		strings.Contains(out, "error during connect"):
+		return false, "Docker Desktop is not running"
+	}
+	return false, "Docker daemon is not reachable: " + strings.TrimSpace(string(output))
+}
+
+// RequireDocker returns an error wrapping ErrDockerUnavailable, with DockerAvailable's reason,
+// when the daemon can't be reached. Called before compose commands so they fail with a clear message.
+func RequireDocker() error {
+	if ok, reason := DockerAvailable(); !ok {
+		return fmt.Errorf("%w: %s", ErrDockerUnavailable, reason)
+	}
+	return nil
+}
+
 // IsDockerConnected returns true if the Docker daemon is running and accessible.
 func IsDockerConnected() bool {
-	cmd := exec.Command("docker", "info")
-	if err := cmd.Run(); err != nil {
-		return false
-	}
-	return true
+	ok, _ := DockerAvailable()
+	return ok
 }
 
 // CheckServiceStatus checks if a Docker service is running
 func CheckServiceStatus(name string, port int, devkitRoot string) string {
-	// Map service names to Docker container names
-	containerMap := map[string]string{
-		"PostgreSQL":     "wabisaby-postgres",
-		"Redis":          "wabisaby-redis",
-		"RedisCommander": "wabisaby-redis-commander",
-		"MinIO":          "wabisaby-minio",
-		"Vault":          "wabisaby-vault",
-		"pgAdmin":        "wabisaby-pgadmin",
-		"Keycloak":       "wabisaby-keycloak",
-	}
-
-	containerName, ok := containerMap[name]
-	if !ok {
+	svc := config.GetDockerServiceByName(name)
+	if svc == nil || svc.ContainerName == "" {
 		return "unknown"
 	}
+	containerName := svc.ContainerName
 
 	// Check if container is running
 	cmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.Status}}")
@@ -47,35 +110,40 @@ func CheckServiceStatus(name string, port int, devkitRoot string) string {
 	return "stopped"
 }
 
-// StartService starts a Docker service
-func StartService(name string, devkitRoot string) error {
-	serviceMap := map[string]string{
-		"PostgreSQL":     "postgres",
-		"Redis":          "redis",
-		"RedisCommander": "redis-commander",
-		"MinIO":          "minio",
-		"Vault":          "vault",
-		"pgAdmin":        "pgadmin",
-		"Keycloak":       "keycloak",
+// CheckServiceHealth returns the container's healthcheck status ("healthy", "unhealthy" or "starting").
+// Containers without a healthcheck report status as given (e.g. "running"/"stopped" from CheckServiceStatus).
+func CheckServiceHealth(name, status string) string {
+	svc := config.GetDockerServiceByName(name)
+	if svc == nil || svc.ContainerName == "" || status != "running" {
+		return status
+	}
+	cmd := exec.Command("docker", "inspect", "--format", "{{if .State.Health}}{{.State.Health.Status}}{{end}}", svc.ContainerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return status
+	}
+	if health := strings.TrimSpace(string(output)); health != "" {
+		return health
 	}
+	return status
+}
 
-	composeServiceName, ok := serviceMap[name]
-	if !ok {
-		composeServiceName = strings.ToLower(name)
+// StartService starts a Docker service
+func StartService(name string, devkitRoot string) error {
+	if err := RequireDocker(); err != nil {
+		return err
 	}
+	composeServiceName, companions := composeServiceFor(name)
 
 	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
-	cmd := exec.Command("docker-compose", "-f", composeFile, "up", "-d", composeServiceName)
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "up", "-d", composeServiceName)
 	if err := cmd.Run(); err != nil {
 		return err
 	}
 
 	// Ensure companion UIs are started alongside base services.
-	if name == "PostgreSQL" {
-		_ = exec.Command("docker-compose", "-f", composeFile, "up", "-d", "pgadmin").Run()
-	}
-	if name == "Redis" {
-		_ = exec.Command("docker-compose", "-f", composeFile, "up", "-d", "redis-commander").Run()
+	for _, companion := range companions {
+		_ = ComposeCmd(context.Background(), "-f", composeFile, "up", "-d", companion).Run()
 	}
 
 	return nil
@@ -83,48 +151,141 @@ func StartService(name string, devkitRoot string) error {
 
 // StopService stops a Docker service
 func StopService(name string, devkitRoot string) error {
-	serviceMap := map[string]string{
-		"PostgreSQL":     "postgres",
-		"Redis":          "redis",
-		"RedisCommander": "redis-commander",
-		"MinIO":          "minio",
-		"Vault":          "vault",
-		"pgAdmin":        "pgadmin",
-		"Keycloak":       "keycloak",
-	}
-
-	composeServiceName, ok := serviceMap[name]
-	if !ok {
-		composeServiceName = strings.ToLower(name)
+	if err := RequireDocker(); err != nil {
+		return err
 	}
+	composeServiceName, companions := composeServiceFor(name)
 
 	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
-	cmd := exec.Command("docker-compose", "-f", composeFile, "stop", composeServiceName)
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "stop", composeServiceName)
 	if err := cmd.Run(); err != nil {
 		return err
 	}
 
 	// Stop companion UIs when base services are stopped.
-	if name == "PostgreSQL" {
-		_ = exec.Command("docker-compose", "-f", composeFile, "stop", "pgadmin").Run()
+	for _, companion := range companions {
+		_ = ComposeCmd(context.Background(), "-f", composeFile, "stop", companion).Run()
 	}
-	if name == "Redis" {
-		_ = exec.Command("docker-compose", "-f", composeFile, "stop", "redis-commander").Run()
+
+	return nil
+}
+
+// RestartService restarts a Docker service and its companion UIs
+func RestartService(name string, devkitRoot string) error {
+	if err := RequireDocker(); err != nil {
+		return err
+	}
+	composeServiceName, companions := composeServiceFor(name)
+
+	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "restart", composeServiceName)
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	// Companions talk to the base service, so restart them with it.
+	for _, companion := range companions {
+		_ = ComposeCmd(context.Background(), "-f", composeFile, "restart", companion).Run()
 	}
 
 	return nil
 }
 
+// ComposeServiceName returns the docker-compose service name for a dashboard service name
+func ComposeServiceName(name string) string {
+	composeServiceName, _ := composeServiceFor(name)
+	return composeServiceName
+}
+
+// ComposeLogSource splits a `compose logs` line ("postgres-1  | message") into the dashboard service
+// it came from and the message. The prefix (container or compose service name, possibly colored) is
+// mapped to a configured service name when it matches one; lines without a prefix return source "".
+func ComposeLogSource(line string) (source, text string) {
+	prefix, text, ok := strings.Cut(line, "|")
+	if !ok {
+		return "", line
+	}
+	prefix = strings.TrimSpace(ansi.Strip(prefix))
+	text = strings.TrimPrefix(text, " ")
+	// Without container_name compose names containers "<project>-<service>-<replica>" (v1: "_")
+	base := prefix
+	if i := strings.LastIndexAny(base, "-_"); i > 0 {
+		if _, err := strconv.Atoi(base[i+1:]); err == nil {
+			base = base[:i]
+		}
+	}
+	for _, svc := range config.GetDockerServices() {
+		compose := svc.ComposeService()
+		if prefix == svc.ContainerName || base == compose ||
+			strings.HasSuffix(base, "-"+compose) || strings.HasSuffix(base, "_"+compose) {
+			return svc.Name, text
+		}
+	}
+	return prefix, text
+}
+
+// composeServiceFor returns the compose service and its companions for a dashboard service name.
+// Unknown names fall back to the lowercased name with no companions.
+func composeServiceFor(name string) (string, []string) {
+	if svc := config.GetDockerServiceByName(name); svc != nil {
+		return svc.ComposeService(), svc.Companions
+	}
+	return strings.ToLower(name), nil
+}
+
 // StartAllServices starts all Docker services
 func StartAllServices(devkitRoot string) error {
+	if err := RequireDocker(); err != nil {
+		return err
+	}
 	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
-	cmd := exec.Command("docker-compose", "-f", composeFile, "up", "-d")
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "up", "-d")
 	return cmd.Run()
 }
 
 // StopAllServices stops all Docker services
 func StopAllServices(devkitRoot string) error {
+	if err := RequireDocker(); err != nil {
+		return err
+	}
 	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
-	cmd := exec.Command("docker-compose", "-f", composeFile, "down")
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "down")
 	return cmd.Run()
 }
+
+// RestartAllServices restarts all running Docker services
+func RestartAllServices(devkitRoot string) error {
+	if err := RequireDocker(); err != nil {
+		return err
+	}
+	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
+	cmd := ComposeCmd(context.Background(), "-f", composeFile, "restart")
+	return cmd.Run()
+}
+
+// WaitForServiceHealthy polls until the service's container reports healthy (or running, for
+// containers without a healthcheck) or timeout expires.
+func WaitForServiceHealthy(name string, timeout time.Duration) error {
+	svc := config.GetDockerServiceByName(name)
+	if svc == nil || svc.ContainerName == "" {
+		return fmt.Errorf("unknown service: %s", name)
+	}
+	containerName := svc.ContainerName
+	deadline := time.Now().Add(timeout)
+	for {
+		cmd := exec.Command("docker", "inspect", "--format", "{{.State.Status}} {{if .State.Health}}{{.State.Health.Status}}{{end}}", containerName)
+		if output, err := cmd.Output(); err == nil {
+			fields := strings.Fields(string(output))
+			if len(fields) == 1 && fields[0] == "running" {
+				return nil
+			}
+			if len(fields) == 2 && fields[0] == "running" && fields[1] == "healthy" {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s not healthy after %s", name, timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// dockerStatsLine is the `docker stats --format '{{json .}}'` output for one container
+type dockerStatsLine struct {
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`  // "0.52%"
+	MemUsage string `json:"MemUsage"` // "21.3MiB / 7.66GiB"
+	MemPerc  string `json:"MemPerc"`  // "0.27%"
+	NetIO    string `json:"NetIO"`    // "1.45kB / 0B"
+}
+
+// sizeUnits are the multipliers for docker's human-readable sizes (decimal for kB/MB, binary for KiB/MiB)
+var sizeUnits = map[string]float64{
+	"B":   1,
+	"kB":  1e3,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// ContainerStats returns a one-shot resource usage snapshot for a running container.
+// Fails with a "not running" error for stopped or missing containers.
+func ContainerStats(containerName string) (*model.ContainerStats, error) {
+	running, err := exec.Command("docker", "inspect", "--format", "{{.State.Running}}", containerName).Output()
+	if err != nil || strings.TrimSpace(string(running)) != "true" {
+		return nil, fmt.Errorf("container %s is not running", containerName)
+	}
+
+	output, err := exec.Command("docker", "stats", "--no-stream", "--format", "{{json .}}", containerName).Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker stats: %w", err)
+	}
+	var line dockerStatsLine
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(output))), &line); err != nil {
+		return nil, fmt.Errorf("invalid docker stats output: %w", err)
+	}
+
+	stats := &model.ContainerStats{
+		Container:  containerName,
+		CPUPercent: parsePercent(line.CPUPerc),
+		MemPercent: parsePercent(line.MemPerc),
+	}
+	stats.MemUsageBytes, stats.MemLimitBytes = parseSizePair(line.MemUsage)
+	stats.NetRxBytes, stats.NetTxBytes = parseSizePair(line.NetIO)
+	return stats, nil
+}
+
+// parsePercent parses "12.34%" (0 when unparseable, e.g. "--" while a container starts)
+func parsePercent(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	return v
+}
+
+// parseSizePair parses docker's "<a> / <b>" size pairs, e.g. "21.3MiB / 7.66GiB"
+func parseSizePair(s string) (int64, int64) {
+	a, b, _ := strings.Cut(s, "/")
+	return parseSize(a), parseSize(b)
+}
+
+// parseSize parses a docker size like "1.45kB" or "21.3MiB" into bytes (0 when unparseable)
+func parseSize(s string) int64 {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0
+	}
+	unit, ok := sizeUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0
+	}
+	return int64(v * unit)
+}
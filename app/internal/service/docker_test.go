@@ -0,0 +1,164 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+)
+
+// fakeDockerScript stands in for docker (and docker-compose): every call is appended to $DOCKER_LOG,
+// "docker ps" lists a container only when its name filter matches $DOCKER_RUNNING, and
+// "compose config" prints $DOCKER_COMPOSE_CONFIG
+const fakeDockerScript = `#!/bin/sh
+echo "$(basename "$0") $*" >> "$DOCKER_LOG"
+if [ "$1" = "ps" ]; then
+	case "$3" in
+	"name=$DOCKER_RUNNING") echo "Up 2 minutes" ;;
+	esac
+fi
+case " $* " in
+*" config --format json "*) cat "$DOCKER_COMPOSE_CONFIG" ;;
+esac
+exit 0
+`
+
+// useFakeDocker puts fakeDockerScript on PATH and returns the file its calls are logged to
+func useFakeDocker(t *testing.T, running string) string {
+	t.Helper()
+	bin := t.TempDir()
+	for _, name := range []string{"docker", "docker-compose"} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(fakeDockerScript), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log := filepath.Join(t.TempDir(), "docker.log")
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("DOCKER_LOG", log)
+	t.Setenv("DOCKER_RUNNING", running)
+	return log
+}
+
+// loadTestDockerServices loads services.json into the service config, restoring the defaults afterwards
+func loadTestDockerServices(t *testing.T, devkitRoot, servicesJSON string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(devkitRoot, "docker"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(devkitRoot, "docker", "services.json"), []byte(servicesJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.LoadDockerServices(devkitRoot); err != nil {
+		t.Fatal(err)
+	}
+	empty := t.TempDir()
+	t.Cleanup(func() { config.LoadDockerServices(empty) })
+}
+
+func TestConfiguredDockerServiceFlowsThrough(t *testing.T) {
+	devkitRoot := t.TempDir()
+	loadTestDockerServices(t, devkitRoot, `[
+		{"name": "Kafka", "composeName": "kafka", "containerName": "wabisaby-kafka", "port": 9092, "companions": ["kafka-ui"]}
+	]`)
+	dockerLog := useFakeDocker(t, "wabisaby-kafka")
+
+	// Listing: appended after the built-in services
+	services := config.GetDockerServices()
+	if last := services[len(services)-1]; last.Name != "Kafka" || last.Port != 9092 {
+		t.Fatalf("last configured service = %+v, want Kafka on 9092", last)
+	}
+	if svc := config.GetDockerServiceByName("PostgreSQL"); svc == nil {
+		t.Fatal("built-in PostgreSQL service lost when loading services.json")
+	}
+
+	// Status: looked up by the configured container name
+	if status := CheckServiceStatus("Kafka", 9092, devkitRoot); status != "running" {
+		t.Errorf("CheckServiceStatus(Kafka) = %q, want running", status)
+	}
+	if status := CheckServiceStatus("Redis", 6379, devkitRoot); status != "stopped" {
+		t.Errorf("CheckServiceStatus(Redis) = %q, want stopped", status)
+	}
+
+	// Actions: routed to the configured compose service and its companions
+	if err := StartService("Kafka", devkitRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := StopService("Kafka", devkitRoot); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(dockerLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := string(data)
+	composeFile := filepath.Join(devkitRoot, "docker/docker-compose.yml")
+	for _, want := range []string{
+		"-f " + composeFile + " up -d kafka\n",
+		"-f " + composeFile + " up -d kafka-ui\n",
+		"-f " + composeFile + " stop kafka\n",
+		"-f " + composeFile + " stop kafka-ui\n",
+	} {
+		if !strings.Contains(calls, want) {
+			t.Errorf("docker calls missing %q:\n%s", strings.TrimSpace(want), calls)
+		}
+	}
+
+	// Log stream: compose prefixes map back to the display name
+	for _, line := range []string{"kafka-1  | started", "wabisaby-kafka | started", "docker-kafka-1 | started"} {
+		if source, text := ComposeLogSource(line); source != "Kafka" || text != "started" {
+			t.Errorf("ComposeLogSource(%q) = %q, %q; want Kafka, started", line, source, text)
+		}
+	}
+}
+
+func TestConfiguredDockerServiceOverridesBuiltIn(t *testing.T) {
+	devkitRoot := t.TempDir()
+	loadTestDockerServices(t, devkitRoot, `[
+		{"name": "Redis", "composeName": "cache", "containerName": "local-cache", "port": 6380}
+	]`)
+	dockerLog := useFakeDocker(t, "local-cache")
+
+	svc := config.GetDockerServiceByName("Redis")
+	if svc == nil || svc.ComposeService() != "cache" || svc.Port != 6380 || len(svc.Companions) != 0 {
+		t.Fatalf("Redis = %+v, want the services.json definition", svc)
+	}
+	if status := CheckServiceStatus("Redis", 6380, devkitRoot); status != "running" {
+		t.Errorf("CheckServiceStatus(Redis) = %q, want running", status)
+	}
+	if err := RestartService("Redis", devkitRoot); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(dockerLog)
+	if !strings.Contains(string(data), " restart cache\n") || strings.Contains(string(data), "redis-commander") {
+		t.Errorf("restart calls = %s, want only the overridden compose service", data)
+	}
+}
+
+func TestResolveServicePortsFollowsOverride(t *testing.T) {
+	devkitRoot := t.TempDir()
+	writeProjectFile(t, devkitRoot, "docker", "docker-compose.yml", "services: {}\n")
+	writeProjectFile(t, devkitRoot, "docker", "docker-compose.override.yml", "services: {}\n")
+	writeProjectFile(t, devkitRoot, "docker", "config.json", mergedComposeConfig)
+	useFakeDocker(t, "")
+	t.Setenv("DOCKER_COMPOSE_CONFIG", filepath.Join(devkitRoot, "docker", "config.json"))
+
+	ports, err := ResolveServicePorts(devkitRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pgAdmin publishes container port 80 (ContainerPort), MinIO's main port is unchanged
+	want := map[string]int{"PostgreSQL": 15432, "MinIO": 9000, "pgAdmin": 5050}
+	if !reflect.DeepEqual(ports, want) {
+		t.Errorf("ResolveServicePorts() = %v, want %v", ports, want)
+	}
+
+	minio := config.GetDockerServiceByName("MinIO")
+	if url := ResolveServiceURL(devkitRoot, *minio); url != "http://localhost:19001" {
+		t.Errorf("MinIO console URL = %q, want the remapped http://localhost:19001", url)
+	}
+}
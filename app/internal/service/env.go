@@ -3,17 +3,23 @@ package service
 import (
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/wabisaby/devkit-dashboard/internal/config"
+	"github.com/wabisaby/devkit-dashboard/internal/dotenv"
 	"github.com/wabisaby/devkit-dashboard/internal/model"
 )
 
 // EnvService manages .env configuration
 type EnvService struct {
 	wabisabyRoot string
+	mu           sync.Mutex // serializes read-modify-write cycles on .env
 }
 
 // NewEnvService creates a new environment service
@@ -23,8 +29,9 @@ func NewEnvService(wabisabyRoot string) *EnvService {
 	}
 }
 
-// GetStatus returns the current environment configuration status
-func (s *EnvService) GetStatus() (*model.EnvStatus, error) {
+// GetStatus returns the current environment configuration status. Values of sensitive vars
+// (config.IsSensitiveVar) are masked unless reveal is set.
+func (s *EnvService) GetStatus(reveal bool) (*model.EnvStatus, error) {
 	status := &model.EnvStatus{
 		RequiredVars: []model.EnvVar{},
 		OptionalVars: []model.EnvVar{},
@@ -90,11 +97,59 @@ func (s *EnvService) GetStatus() (*model.EnvStatus, error) {
 		}
 	}
 
+	if !reveal {
+		for _, vars := range [][]model.EnvVar{status.RequiredVars, status.OptionalVars, status.CustomVars} {
+			for i := range vars {
+				if vars[i].Sensitive {
+					vars[i].Value = MaskEnvValue(vars[i].Value)
+				}
+			}
+		}
+	}
+
 	return status, nil
 }
 
+// MaskEnvValue hides a secret value, keeping the last 4 characters of long values so they can
+// still be told apart ("****wxyz"). Empty values stay empty so unset vars remain recognisable.
+func MaskEnvValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) < 12 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
+
+// MaskSensitiveEnv replaces the values of sensitive NAME=value entries (see config.IsSensitiveVar)
+// with "********" in place; empty values are left as they are. Returns env.
+func MaskSensitiveEnv(env []string) []string {
+	for i, e := range env {
+		name, value, _ := strings.Cut(e, "=")
+		if value != "" && config.IsSensitiveVar(name) {
+			env[i] = name + "=********"
+		}
+	}
+	return env
+}
+
+// RevealVar returns the unmasked value of a var in .env
+func (s *EnvService) RevealVar(name string) (string, error) {
+	vars, err := s.parseEnvFileValues(filepath.Join(s.wabisabyRoot, ".env"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read .env: %w", err)
+	}
+	value, ok := vars[strings.TrimSpace(name)]
+	if !ok {
+		return "", fmt.Errorf("variable %s not found in .env", name)
+	}
+	return value, nil
+}
+
 // UpdateVar updates or adds an environment variable in the .env file.
-// If the variable exists, its value is replaced in-place preserving file structure.
+// If the variable exists, its value is replaced in-place preserving file structure
+// (export prefix, quoting and inline comment included).
 // If the variable does not exist, it is appended to the end.
 func (s *EnvService) UpdateVar(name, value string) error {
 	name = strings.TrimSpace(name)
@@ -102,11 +157,16 @@ func (s *EnvService) UpdateVar(name, value string) error {
 		return fmt.Errorf("variable name cannot be empty")
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	envPath := filepath.Join(s.wabisabyRoot, ".env")
 
 	// If .env doesn't exist, create it with just this variable
 	if _, err := os.Stat(envPath); err != nil {
-		return os.WriteFile(envPath, []byte(name+"="+value+"\n"), 0644)
+		f := dotenv.Parse([]byte("\n"))
+		f.Set(name, value)
+		return writeFileAtomic(envPath, f.Bytes())
 	}
 
 	data, err := os.ReadFile(envPath)
@@ -114,33 +174,13 @@ func (s *EnvService) UpdateVar(name, value string) error {
 		return fmt.Errorf("failed to read .env: %w", err)
 	}
 
-	lines := strings.Split(string(data), "\n")
-	found := false
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-		parts := strings.SplitN(trimmed, "=", 2)
-		if len(parts) >= 1 && strings.TrimSpace(parts[0]) == name {
-			lines[i] = name + "=" + value
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		// Append to end, ensuring there's a newline before if needed
-		if len(lines) > 0 && lines[len(lines)-1] == "" {
-			// File already ends with newline, insert before trailing empty
-			lines = append(lines[:len(lines)-1], name+"="+value, "")
-		} else {
-			lines = append(lines, name+"="+value)
-		}
+	f := dotenv.Parse(data)
+	// GetEnvStatus hands out masked sensitive values; refuse to write one back over the real secret
+	if current, ok := f.Get(name); ok && config.IsSensitiveVar(name) && value != current && value == MaskEnvValue(current) {
+		return fmt.Errorf("refusing to save the masked value of %s; reveal it first", name)
 	}
-
-	output := strings.Join(lines, "\n")
-	return os.WriteFile(envPath, []byte(output), 0644)
+	f.Set(name, value)
+	return writeFileAtomic(envPath, f.Bytes())
 }
 
 // DeleteVar removes an environment variable from the .env file.
@@ -150,6 +190,9 @@ func (s *EnvService) DeleteVar(name string) error {
 		return fmt.Errorf("variable name cannot be empty")
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	envPath := filepath.Join(s.wabisabyRoot, ".env")
 
 	data, err := os.ReadFile(envPath)
@@ -157,32 +200,51 @@ func (s *EnvService) DeleteVar(name string) error {
 		return fmt.Errorf("failed to read .env: %w", err)
 	}
 
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	found := false
+	f := dotenv.Parse(data)
+	if !f.Delete(name) {
+		return fmt.Errorf("variable %s not found in .env", name)
+	}
+	return writeFileAtomic(envPath, f.Bytes())
+}
 
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
-			parts := strings.SplitN(trimmed, "=", 2)
-			if len(parts) >= 1 && strings.TrimSpace(parts[0]) == name {
-				found = true
-				continue // skip this line
-			}
-		}
-		result = append(result, line)
+// writeFileAtomic replaces path with data by writing a temp file in the same directory and renaming
+// it into place, so a crash mid-write never leaves a truncated file. The existing file's mode is
+// kept (0644 for new files).
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
 	}
 
-	if !found {
-		return fmt.Errorf("variable %s not found in .env", name)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
 	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once renamed
 
-	output := strings.Join(result, "\n")
-	return os.WriteFile(envPath, []byte(output), 0644)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
 }
 
 // CopyExample copies env.example to .env
 func (s *EnvService) CopyExample() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	examplePath := filepath.Join(s.wabisabyRoot, "env.example")
 	envPath := filepath.Join(s.wabisabyRoot, ".env")
 
@@ -216,30 +278,147 @@ func (s *EnvService) CopyExample() error {
 	return nil
 }
 
-// Validate checks if all required environment variables are set
-func (s *EnvService) Validate() ([]string, error) {
+// Validate checks that all required environment variables are set and that set values match
+// their declared types (config.EnvVarSchema). Returns the missing required vars and the invalid values.
+func (s *EnvService) Validate() (missing []string, invalid []model.EnvValidationError, err error) {
 	envPath := filepath.Join(s.wabisabyRoot, ".env")
 
 	// Check if .env exists
 	if _, err := os.Stat(envPath); err != nil {
-		return config.RequiredEnvVars(), fmt.Errorf(".env file not found")
+		return config.RequiredEnvVars(), nil, fmt.Errorf(".env file not found")
 	}
 
 	// Parse .env
 	vars, err := s.parseEnvFileValues(envPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse .env: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse .env: %w", err)
 	}
 
 	// Check required vars
-	var missing []string
 	for _, name := range config.RequiredEnvVars() {
 		if val, ok := vars[name]; !ok || val == "" {
 			missing = append(missing, name)
 		}
 	}
 
-	return missing, nil
+	// Check typed values (empty values are left to the required check)
+	schema := config.EnvVarSchema()
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := vars[name]
+		if value == "" {
+			continue
+		}
+		if problem := checkEnvValue(schema[name], value); problem != "" {
+			shown := name + "=" + value
+			if config.IsSensitiveVar(name) {
+				shown = name
+			}
+			invalid = append(invalid, model.EnvValidationError{Name: name, Reason: shown + " " + problem})
+		}
+	}
+
+	return missing, invalid, nil
+}
+
+// checkEnvValue returns why value doesn't satisfy spec ("is not a valid integer"), or "" if it does
+func checkEnvValue(spec config.EnvVarSpec, value string) string {
+	switch spec.Kind {
+	case config.EnvKindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "is not a valid integer"
+		}
+		if (spec.Min != 0 || spec.Max != 0) && (n < spec.Min || n > spec.Max) {
+			return fmt.Sprintf("is out of range (%d-%d)", spec.Min, spec.Max)
+		}
+	case config.EnvKindPort:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "is not a valid integer"
+		}
+		if n < 1 || n > 65535 {
+			return "is not a valid port (1-65535)"
+		}
+	case config.EnvKindBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "is not a valid boolean (true/false)"
+		}
+	case config.EnvKindURL:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return "is not a valid URL"
+		}
+		if len(spec.Schemes) > 0 && !containsFold(spec.Schemes, u.Scheme) {
+			return fmt.Sprintf("must use scheme %s", strings.Join(spec.Schemes, " or "))
+		}
+	case config.EnvKindEnum:
+		if !containsFold(spec.Values, value) {
+			return fmt.Sprintf("must be one of %s", strings.Join(spec.Values, ", "))
+		}
+	}
+	return ""
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffExample compares variable names in env.example and .env: added are in env.example but
+// missing from .env, removed are in .env but not in env.example. Both lists are sorted.
+func (s *EnvService) DiffExample() (added, removed []string, err error) {
+	example, err := s.parseEnvFileValues(filepath.Join(s.wabisabyRoot, "env.example"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read env.example: %w", err)
+	}
+	env, err := s.parseEnvFileValues(filepath.Join(s.wabisabyRoot, ".env"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+
+	added, removed = []string{}, []string{}
+	for name := range example {
+		if _, ok := env[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for name := range env {
+		if _, ok := example[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed, nil
+}
+
+// SyncMissing appends the variables in env.example that are missing from .env, with their
+// example values, and returns their names
+func (s *EnvService) SyncMissing() ([]string, error) {
+	added, _, err := s.DiffExample()
+	if err != nil {
+		return nil, err
+	}
+	example, err := s.parseEnvFileValues(filepath.Join(s.wabisabyRoot, "env.example"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env.example: %w", err)
+	}
+	for _, name := range added {
+		if err := s.UpdateVar(name, example[name]); err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
 }
 
 // parseEnvFileValues parses an env file and returns a map of var names to values
@@ -248,21 +427,81 @@ func (s *EnvService) parseEnvFileValues(path string) (map[string]string, error)
 	if err != nil {
 		return nil, err
 	}
+	return dotenv.Parse(data).Vars(), nil
+}
 
-	vars := make(map[string]string)
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+// Export returns the contents of .env for sharing, with sensitive values (config.IsSensitiveVar)
+// emptied unless includeSensitive is set. Comments and layout are kept.
+func (s *EnvService) Export(includeSensitive bool) (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.wabisabyRoot, ".env"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read .env: %w", err)
+	}
+	f := dotenv.Parse(data)
+	if !includeSensitive {
+		for _, key := range f.Keys() {
+			if config.IsSensitiveVar(key) {
+				f.Set(key, "")
+			}
 		}
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			name := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			vars[name] = value
+	}
+	return string(f.Bytes()), nil
+}
+
+// Import merges the variables in content (.env syntax) into .env. Keys missing from .env are added;
+// existing keys are updated only when overwrite is set. Empty imported values (e.g. secrets redacted
+// by Export) never replace existing ones. Content with malformed lines or values that fail their
+// declared type (config.EnvVarSchema) is rejected without changing .env.
+func (s *EnvService) Import(content string, overwrite bool) (*model.EnvImportSummary, error) {
+	imported := dotenv.Parse([]byte(content))
+	if invalid := imported.Invalid(); len(invalid) > 0 {
+		return nil, fmt.Errorf("line %d is not a KEY=value assignment", invalid[0])
+	}
+	schema := config.EnvVarSchema()
+	importedVars := imported.Vars()
+	keys := imported.Keys()
+	for _, key := range keys {
+		value := importedVars[key]
+		if spec, ok := schema[key]; ok && value != "" {
+			if problem := checkEnvValue(spec, value); problem != "" {
+				return nil, fmt.Errorf("%s %s", key, problem)
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envPath := filepath.Join(s.wabisabyRoot, ".env")
+	data, err := os.ReadFile(envPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+	if len(data) == 0 {
+		data = []byte("\n")
+	}
+	f := dotenv.Parse(data)
+
+	summary := &model.EnvImportSummary{Added: []string{}, Updated: []string{}, Skipped: []string{}}
+	for _, key := range keys {
+		value := importedVars[key]
+		current, exists := f.Get(key)
+		switch {
+		case !exists:
+			f.Set(key, value)
+			summary.Added = append(summary.Added, key)
+		case current == value || value == "" || !overwrite:
+			summary.Skipped = append(summary.Skipped, key)
+		default:
+			f.Set(key, value)
+			summary.Updated = append(summary.Updated, key)
 		}
 	}
 
-	return vars, nil
+	if len(summary.Added) > 0 || len(summary.Updated) > 0 {
+		if err := writeFileAtomic(envPath, f.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to write .env: %w", err)
+		}
+	}
+	return summary, nil
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envProfileRegex matches valid profile names (the <profile> part of .env.<profile>)
+var envProfileRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// envBackupProfile is the suffix ActivateProfile backs .env up to; it is not listed as a profile
+const envBackupProfile = "bak"
+
+// ListProfiles returns the names of the .env.<profile> files in the wabisaby-core root, sorted.
+// The .env.bak backup written by ActivateProfile is not a profile.
+func (s *EnvService) ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(s.wabisabyRoot)
+	if err != nil {
+		return nil, err
+	}
+	profiles := []string{}
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), ".env.")
+		if !ok || entry.IsDir() || name == envBackupProfile || !envProfileRegex.MatchString(name) {
+			continue
+		}
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// ActivateProfile copies .env.<profile> over .env, first backing the current .env up to .env.bak
+func (s *EnvService) ActivateProfile(profile string) error {
+	profile = strings.TrimSpace(profile)
+	if !envProfileRegex.MatchString(profile) || profile == envBackupProfile {
+		return fmt.Errorf("invalid profile name %q", profile)
+	}
+
+	profilePath := filepath.Join(s.wabisabyRoot, ".env."+profile)
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile %s not found (expected %s)", profile, profilePath)
+		}
+		return fmt.Errorf("failed to read .env.%s: %w", profile, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envPath := filepath.Join(s.wabisabyRoot, ".env")
+	if current, err := os.ReadFile(envPath); err == nil {
+		if err := writeFileAtomic(filepath.Join(s.wabisabyRoot, ".env."+envBackupProfile), current); err != nil {
+			return fmt.Errorf("failed to back up .env: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .env: %w", err)
+	}
+
+	if err := writeFileAtomic(envPath, data); err != nil {
+		return fmt.Errorf("failed to write .env: %w", err)
+	}
+	return nil
+}
+
+// CurrentProfile returns the profile whose contents match .env, or "" when .env is missing or
+// doesn't match any profile (e.g. it was edited after activating one)
+func (s *EnvService) CurrentProfile() (string, error) {
+	current, err := os.ReadFile(filepath.Join(s.wabisabyRoot, ".env"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	profiles, err := s.ListProfiles()
+	if err != nil {
+		return "", err
+	}
+	for _, profile := range profiles {
+		data, err := os.ReadFile(filepath.Join(s.wabisabyRoot, ".env."+profile))
+		if err == nil && bytes.Equal(data, current) {
+			return profile, nil
+		}
+	}
+	return "", nil
+}
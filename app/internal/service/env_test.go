@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wabisaby/devkit-dashboard/internal/dotenv"
+)
+
+func writeEnvFile(t *testing.T, content string) (*EnvService, string) {
+	t.Helper()
+	root := t.TempDir()
+	path := filepath.Join(root, ".env")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewEnvService(root), path
+}
+
+func TestUpdateVarRejectsMaskedValue(t *testing.T) {
+	const secret = "supersecretvalue1234"
+	s, path := writeEnvFile(t, "API_TOKEN="+secret+"\n")
+
+	if err := s.UpdateVar("API_TOKEN", MaskEnvValue(secret)); err == nil {
+		t.Fatal("UpdateVar accepted the masked value")
+	}
+	if got, err := s.RevealVar("API_TOKEN"); err != nil || got != secret {
+		t.Fatalf("RevealVar = %q, %v; want %q", got, err, secret)
+	}
+
+	if err := s.UpdateVar("API_TOKEN", "newsecretvalue5678"); err != nil {
+		t.Fatalf("UpdateVar: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "API_TOKEN=newsecretvalue5678\n" {
+		t.Fatalf(".env = %q", data)
+	}
+}
+
+func TestGetStatusMasksSensitiveValues(t *testing.T) {
+	const secret = "supersecretvalue1234"
+	s, _ := writeEnvFile(t, "API_TOKEN="+secret+"\n")
+
+	status, err := s.GetStatus(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range status.CustomVars {
+		if v.Name == "API_TOKEN" && v.Value != "****1234" {
+			t.Fatalf("masked value = %q", v.Value)
+		}
+	}
+}
+
+func TestUpdateVarConcurrentWrites(t *testing.T) {
+	const (
+		writers = 16
+		rounds  = 20
+	)
+	s, path := writeEnvFile(t, "# WabiSaby local env\nexport LOG_LEVEL=info # keep\nTEMP=1\n")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*rounds*2)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				// Each writer owns one var, and all of them fight over LAST_WRITER and TEMP
+				if err := s.UpdateVar(fmt.Sprintf("WRITER_%d", w), fmt.Sprintf("round-%d", r)); err != nil {
+					errs <- err
+				}
+				if err := s.UpdateVar("LAST_WRITER", fmt.Sprintf("%d", w)); err != nil {
+					errs <- err
+				}
+				if r%5 == 0 {
+					s.DeleteVar("TEMP")
+					s.UpdateVar("TEMP", "1")
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := dotenv.Parse(data)
+	for w := 0; w < writers; w++ {
+		name := fmt.Sprintf("WRITER_%d", w)
+		if got, ok := f.Get(name); !ok || got != fmt.Sprintf("round-%d", rounds-1) {
+			t.Errorf("%s = %q (present %v), want its last write; a concurrent update was lost", name, got, ok)
+		}
+	}
+	if got, _ := f.Get("LAST_WRITER"); got == "" {
+		t.Error("LAST_WRITER missing")
+	}
+	if got, ok := f.Get("TEMP"); !ok || got != "1" {
+		t.Errorf("TEMP = %q (present %v), want 1", got, ok)
+	}
+	// Untouched lines keep their structure, and no var was duplicated
+	if !strings.HasPrefix(string(data), "# WabiSaby local env\nexport LOG_LEVEL=info # keep\n") {
+		t.Errorf(".env lost its header:\n%s", data)
+	}
+	for _, name := range []string{"LAST_WRITER", "TEMP", "WRITER_0"} {
+		if n := strings.Count(string(data), "\n"+name+"="); n != 1 {
+			t.Errorf("%s appears %d times in .env", name, n)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envLookupRegex matches os.Getenv("NAME") and os.LookupEnv("NAME") calls with a literal name
+var envLookupRegex = regexp.MustCompile(`os\.(?:Getenv|LookupEnv)\(\s*"([^"]+)"\s*\)`)
+
+// ScanServiceEnvUsage returns the sorted env var names read via os.Getenv / os.LookupEnv
+// in the Go source under projectDir/cmdPath (test files excluded). Names built at runtime are not detected.
+func ScanServiceEnvUsage(projectDir, cmdPath string) ([]string, error) {
+	root := filepath.Join(projectDir, filepath.FromSlash(cmdPath))
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("service source not found at %s: %w", root, err)
+	}
+
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (d.Name() == "vendor" || d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range envLookupRegex.FindAllSubmatch(data, -1) {
+			seen[string(m[1])] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanServiceEnvUsage(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "cmd/api/main.go", `package main
+
+import "os"
+
+func main() {
+	port := os.Getenv("PORT")
+	if url, ok := os.LookupEnv( "DATABASE_URL" ); ok {
+		_ = url
+	}
+	_ = os.Getenv("PORT") // read twice, listed once
+	_ = os.Getenv(dynamicName())
+	_ = port
+}
+`)
+	writeProjectFile(t, projectsDir, "core", "cmd/api/internal/cfg/cfg.go", `package cfg
+
+import "os"
+
+var Secret = os.Getenv("JWT_SECRET")
+`)
+	// Skipped: test files, vendor, testdata, hidden dirs, non-Go files and other commands
+	writeProjectFile(t, projectsDir, "core", "cmd/api/main_test.go", `package main; var _ = os.Getenv("TEST_ONLY")`)
+	writeProjectFile(t, projectsDir, "core", "cmd/api/vendor/lib/lib.go", `package lib; var _ = os.Getenv("VENDORED")`)
+	writeProjectFile(t, projectsDir, "core", "cmd/api/testdata/fixture.go", `package fixture; var _ = os.Getenv("FIXTURE")`)
+	writeProjectFile(t, projectsDir, "core", "cmd/api/.cache/gen.go", `package gen; var _ = os.Getenv("HIDDEN")`)
+	writeProjectFile(t, projectsDir, "core", "cmd/api/README.md", `Set os.Getenv("DOCS_ONLY")`)
+	writeProjectFile(t, projectsDir, "core", "cmd/worker/main.go", `package main; var _ = os.Getenv("WORKER_ONLY")`)
+
+	got, err := ScanServiceEnvUsage(filepath.Join(projectsDir, "core"), "cmd/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"DATABASE_URL", "JWT_SECRET", "PORT"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanServiceEnvUsage() = %v, want %v", got, want)
+	}
+}
+
+func TestScanServiceEnvUsageNoLookups(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "cmd/api/main.go", "package main\n\nfunc main() {}\n")
+
+	got, err := ScanServiceEnvUsage(filepath.Join(projectsDir, "core"), "cmd/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("ScanServiceEnvUsage() = %#v, want an empty, non-nil list", got)
+	}
+}
+
+func TestScanServiceEnvUsageMissingSource(t *testing.T) {
+	if _, err := ScanServiceEnvUsage(t.TempDir(), "cmd/missing"); err == nil {
+		t.Error("ScanServiceEnvUsage() on a missing command directory succeeded, want an error")
+	}
+}
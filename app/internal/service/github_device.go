@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,14 +11,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
 // GitHubService handles GitHub OAuth Device Flow and team-based permissions.
 type GitHubService struct {
 	clientID string
-	org      string
-	authDir  string // Application Support dir for github_auth.json; not workspace root
+	orgs     []string // orgs whose teams count; with more than one, teams are "org/slug"
+	authDir  string   // Application Support dir for github_auth.json; not workspace root
+	scopes   []string // OAuth scopes requested in the device flow
+
+	// mu guards everything below it (and scopes, teamsTTL). GitHub calls are made without holding
+	// it: callers snapshot the token, call GitHub, then store the results only if the token is unchanged.
+	mu sync.RWMutex
 
 	// Device flow state (transient, not persisted)
 	deviceCode string
@@ -24,10 +32,15 @@ type GitHubService struct {
 	expiresAt  time.Time
 
 	// Auth state
-	accessToken string
-	username    string
-	avatarURL   string
-	teams       []string
+	accessToken   string
+	username      string
+	avatarURL     string
+	teams         []string
+	teamsFetched  time.Time // when teams were last fetched from GitHub
+	grantedScopes []string
+
+	teamsTTL   time.Duration // how long GetStatus reuses cached teams
+	httpClient *http.Client
 }
 
 // DeviceFlowResponse is returned when initiating the GitHub OAuth Device Flow.
@@ -45,14 +58,22 @@ type Permissions struct {
 	Teams     []string `json:"teams"`
 	Views     []string `json:"views"`
 	Commands  []string `json:"commands"`
+	// Scopes are the OAuth scopes granted to the stored token; MissingScopes are
+	// requested scopes the token lacks (re-auth needed to use features that depend on them).
+	Scopes        []string `json:"scopes"`
+	MissingScopes []string `json:"missingScopes,omitempty"`
+	// Warning explains why the status may be stale, e.g. "GitHub rate limit hit, retry at 15:04".
+	Warning string `json:"warning,omitempty"`
 }
 
 // storedAuth is the JSON structure persisted to disk.
 type storedAuth struct {
-	AccessToken string   `json:"accessToken"`
-	Username    string   `json:"username"`
-	AvatarURL   string   `json:"avatarUrl"`
-	Teams       []string `json:"teams"`
+	AccessToken string    `json:"accessToken"`
+	Username    string    `json:"username"`
+	AvatarURL   string    `json:"avatarUrl"`
+	Teams       []string  `json:"teams"`
+	TeamsAt     time.Time `json:"teamsFetchedAt"`
+	Scopes      []string  `json:"scopes"`
 }
 
 // ──────────────────────────────────────────────────────────────────────────────
@@ -74,22 +95,79 @@ var teamExtraCommands = map[string][]string{
 	"core-devs": {"Infrastructure", "Backend", "Migrations", "Protobuf"},
 }
 
+// Command groups that gate App bindings on the Go side (see RequireCommand)
+const (
+	CommandInfrastructure = "Infrastructure"
+	CommandBackend        = "Backend"
+	CommandMigrations     = "Migrations"
+	CommandProtobuf       = "Protobuf"
+)
+
+// ErrPermissionDenied is wrapped by RequireCommand's error when the user may not run a command group.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrDeviceFlowCancelled is wrapped by PollForToken's error when the poll was cancelled (user aborted).
+var ErrDeviceFlowCancelled = errors.New("device flow cancelled")
+
+// defaultTeamsTTL is how long team memberships are cached before GetStatus re-fetches them.
+const defaultTeamsTTL = 10 * time.Minute
+
+// defaultScopes are requested when no scopes are configured (team membership only).
+var defaultScopes = []string{"read:org"}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Constructor
 // ──────────────────────────────────────────────────────────────────────────────
 
 // NewGitHubService creates a new service and loads any persisted auth token.
 // authDir should be the Application Support path (cfg.AppDataDir), not the workspace root.
-func NewGitHubService(clientID, org, authDir string) *GitHubService {
+// Teams are collected from every org in orgs, named "org/slug" unless only one org is configured.
+func NewGitHubService(clientID string, orgs []string, authDir string) *GitHubService {
 	svc := &GitHubService{
-		clientID: clientID,
-		org:      org,
-		authDir:  authDir,
+		clientID:   clientID,
+		orgs:       orgs,
+		authDir:    authDir,
+		scopes:     defaultScopes,
+		teamsTTL:   defaultTeamsTTL,
+		httpClient: sharedHTTPClient,
 	}
 	svc.loadToken()
 	return svc
 }
 
+// SetHTTPClient replaces the client used for GitHub API calls (e.g. to inject a transport in tests).
+func (s *GitHubService) SetHTTPClient(client *http.Client) {
+	s.httpClient = client
+}
+
+// SetTeamsTTL sets how long GetStatus reuses cached team memberships before re-fetching them.
+// A non-positive TTL restores the default (10 minutes). RefreshTeams always re-fetches.
+func (s *GitHubService) SetTeamsTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultTeamsTTL
+	}
+	s.mu.Lock()
+	s.teamsTTL = ttl
+	s.mu.Unlock()
+}
+
+// SetScopes sets the OAuth scopes requested by the device flow (e.g. "repo" for release/PR features).
+// An empty list restores the default (read:org). Takes effect on the next StartDeviceFlow.
+func (s *GitHubService) SetScopes(scopes []string) {
+	var cleaned []string
+	for _, sc := range scopes {
+		if sc = strings.TrimSpace(sc); sc != "" {
+			cleaned = append(cleaned, sc)
+		}
+	}
+	if len(cleaned) == 0 {
+		cleaned = defaultScopes
+	}
+	s.mu.Lock()
+	s.scopes = cleaned
+	s.mu.Unlock()
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Token persistence
 // ──────────────────────────────────────────────────────────────────────────────
@@ -111,14 +189,19 @@ func (s *GitHubService) loadToken() {
 	s.username = stored.Username
 	s.avatarURL = stored.AvatarURL
 	s.teams = stored.Teams
+	s.teamsFetched = stored.TeamsAt
+	s.grantedScopes = stored.Scopes
 }
 
+// saveToken persists the auth state; the caller holds s.mu
 func (s *GitHubService) saveToken() error {
 	stored := storedAuth{
 		AccessToken: s.accessToken,
 		Username:    s.username,
 		AvatarURL:   s.avatarURL,
 		Teams:       s.teams,
+		TeamsAt:     s.teamsFetched,
+		Scopes:      s.grantedScopes,
 	}
 	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
@@ -127,11 +210,14 @@ func (s *GitHubService) saveToken() error {
 	return os.WriteFile(s.authFilePath(), data, 0600)
 }
 
+// clearToken forgets the auth state and removes the persisted token; the caller holds s.mu
 func (s *GitHubService) clearToken() error {
 	s.accessToken = ""
 	s.username = ""
 	s.avatarURL = ""
 	s.teams = nil
+	s.teamsFetched = time.Time{}
+	s.grantedScopes = nil
 	_ = os.Remove(s.authFilePath())
 	return nil
 }
@@ -147,9 +233,12 @@ func (s *GitHubService) StartDeviceFlow() (*DeviceFlowResponse, error) {
 		return nil, fmt.Errorf("GitHub Client ID not configured. Set WABISABY_GITHUB_CLIENT_ID")
 	}
 
+	s.mu.RLock()
+	scope := strings.Join(s.scopes, " ")
+	s.mu.RUnlock()
 	form := url.Values{}
 	form.Set("client_id", s.clientID)
-	form.Set("scope", "read:org")
+	form.Set("scope", scope)
 
 	req, err := http.NewRequest("POST", "https://github.com/login/device/code", strings.NewReader(form.Encode()))
 	if err != nil {
@@ -158,11 +247,11 @@ func (s *GitHubService) StartDeviceFlow() (*DeviceFlowResponse, error) {
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.doGitHubRequest(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to contact GitHub: %w", err)
 	}
-	defer resp.Body.Close()
+	defer DrainAndClose(resp.Body)
 
 	body, _ := io.ReadAll(resp.Body)
 
@@ -182,12 +271,14 @@ func (s *GitHubService) StartDeviceFlow() (*DeviceFlowResponse, error) {
 		return nil, fmt.Errorf("GitHub error: %s — %s", result.Error, result.ErrorDesc)
 	}
 
+	s.mu.Lock()
 	s.deviceCode = result.DeviceCode
 	s.interval = result.Interval
 	if s.interval < 5 {
 		s.interval = 5
 	}
 	s.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	s.mu.Unlock()
 
 	return &DeviceFlowResponse{
 		UserCode:        result.UserCode,
@@ -197,33 +288,61 @@ func (s *GitHubService) StartDeviceFlow() (*DeviceFlowResponse, error) {
 }
 
 // PollForToken polls GitHub until the user completes authorisation.
-// It blocks until success, expiry, or denial.
-func (s *GitHubService) PollForToken() (*Permissions, error) {
-	if s.deviceCode == "" {
+// It blocks until success, expiry, denial, or ctx is cancelled; cancellation clears the pending
+// device flow and returns an error wrapping both ErrDeviceFlowCancelled and ctx.Err().
+// When rate limited it pauses polling until the limit resets, or fails (wrapping the
+// *RateLimitError) if that is after the device code expires.
+func (s *GitHubService) PollForToken(ctx context.Context) (*Permissions, error) {
+	s.mu.RLock()
+	deviceCode, interval, expiresAt := s.deviceCode, s.interval, s.expiresAt
+	s.mu.RUnlock()
+	if deviceCode == "" {
 		return nil, fmt.Errorf("no pending device flow; call StartDeviceFlow first")
 	}
 
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
 	for {
-		if time.Now().After(s.expiresAt) {
-			s.deviceCode = ""
+		if time.Now().After(expiresAt) {
+			s.CancelDeviceFlow()
 			return nil, fmt.Errorf("device code expired; please try again")
 		}
 
-		time.Sleep(time.Duration(s.interval) * time.Second)
+		select {
+		case <-ctx.Done():
+			s.CancelDeviceFlow()
+			return nil, fmt.Errorf("%w: %w", ErrDeviceFlowCancelled, ctx.Err())
+		case <-ticker.C:
+		}
 
 		form := url.Values{}
 		form.Set("client_id", s.clientID)
-		form.Set("device_code", s.deviceCode)
+		form.Set("device_code", deviceCode)
 		form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
 
-		req, err := http.NewRequest("POST", "https://github.com/login/oauth/access_token", strings.NewReader(form.Encode()))
+		req, err := http.NewRequestWithContext(ctx, "POST", "https://github.com/login/oauth/access_token", strings.NewReader(form.Encode()))
 		if err != nil {
 			return nil, err
 		}
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := s.doGitHubRequest(req)
+		var rateLimited *RateLimitError
+		if errors.As(err, &rateLimited) {
+			if rateLimited.ResetAt.After(expiresAt) {
+				s.CancelDeviceFlow()
+				return nil, fmt.Errorf("%w, after the device code expires; please try again later", err)
+			}
+			select {
+			case <-ctx.Done():
+				s.CancelDeviceFlow()
+				return nil, fmt.Errorf("%w: %w", ErrDeviceFlowCancelled, ctx.Err())
+			case <-time.After(time.Until(rateLimited.ResetAt)):
+			}
+			continue
+		}
 		if err != nil {
 			continue // retry on transient network error
 		}
@@ -245,36 +364,39 @@ func (s *GitHubService) PollForToken() (*Permissions, error) {
 		case "authorization_pending":
 			continue
 		case "slow_down":
-			s.interval += 5
+			interval += 5
+			ticker.Reset(time.Duration(interval) * time.Second)
 			continue
 		case "expired_token":
-			s.deviceCode = ""
+			s.CancelDeviceFlow()
 			return nil, fmt.Errorf("device code expired; please try again")
 		case "access_denied":
-			s.deviceCode = ""
+			s.CancelDeviceFlow()
 			return nil, fmt.Errorf("authorisation denied by user")
 		case "":
-			// Success — save the token and fetch user info + teams.
-			s.accessToken = result.AccessToken
-			s.deviceCode = ""
-
-			username, avatarURL, err := s.fetchUser()
+			// Success — fetch user info + teams, then save the token.
+			s.CancelDeviceFlow()
+			username, avatarURL, err := s.fetchUser(result.AccessToken)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub user: %w", err)
 			}
-			s.username = username
-			s.avatarURL = avatarURL
-
-			teams, err := s.fetchTeams()
+			teams, err := s.fetchTeams(result.AccessToken)
 			if err != nil {
 				return nil, fmt.Errorf("failed to fetch teams: %w", err)
 			}
-			s.teams = teams
 
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.accessToken = result.AccessToken
+			s.grantedScopes = parseScopes(result.Scope)
+			s.username = username
+			s.avatarURL = avatarURL
+			s.teams = teams
+			s.teamsFetched = time.Now()
 			_ = s.saveToken()
 			return s.computePermissions(), nil
 		default:
-			s.deviceCode = ""
+			s.CancelDeviceFlow()
 			return nil, fmt.Errorf("GitHub error: %s — %s", result.Error, result.ErrorDesc)
 		}
 	}
@@ -285,45 +407,141 @@ func (s *GitHubService) PollForToken() (*Permissions, error) {
 // ──────────────────────────────────────────────────────────────────────────────
 
 // GetStatus returns the current auth status and cached permissions.
-// If a token is stored it verifies it is still valid.
+// If a token is stored it verifies it is still valid (one /user call); team memberships are
+// re-fetched only once the cached ones are older than the teams TTL.
 func (s *GitHubService) GetStatus() *Permissions {
-	if s.accessToken == "" {
+	s.mu.RLock()
+	token, teamsStale := s.accessToken, time.Since(s.teamsFetched) > s.teamsTTL
+	s.mu.RUnlock()
+	if token == "" {
 		return &Permissions{Connected: false}
 	}
 
 	// Quick validation: hit /user to check the token is alive.
-	username, avatarURL, err := s.fetchUser()
+	username, avatarURL, err := s.fetchUser(token)
+	var rateLimited *RateLimitError
+	if errors.As(err, &rateLimited) {
+		// Can't verify right now; report the cached state rather than dropping the token.
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.statusWithWarning(token, rateLimited.Error())
+	}
 	if err != nil {
-		// Token invalid/revoked — clear it.
-		s.clearToken()
-		return &Permissions{Connected: false}
+		// Token invalid/revoked — clear it (unless it was replaced meanwhile).
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.accessToken == token {
+			s.clearToken()
+		}
+		return s.statusWithWarning(token, "")
+	}
+
+	var teams []string
+	var teamsErr error
+	if teamsStale {
+		teams, teamsErr = s.fetchTeams(token)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accessToken != token {
+		// Disconnected or re-authorised while GitHub was being called
+		return s.statusWithWarning(token, "")
 	}
 	s.username = username
 	s.avatarURL = avatarURL
+	if teamsStale && teamsErr == nil {
+		s.teams = teams
+		s.teamsFetched = time.Now()
+	}
 	_ = s.saveToken()
-
+	// On a teams error keep the cached teams; the next status check retries
+	if errors.As(teamsErr, &rateLimited) {
+		return s.statusWithWarning(token, rateLimited.Error())
+	}
 	return s.computePermissions()
 }
 
+// statusWithWarning returns the current permissions, disconnected when there is no token, with
+// warning set when the token is still the one GetStatus checked; the caller holds s.mu
+func (s *GitHubService) statusWithWarning(checked, warning string) *Permissions {
+	if s.accessToken == "" {
+		return &Permissions{Connected: false}
+	}
+	perms := s.computePermissions()
+	if s.accessToken == checked {
+		perms.Warning = warning
+	}
+	return perms
+}
+
+// RequireCommand returns an ErrPermissionDenied error unless the connected user's permissions include
+// the command group. Uses the cached auth state (no GitHub call), so it is cheap enough to run on every
+// gated action; GetStatus keeps that state fresh.
+func (s *GitHubService) RequireCommand(group string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.accessToken == "" {
+		return fmt.Errorf("%w: connect GitHub in Settings to use %s actions", ErrPermissionDenied, group)
+	}
+	for _, c := range s.computePermissions().Commands {
+		if c == group {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: your GitHub teams don't grant %s actions", ErrPermissionDenied, group)
+}
+
+// CancelDeviceFlow discards the pending device flow, if any. A running PollForToken keeps polling
+// its device code; cancel its context instead.
+func (s *GitHubService) CancelDeviceFlow() {
+	s.mu.Lock()
+	s.deviceCode = ""
+	s.mu.Unlock()
+}
+
 // Disconnect clears the stored token and returns disconnected state.
 func (s *GitHubService) Disconnect() *Permissions {
+	s.mu.Lock()
 	s.clearToken()
+	s.mu.Unlock()
 	return &Permissions{Connected: false}
 }
 
 // RefreshTeams re-fetches team memberships from GitHub and recomputes permissions.
+// If rate-limited with a reset less than maxRateLimitRetryWait away, it waits and retries once.
 func (s *GitHubService) RefreshTeams() (*Permissions, error) {
-	if s.accessToken == "" {
+	s.mu.RLock()
+	token := s.accessToken
+	s.mu.RUnlock()
+	if token == "" {
 		return &Permissions{Connected: false}, nil
 	}
 
-	teams, err := s.fetchTeams()
+	teams, err := s.fetchTeams(token)
+	var rateLimited *RateLimitError
+	if errors.As(err, &rateLimited) {
+		if wait := time.Until(rateLimited.ResetAt); wait <= maxRateLimitRetryWait {
+			time.Sleep(wait)
+			teams, err = s.fetchTeams(token)
+		}
+	}
+	if errors.As(err, &rateLimited) {
+		return nil, rateLimited
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh teams: %w", err)
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accessToken != token {
+		// Disconnected or re-authorised while the teams were fetched
+		return s.statusWithWarning(token, ""), nil
+	}
 	s.teams = teams
+	s.teamsFetched = time.Now()
 	_ = s.saveToken()
-
 	return s.computePermissions(), nil
 }
 
@@ -331,20 +549,20 @@ func (s *GitHubService) RefreshTeams() (*Permissions, error) {
 // GitHub API helpers
 // ──────────────────────────────────────────────────────────────────────────────
 
-// fetchUser returns the authenticated user's login and avatar URL.
-func (s *GitHubService) fetchUser() (login, avatarURL string, err error) {
+// fetchUser returns the login and avatar URL of token's user.
+func (s *GitHubService) fetchUser(token string) (login, avatarURL string, err error) {
 	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
 	if err != nil {
 		return "", "", err
 	}
-	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Accept", "application/vnd.github+json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.doGitHubRequest(req)
 	if err != nil {
 		return "", "", err
 	}
-	defer resp.Body.Close()
+	defer DrainAndClose(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		return "", "", fmt.Errorf("GitHub API returned %d", resp.StatusCode)
@@ -361,7 +579,8 @@ func (s *GitHubService) fetchUser() (login, avatarURL string, err error) {
 	return user.Login, user.AvatarURL, nil
 }
 
-func (s *GitHubService) fetchTeams() ([]string, error) {
+// fetchTeams returns token's user's teams in the configured orgs.
+func (s *GitHubService) fetchTeams(token string) ([]string, error) {
 	var orgTeams []string
 	page := 1
 
@@ -371,10 +590,10 @@ func (s *GitHubService) fetchTeams() ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set("Authorization", "Bearer "+s.accessToken)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Accept", "application/vnd.github+json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := s.doGitHubRequest(req)
 		if err != nil {
 			return nil, err
 		}
@@ -399,8 +618,12 @@ func (s *GitHubService) fetchTeams() ([]string, error) {
 			break
 		}
 		for _, t := range teams {
-			if strings.EqualFold(t.Org.Login, s.org) {
-				orgTeams = append(orgTeams, t.Slug)
+			if org, ok := s.matchOrg(t.Org.Login); ok {
+				if len(s.orgs) > 1 {
+					orgTeams = append(orgTeams, org+"/"+t.Slug)
+				} else {
+					orgTeams = append(orgTeams, t.Slug)
+				}
 			}
 		}
 		if len(teams) < 100 {
@@ -416,17 +639,42 @@ func (s *GitHubService) fetchTeams() ([]string, error) {
 // Permission computation
 // ──────────────────────────────────────────────────────────────────────────────
 
+// matchOrg returns the configured org matching login (case-insensitively)
+func (s *GitHubService) matchOrg(login string) (string, bool) {
+	for _, org := range s.orgs {
+		if strings.EqualFold(org, login) {
+			return org, true
+		}
+	}
+	return "", false
+}
+
+// teamKeys returns the permission-mapping keys a team matches: the team as stored plus its other
+// form, bare "slug" for "org/slug" and "org/slug" for a bare slug (single org)
+func (s *GitHubService) teamKeys(team string) []string {
+	if _, slug, ok := strings.Cut(team, "/"); ok {
+		return []string{team, slug}
+	}
+	if len(s.orgs) == 1 {
+		return []string{team, s.orgs[0] + "/" + team}
+	}
+	return []string{team}
+}
+
+// computePermissions maps the cached teams to views and commands; the caller holds s.mu
 func (s *GitHubService) computePermissions() *Permissions {
 	// Maintainers get full access.
 	for _, t := range s.teams {
-		if t == "maintainers" {
+		if s.hasTeamKey(t, "maintainers") {
 			return &Permissions{
-				Connected: true,
-				Username:  s.username,
-				AvatarURL: s.avatarURL,
-				Teams:     s.teams,
-				Views:     everyView,
-				Commands:  everyCommand,
+				Connected:     true,
+				Username:      s.username,
+				AvatarURL:     s.avatarURL,
+				Teams:         s.teams,
+				Views:         everyView,
+				Commands:      everyCommand,
+				Scopes:        s.grantedScopes,
+				MissingScopes: s.missingScopes(),
 			}
 		}
 	}
@@ -443,11 +691,13 @@ func (s *GitHubService) computePermissions() *Permissions {
 	}
 
 	for _, team := range s.teams {
-		for _, v := range teamExtraViews[team] {
-			viewSet[v] = true
-		}
-		for _, c := range teamExtraCommands[team] {
-			cmdSet[c] = true
+		for _, key := range s.teamKeys(team) {
+			for _, v := range teamExtraViews[key] {
+				viewSet[v] = true
+			}
+			for _, c := range teamExtraCommands[key] {
+				cmdSet[c] = true
+			}
 		}
 	}
 
@@ -461,11 +711,66 @@ func (s *GitHubService) computePermissions() *Permissions {
 	}
 
 	return &Permissions{
-		Connected: true,
-		Username:  s.username,
-		AvatarURL: s.avatarURL,
-		Teams:     s.teams,
-		Views:     views,
-		Commands:  commands,
+		Connected:     true,
+		Username:      s.username,
+		AvatarURL:     s.avatarURL,
+		Teams:         s.teams,
+		Views:         views,
+		Commands:      commands,
+		Scopes:        s.grantedScopes,
+		MissingScopes: s.missingScopes(),
+	}
+}
+
+// hasTeamKey reports whether team matches the permission-mapping key (see teamKeys)
+func (s *GitHubService) hasTeamKey(team, key string) bool {
+	for _, k := range s.teamKeys(team) {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// missingScopes returns requested scopes not present in the granted scopes.
+// GitHub reports implied scopes only by their parent, so "repo" satisfies "public_repo"
+// and "admin:org"/"write:org" satisfy "read:org".
+func (s *GitHubService) missingScopes() []string {
+	granted := make(map[string]bool, len(s.grantedScopes))
+	for _, sc := range s.grantedScopes {
+		granted[sc] = true
+	}
+	implied := map[string][]string{
+		"public_repo": {"repo"},
+		"read:org":    {"write:org", "admin:org"},
+		"write:org":   {"admin:org"},
+	}
+	var missing []string
+	for _, want := range s.scopes {
+		if granted[want] {
+			continue
+		}
+		ok := false
+		for _, parent := range implied[want] {
+			if granted[parent] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// parseScopes splits GitHub's comma-separated scope string (e.g. "read:org,repo").
+func parseScopes(raw string) []string {
+	var scopes []string
+	for _, sc := range strings.Split(raw, ",") {
+		if sc = strings.TrimSpace(sc); sc != "" {
+			scopes = append(scopes, sc)
+		}
 	}
+	return scopes
 }
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// redirectTransport sends every request to the test server, whatever host it was made for
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestGitHubService returns a service whose GitHub calls are answered by handler
+func newTestGitHubService(t *testing.T, handler http.HandlerFunc) *GitHubService {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+
+	s := NewGitHubService("test-client", []string{"WabiSaby"}, t.TempDir())
+	s.SetHTTPClient(&http.Client{Transport: redirectTransport{target: target}})
+	return s
+}
+
+func TestStartDeviceFlowRequestsScopes(t *testing.T) {
+	var gotScope, gotClient string
+	s := newTestGitHubService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login/device/code" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		r.ParseForm()
+		gotScope, gotClient = r.PostForm.Get("scope"), r.PostForm.Get("client_id")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"device_code":"dc","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","expires_in":900,"interval":5}`))
+	})
+	s.SetScopes([]string{"read:org", " repo ", ""})
+
+	resp, err := s.StartDeviceFlow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotScope != "read:org repo" || gotClient != "test-client" {
+		t.Fatalf("scope = %q, client_id = %q", gotScope, gotClient)
+	}
+	if resp.UserCode != "ABCD-1234" {
+		t.Fatalf("user code = %q", resp.UserCode)
+	}
+}
+
+func TestStartDeviceFlowDefaultScope(t *testing.T) {
+	var gotScope string
+	s := newTestGitHubService(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotScope = r.PostForm.Get("scope")
+		w.Write([]byte(`{"device_code":"dc","user_code":"X","verification_uri":"u","expires_in":900,"interval":5}`))
+	})
+	if _, err := s.StartDeviceFlow(); err != nil {
+		t.Fatal(err)
+	}
+	if gotScope != "read:org" {
+		t.Fatalf("scope = %q, want read:org", gotScope)
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	got := parseScopes(" read:org, repo,,gist ")
+	if want := []string{"read:org", "repo", "gist"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseScopes = %v, want %v", got, want)
+	}
+	if got := parseScopes(""); len(got) != 0 {
+		t.Fatalf("parseScopes(\"\") = %v", got)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		requested []string
+		granted   string
+		want      []string
+	}{
+		{[]string{"read:org"}, "read:org", nil},
+		{[]string{"read:org", "repo"}, "read:org", []string{"repo"}},
+		{[]string{"read:org", "public_repo"}, "admin:org,repo", nil},
+		{[]string{"write:org", "gist"}, "", []string{"write:org", "gist"}},
+	}
+	for _, tt := range tests {
+		s := NewGitHubService("id", []string{"WabiSaby"}, t.TempDir())
+		s.SetScopes(tt.requested)
+		s.grantedScopes = parseScopes(tt.granted)
+		if got := s.missingScopes(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("requested %v, granted %q: missing = %v, want %v", tt.requested, tt.granted, got, tt.want)
+		}
+	}
+}
+
+func TestGitHubServiceConcurrentAccess(t *testing.T) {
+	s := newTestGitHubService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			w.Write([]byte(`{"login":"octo","avatar_url":"https://example.com/a.png"}`))
+		case "/user/teams":
+			w.Write([]byte(`[{"slug":"core-devs","organization":{"login":"WabiSaby"}}]`))
+		}
+	})
+	s.SetTeamsTTL(time.Nanosecond) // every GetStatus re-fetches teams
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		s.mu.Lock()
+		s.accessToken = "token"
+		s.mu.Unlock()
+		wg.Add(4)
+		go func() { defer wg.Done(); s.GetStatus() }()
+		go func() { defer wg.Done(); s.RefreshTeams() }()
+		go func() { defer wg.Done(); s.RequireCommand(CommandBackend) }()
+		go func() { defer wg.Done(); s.Disconnect() }()
+	}
+	wg.Wait()
+
+	// With the token restored, the state is consistent again
+	s.mu.Lock()
+	s.accessToken = "token"
+	s.mu.Unlock()
+	perms := s.GetStatus()
+	if !perms.Connected || perms.Username != "octo" || !reflect.DeepEqual(perms.Teams, []string{"core-devs"}) {
+		t.Errorf("GetStatus after concurrent use = %+v", perms)
+	}
+	if err := s.RequireCommand(CommandBackend); err != nil {
+		t.Errorf("RequireCommand(Backend) = %v, want allowed for core-devs", err)
+	}
+}
+
+// startTestDeviceFlow puts s in the middle of a device flow polled every second
+func startTestDeviceFlow(s *GitHubService, expiresIn time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deviceCode, s.interval, s.expiresAt = "dc", 1, time.Now().Add(expiresIn)
+}
+
+func TestPollForTokenWaitsForRateLimitReset(t *testing.T) {
+	var mu sync.Mutex
+	var polls []time.Time
+	s := newTestGitHubService(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls = append(polls, time.Now())
+		if len(polls) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"error":"access_denied"}`))
+	})
+	startTestDeviceFlow(s, time.Minute)
+
+	if _, err := s.PollForToken(context.Background()); err == nil || err.Error() != "authorisation denied by user" {
+		t.Fatalf("PollForToken = %v, want the denial from the poll after the reset", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(polls) != 2 {
+		t.Fatalf("polled %d times, want 2", len(polls))
+	}
+	if gap := polls[1].Sub(polls[0]); gap < 2*time.Second {
+		t.Errorf("polled again %s after the rate limit, want at least Retry-After (2s)", gap)
+	}
+}
+
+func TestPollForTokenRateLimitedPastExpiry(t *testing.T) {
+	polls := 0
+	s := newTestGitHubService(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	startTestDeviceFlow(s, time.Minute)
+
+	_, err := s.PollForToken(context.Background())
+	var rateLimited *RateLimitError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("PollForToken = %v, want a rate limit error", err)
+	}
+	if polls != 1 {
+		t.Errorf("polled %d times, want to give up after the first rate limit", polls)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.deviceCode != "" {
+		t.Error("device flow still pending after giving up")
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRateLimitWait is assumed when a rate-limited response does not say when to retry
+const defaultRateLimitWait = time.Minute
+
+// maxRateLimitRetryWait is the longest RefreshTeams waits to retry once after a rate limit
+const maxRateLimitRetryWait = 30 * time.Second
+
+// RateLimitError is returned when GitHub rejects a request because a (primary or secondary)
+// rate limit was hit. ResetAt is when requests are expected to succeed again.
+type RateLimitError struct {
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitHub rate limit hit, retry at %s", e.ResetAt.Local().Format("15:04"))
+}
+
+// doGitHubRequest sends req with the service's client. A rate-limited response (429, or 403 with
+// X-RateLimit-Remaining: 0 or Retry-After) is closed and returned as *RateLimitError.
+func (s *GitHubService) doGitHubRequest(req *http.Request) (*http.Response, error) {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resetAt, limited := rateLimitReset(resp, time.Now()); limited {
+		DrainAndClose(resp.Body)
+		return nil, &RateLimitError{ResetAt: resetAt}
+	}
+	return resp, nil
+}
+
+// rateLimitReset reports whether resp is a rate-limit rejection and when to retry, from Retry-After
+// (seconds) or X-RateLimit-Reset (Unix time), else defaultRateLimitWait from now
+func rateLimitReset(resp *http.Response, now time.Time) (time.Time, bool) {
+	retryAfter := resp.Header.Get("Retry-After")
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusForbidden:
+		if resp.Header.Get("X-RateLimit-Remaining") != "0" && retryAfter == "" {
+			return time.Time{}, false
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		return now.Add(time.Duration(secs) * time.Second), true
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		return time.Unix(reset, 0), true
+	}
+	return now.Add(defaultRateLimitWait), true
+}
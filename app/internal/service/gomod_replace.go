@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// goModEditJSON is the part of `go mod edit -json` output the replace helpers use
+type goModEditJSON struct {
+	Module struct {
+		Path string
+	}
+	Replace []struct {
+		Old struct{ Path, Version string }
+		New struct{ Path, Version string }
+	}
+}
+
+// readGoMod runs go mod edit -json in dir
+func readGoMod(dir string) (*goModEditJSON, error) {
+	cmd := exec.Command("go", "mod", "edit", "-json")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod in %s: %w", filepath.Base(dir), err)
+	}
+	var mod goModEditJSON
+	if err := json.Unmarshal(output, &mod); err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod in %s: %w", filepath.Base(dir), err)
+	}
+	return &mod, nil
+}
+
+// goProjectDir returns projectsDir/name after checking name is a plain project name with a go.mod
+func goProjectDir(projectsDir, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid project name %q", name)
+	}
+	dir := filepath.Join(projectsDir, name)
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return "", fmt.Errorf("%s is not a cloned Go project", name)
+	}
+	return dir, nil
+}
+
+// ListLocalReplaces returns the replace directives in a project's go.mod that point at a local directory
+func ListLocalReplaces(projectsDir, project string) ([]model.LocalReplace, error) {
+	dir, err := goProjectDir(projectsDir, project)
+	if err != nil {
+		return nil, err
+	}
+	mod, err := readGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	replaces := []model.LocalReplace{}
+	for _, r := range mod.Replace {
+		if r.New.Version == "" && (strings.HasPrefix(r.New.Path, ".") || filepath.IsAbs(r.New.Path)) {
+			replaces = append(replaces, model.LocalReplace{Module: r.Old.Path, Path: r.New.Path})
+		}
+	}
+	return replaces, nil
+}
+
+// SetLocalReplace adds (enable) or drops a `replace <module> => ../<dependency>` directive in the
+// project's go.mod, so it builds against the sibling clone. The module path is read from the
+// dependency's own go.mod.
+func SetLocalReplace(projectsDir, project, dependency string, enable bool) error {
+	dir, err := goProjectDir(projectsDir, project)
+	if err != nil {
+		return err
+	}
+	if dependency == project {
+		return fmt.Errorf("a project cannot replace itself")
+	}
+	depDir, err := goProjectDir(projectsDir, dependency)
+	if err != nil {
+		return err
+	}
+	depMod, err := readGoMod(depDir)
+	if err != nil {
+		return err
+	}
+	module := depMod.Module.Path
+
+	arg := "-dropreplace=" + module
+	if enable {
+		arg = fmt.Sprintf("-replace=%s=../%s", module, dependency)
+	}
+	cmd := exec.Command("go", "mod", "edit", arg)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod edit failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
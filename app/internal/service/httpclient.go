@@ -0,0 +1,57 @@
+package service
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxDrainBytes bounds how much of an unread response body is discarded to allow connection reuse;
+// larger remainders are cheaper to drop with the connection.
+const maxDrainBytes = 64 * 1024
+
+// DefaultHTTPTimeout is the overall request timeout of clients created without one
+const DefaultHTTPTimeout = 30 * time.Second
+
+// sharedTransport is the connection pool behind every client NewHTTPClient returns
+var sharedTransport = newHTTPTransport()
+
+// sharedHTTPClient is the default client for health probes, GitHub API calls and crash webhooks;
+// the App replaces it with one using the configured timeout (see SetHTTPClient on each service).
+var sharedHTTPClient = NewHTTPClient(DefaultHTTPTimeout)
+
+// NewHTTPClient returns a client on the shared connection pool with the given overall request
+// timeout (d <= 0 = DefaultHTTPTimeout). Callers bound individual requests with a context deadline;
+// the timeout is only a backstop.
+func NewHTTPClient(d time.Duration) *http.Client {
+	if d <= 0 {
+		d = DefaultHTTPTimeout
+	}
+	return &http.Client{Transport: sharedTransport, Timeout: d}
+}
+
+// newHTTPTransport returns a transport with bounded idle pooling and dial/handshake timeouts.
+// Proxies come from HTTPS_PROXY/HTTP_PROXY/NO_PROXY (localhost health probes are never proxied).
+func newHTTPTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   2 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          32,
+		MaxIdleConnsPerHost:   4,
+		IdleConnTimeout:       60 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// DrainAndClose discards the rest of a response body (up to maxDrainBytes) and closes it,
+// so the underlying connection can go back to the pool.
+func DrainAndClose(body io.ReadCloser) {
+	_, _ = io.CopyN(io.Discard, body, maxDrainBytes)
+	_ = body.Close()
+}
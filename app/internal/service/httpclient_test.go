@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingClient returns a client like NewHTTPClient's whose transport counts the connections it dials
+func countingClient(t *testing.T) (*http.Client, *atomic.Int32) {
+	t.Helper()
+	client := NewHTTPClient(0)
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("shared transport is %T, want *http.Transport", client.Transport)
+	}
+	transport = transport.Clone()
+	t.Cleanup(transport.CloseIdleConnections)
+	var dials atomic.Int32
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return dial(ctx, network, addr)
+	}
+	client.Transport = transport
+	return client, &dials
+}
+
+func TestSharedHTTPClientReusesConnections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("ok\n", 1000)))
+	}))
+	defer srv.Close()
+	client, dials := countingClient(t)
+
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(srv.URL + "/health")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Like a health probe: only the status matters, the body is left unread
+		DrainAndClose(resp.Body)
+	}
+	if n := dials.Load(); n != 1 {
+		t.Errorf("5 sequential requests dialed %d connections, want 1", n)
+	}
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	client := NewHTTPClient(5 * time.Second)
+	if client.Timeout != 5*time.Second || client.Transport != sharedTransport {
+		t.Errorf("NewHTTPClient(5s) = timeout %s on %p, want 5s on the shared transport", client.Timeout, client.Transport)
+	}
+	if NewHTTPClient(0).Timeout != DefaultHTTPTimeout || sharedHTTPClient.Timeout != DefaultHTTPTimeout {
+		t.Error("default timeout not used, or the shared default client was changed")
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// Log line streams
+const (
+	LogStreamStdout = "stdout"
+	LogStreamStderr = "stderr"
+	LogStreamSystem = "system" // lines the dashboard adds, e.g. "[Process exited]"
+)
+
+// logLevelPattern finds a level keyword, e.g. "INFO ...", "[WARN]", "level=error", `"level":"debug"`
+var logLevelPattern = regexp.MustCompile(`(?i)\b(DEBUG|INFO|WARN|WARNING|ERROR|ERR|FATAL|PANIC)\b`)
+
+// detectLogLevel returns "debug", "info", "warn" or "error" for the first level keyword in text, or "" if none
+func detectLogLevel(text string) string {
+	m := logLevelPattern.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	switch strings.ToUpper(m[1]) {
+	case "DEBUG":
+		return "debug"
+	case "INFO":
+		return "info"
+	case "WARN", "WARNING":
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// newLogLine builds a log line received now from the given stream
+func newLogLine(serviceName, stream, text string) model.LogLine {
+	line := model.LogLine{
+		Service:   serviceName,
+		Text:      text,
+		Stream:    stream,
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+	}
+	if stream != LogStreamSystem {
+		line.Level = detectLogLevel(text)
+	}
+	return line
+}
+
+// logLineText formats a log line as plain text, marking stderr lines the way raw log output always has
+func logLineText(line model.LogLine) string {
+	if line.Stream == LogStreamStderr {
+		return "[stderr] " + line.Text
+	}
+	return line.Text
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// makeTargetRegex matches a rule line "target [target...]:" but not variable assignments ("X := y", "X ::= y").
+var makeTargetRegex = regexp.MustCompile(`^([A-Za-z0-9_./ -]+?)\s*::?(?:[^=:]|$)`)
+
+// makeTargetsCacheEntry holds parsed targets for a Makefile at a given mtime.
+type makeTargetsCacheEntry struct {
+	modTime time.Time
+	targets []string
+}
+
+var (
+	makeTargetsMu    sync.Mutex
+	makeTargetsCache = make(map[string]makeTargetsCacheEntry)
+)
+
+// ListMakeTargets returns the sorted make targets defined in the project's Makefile.
+// Special targets (.PHONY etc.) and pattern rules are skipped. Results are cached by Makefile mtime;
+// callers get their own copy.
+func ListMakeTargets(projectsDir, projectName string) ([]string, error) {
+	makefilePath := filepath.Join(projectsDir, projectName, "Makefile")
+	info, err := os.Stat(makefilePath)
+	if err != nil {
+		return nil, fmt.Errorf("no Makefile in %s: %w", projectName, err)
+	}
+
+	makeTargetsMu.Lock()
+	if entry, ok := makeTargetsCache[makefilePath]; ok && entry.modTime.Equal(info.ModTime()) {
+		makeTargetsMu.Unlock()
+		return append([]string(nil), entry.targets...), nil
+	}
+	makeTargetsMu.Unlock()
+
+	targets, err := parseMakeTargets(makefilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	makeTargetsMu.Lock()
+	makeTargetsCache[makefilePath] = makeTargetsCacheEntry{modTime: info.ModTime(), targets: targets}
+	makeTargetsMu.Unlock()
+
+	return append([]string(nil), targets...), nil
+}
+
+// parseMakeTargets reads a Makefile and returns its sorted, de-duplicated rule targets
+func parseMakeTargets(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Makefile: %w", err)
+	}
+	defer f.Close()
+
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Recipe lines and comments never declare targets
+		if line == "" || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		matches := makeTargetRegex.FindStringSubmatch(line)
+		if len(matches) < 2 {
+			continue
+		}
+		for _, target := range strings.Fields(matches[1]) {
+			if strings.HasPrefix(target, ".") || strings.Contains(target, "%") {
+				continue
+			}
+			seen[target] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Makefile: %w", err)
+	}
+
+	targets := make([]string, 0, len(seen))
+	for t := range seen {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// projectConfigFile is the optional per-project DevKit config (project root)
+const projectConfigFile = ".devkit.yml"
+
+// builtinProjectActions are the make targets every project stream accepts
+var builtinProjectActions = []string{"build", "format", "lint", "test"}
+
+// ProjectTargets returns the make targets a project stream may run: the built-in actions (build,
+// format, lint, test) plus the "targets" list in the project's .devkit.yml. Without a .devkit.yml,
+// or when it is invalid (logged), only the built-in actions are allowed. Entries that make would
+// read as an option or a variable assignment ("-x", "X=y") are dropped.
+func ProjectTargets(projectsDir, projectName string) ([]string, error) {
+	configPath := filepath.Join(projectsDir, projectName, projectConfigFile)
+	var targets []string
+	data, err := os.ReadFile(configPath)
+	switch {
+	case err == nil:
+		var cfg struct {
+			Targets []string `yaml:"targets"`
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			log.Printf("Ignoring invalid %s in %s: %v", projectConfigFile, projectName, err)
+		} else {
+			targets = cfg.Targets
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(targets)+len(builtinProjectActions))
+	for _, t := range append(append([]string{}, builtinProjectActions...), targets...) {
+		t = strings.TrimSpace(t)
+		if t == "" || strings.HasPrefix(t, "-") || strings.ContainsAny(t, "= \t") || seen[t] {
+			continue
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// IsProjectTarget reports whether target is in ProjectTargets for the project
+func IsProjectTarget(projectsDir, projectName, target string) bool {
+	targets, err := ProjectTargets(projectsDir, projectName)
+	if err != nil {
+		return false
+	}
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
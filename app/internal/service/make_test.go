@@ -0,0 +1,130 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeProjectFile writes name under projectsDir/project, creating the project directory
+func writeProjectFile(t *testing.T, projectsDir, project, name, content string) {
+	t.Helper()
+	path := filepath.Join(projectsDir, project, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListMakeTargets(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "go-svc", "Makefile", `# Build the service
+GO ?= go
+LDFLAGS := -s -w
+VERSION ::= 1.0
+
+.PHONY: build test lint
+build:
+	$(GO) build ./...
+
+test: build
+	$(GO) test ./...
+
+lint fmt:
+	golangci-lint run
+
+%.pb.go: %.proto
+	protoc $<
+`)
+	writeProjectFile(t, projectsDir, "web", "Makefile", `.DEFAULT_GOAL := dev
+install:
+	npm ci
+dev: install
+	npm run dev
+build::
+	npm run build
+build::
+	cp -r public dist
+`)
+
+	tests := []struct {
+		project string
+		want    []string
+	}{
+		{"go-svc", []string{"build", "fmt", "lint", "test"}},
+		{"web", []string{"build", "dev", "install"}},
+	}
+	for _, tt := range tests {
+		got, err := ListMakeTargets(projectsDir, tt.project)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.project, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: targets = %v, want %v", tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestListMakeTargetsWithoutMakefile(t *testing.T) {
+	projectsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectsDir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if targets, err := ListMakeTargets(projectsDir, "docs"); err == nil {
+		t.Fatalf("ListMakeTargets = %v, want an error", targets)
+	}
+}
+
+func TestListMakeTargetsReturnsCopy(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "svc", "Makefile", "build:\n\tgo build\n")
+
+	first, err := ListMakeTargets(projectsDir, "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0] = "mutated"
+	second, err := ListMakeTargets(projectsDir, "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0] != "build" {
+		t.Fatalf("cached targets were mutated: %v", second)
+	}
+}
+
+func TestProjectTargets(t *testing.T) {
+	projectsDir := t.TempDir()
+	builtins := []string{"build", "format", "lint", "test"}
+
+	// Without .devkit.yml only the built-in actions are allowed, whatever the Makefile defines
+	writeProjectFile(t, projectsDir, "core", "Makefile", "build:\n\tgo build\n\ndeploy:\n\t./deploy.sh\n")
+	if got, err := ProjectTargets(projectsDir, "core"); err != nil || !reflect.DeepEqual(got, builtins) {
+		t.Errorf("without .devkit.yml: ProjectTargets = %v, %v; want %v", got, err, builtins)
+	}
+	if IsProjectTarget(projectsDir, "core", "deploy") {
+		t.Error("deploy allowed without being listed in .devkit.yml")
+	}
+
+	// Listed targets are added; options and variable assignments are dropped
+	writeProjectFile(t, projectsDir, "core", ".devkit.yml", "targets: [deploy, proto, \"-n\", \"CC=evil\", \"a b\", build]\n")
+	want := []string{"build", "deploy", "format", "lint", "proto", "test"}
+	if got, err := ProjectTargets(projectsDir, "core"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("with .devkit.yml: ProjectTargets = %v, %v; want %v", got, err, want)
+	}
+	if IsProjectTarget(projectsDir, "core", "CC=evil") {
+		t.Error("variable assignment accepted as a target")
+	}
+
+	// An invalid .devkit.yml falls back to the built-in actions
+	writeProjectFile(t, projectsDir, "core", ".devkit.yml", "targets: [deploy\n")
+	if got, err := ProjectTargets(projectsDir, "core"); err != nil || !reflect.DeepEqual(got, builtins) {
+		t.Errorf("invalid .devkit.yml: ProjectTargets = %v, %v; want %v", got, err, builtins)
+	}
+	if !IsProjectTarget(projectsDir, "core", "build") {
+		t.Error("build rejected with an invalid .devkit.yml")
+	}
+}
@@ -3,7 +3,12 @@ package service
 import (
 	"bufio"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,24 +16,211 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	_ "github.com/lib/pq" // postgres driver for TestConnection
+	"github.com/wabisaby/devkit-dashboard/internal/dotenv"
 	"github.com/wabisaby/devkit-dashboard/internal/model"
 )
 
+// migrationVersionTTL is how long the migrate tool's version probe result is reused by GetStatus
+const migrationVersionTTL = 10 * time.Second
+
+// dbTestTimeout bounds TestConnection
+const dbTestTimeout = 5 * time.Second
+
+// migrationFileRegex matches migration files: NNNNNN_name.up.sql or NNNNNN_name.down.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// migrationNameRegex is what a sanitized migration name may contain
+var migrationNameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// defaultMigrationVersionWidth is the zero-padded width of new version numbers when no migrations exist yet
+const defaultMigrationVersionWidth = 6
+
+// ErrDatabaseDown is returned when a migration is requested while the database is unreachable
+var ErrDatabaseDown = errors.New("database is not running; start PostgreSQL first")
+
 // MigrationService manages database migrations
 type MigrationService struct {
 	wabisabyRoot string
+	dbCheck      func() bool                // reports whether the database is reachable
+	dbStart      func() error               // starts the database and waits for it (auto-start)
+	autoStart    atomic.Bool                // start the database when a migration finds it down
+	probeVersion func() (uint, bool, error) // getCurrentVersion; replaced in tests
+
+	// Cached version probe (go run ./tools/migrate -version compiles the tool each time). The probe
+	// runs without versionMu; its result is only cached if versionGen didn't change meanwhile.
+	versionMu  sync.Mutex
+	version    *migrationVersion
+	versionGen uint64 // bumped by InvalidateStatus
+}
+
+// migrationVersion is a cached result of getCurrentVersion
+type migrationVersion struct {
+	current uint
+	dirty   bool
+	err     error
+	at      time.Time
 }
 
 // NewMigrationService creates a new migration service
 func NewMigrationService(wabisabyRoot string) *MigrationService {
-	return &MigrationService{
+	s := &MigrationService{
 		wabisabyRoot: wabisabyRoot,
 	}
+	s.dbCheck = s.databaseReachable
+	s.probeVersion = s.getCurrentVersion
+	return s
+}
+
+// SetDatabaseCheck replaces the check used to detect a down database before migrating
+func (s *MigrationService) SetDatabaseCheck(fn func() bool) {
+	s.dbCheck = fn
+}
+
+// SetDatabaseStarter sets how the database is started when auto-start is enabled (see SetAutoStart)
+func (s *MigrationService) SetDatabaseStarter(fn func() error) {
+	s.dbStart = fn
+}
+
+// SetAutoStart controls whether migrations start the database when they find it down
+func (s *MigrationService) SetAutoStart(enabled bool) {
+	s.autoStart.Store(enabled)
+}
+
+// CheckDatabase returns ErrDatabaseDown if the database is not reachable
+func (s *MigrationService) CheckDatabase() error {
+	if s.dbCheck != nil && !s.dbCheck() {
+		return ErrDatabaseDown
+	}
+	return nil
+}
+
+// ensureDatabase probes the database once before a migration. When it is down and auto-start is
+// enabled, the database is started and probed again; otherwise ErrDatabaseDown is returned.
+func (s *MigrationService) ensureDatabase() error {
+	err := s.CheckDatabase()
+	if !errors.Is(err, ErrDatabaseDown) || !s.autoStart.Load() || s.dbStart == nil {
+		return err
+	}
+	if err := s.dbStart(); err != nil {
+		return err
+	}
+	return s.CheckDatabase()
+}
+
+// databaseURL returns DATABASE_URL from .env and its host:port (port 5432 if not given)
+func (s *MigrationService) databaseURL() (string, string, error) {
+	envVars, err := loadEnvFile(s.wabisabyRoot)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load .env: %w", err)
+	}
+	for _, e := range envVars {
+		value, ok := strings.CutPrefix(e, "DATABASE_URL=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return "", "", fmt.Errorf("DATABASE_URL is not a valid postgres:// URL")
+		}
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "5432")
+		}
+		return value, host, nil
+	}
+	return "", "", fmt.Errorf("DATABASE_URL is not set in .env")
+}
+
+// databaseReachable dials the host:port from DATABASE_URL in .env; without a usable
+// DATABASE_URL it falls back to the PostgreSQL container status.
+func (s *MigrationService) databaseReachable() bool {
+	_, host, err := s.databaseURL()
+	if err != nil {
+		return CheckServiceStatus("PostgreSQL", 5432, "") == "running"
+	}
+	conn, err := net.DialTimeout("tcp", host, time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
 }
 
-// GetStatus returns the current migration status
+// TestConnection connects to DATABASE_URL from .env and runs SELECT 1, returning a readable error
+// (e.g. "can't connect to Postgres on localhost:5432: ...") if that fails within dbTestTimeout
+func (s *MigrationService) TestConnection() error {
+	dsn, host, err := s.databaseURL()
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTestTimeout)
+	defer cancel()
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) || errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("can't connect to Postgres on %s: %w", host, err)
+		}
+		return fmt.Errorf("postgres on %s: %w", host, err)
+	}
+	return nil
+}
+
+// GetStatus returns the current migration status. The migration files are always re-read; the current
+// version is probed at most every migrationVersionTTL unless a migration ran since (see RefreshStatus).
 func (s *MigrationService) GetStatus() (*model.MigrationStatus, error) {
+	return s.status(false)
+}
+
+// RefreshStatus returns the current migration status, re-probing the current version
+func (s *MigrationService) RefreshStatus() (*model.MigrationStatus, error) {
+	return s.status(true)
+}
+
+// InvalidateStatus drops the cached current version so the next GetStatus probes it again; a probe
+// already in flight is not cached
+func (s *MigrationService) InvalidateStatus() {
+	s.versionMu.Lock()
+	s.version = nil
+	s.versionGen++
+	s.versionMu.Unlock()
+}
+
+// cachedCurrentVersion returns the current version, probing the migrate tool when the cached result is
+// missing, older than migrationVersionTTL or refresh is set
+func (s *MigrationService) cachedCurrentVersion(refresh bool) (uint, bool, error) {
+	s.versionMu.Lock()
+	if v := s.version; !refresh && v != nil && time.Since(v.at) <= migrationVersionTTL {
+		s.versionMu.Unlock()
+		return v.current, v.dirty, v.err
+	}
+	gen := s.versionGen
+	s.versionMu.Unlock()
+
+	current, dirty, err := s.probeVersion()
+
+	s.versionMu.Lock()
+	if s.versionGen == gen {
+		s.version = &migrationVersion{current: current, dirty: dirty, err: err, at: time.Now()}
+	}
+	s.versionMu.Unlock()
+	return current, dirty, err
+}
+
+// status builds the migration status, see GetStatus and RefreshStatus
+func (s *MigrationService) status(refresh bool) (*model.MigrationStatus, error) {
 	status := &model.MigrationStatus{
 		Migrations: []model.Migration{},
 	}
@@ -42,15 +234,13 @@ func (s *MigrationService) GetStatus() (*model.MigrationStatus, error) {
 	}
 
 	// Parse migration files
-	// Format: NNNNNN_name.up.sql or NNNNNN_name.down.sql
-	versionRegex := regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
 	migrationMap := make(map[uint]string)
 
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
-		matches := versionRegex.FindStringSubmatch(entry.Name())
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
 		if len(matches) == 4 && matches[3] == "up" {
 			version, _ := strconv.ParseUint(matches[1], 10, 32)
 			name := matches[2]
@@ -68,7 +258,7 @@ func (s *MigrationService) GetStatus() (*model.MigrationStatus, error) {
 	})
 
 	// Try to get current version by running migrate tool
-	currentVersion, dirty, err := s.getCurrentVersion()
+	currentVersion, dirty, err := s.cachedCurrentVersion(refresh)
 	if err != nil {
 		// If we can't get the current version, just show all as not applied
 		status.Error = fmt.Sprintf("Could not determine current version: %v", err)
@@ -89,6 +279,69 @@ func (s *MigrationService) GetStatus() (*model.MigrationStatus, error) {
 	return status, nil
 }
 
+// Create writes an empty up/down migration pair named name with the next version number and returns
+// their paths. The name is lowercased with spaces and dashes turned into underscores; anything else
+// outside [a-z0-9_] is rejected.
+func (s *MigrationService) Create(name string) ([]string, error) {
+	clean := sanitizeMigrationName(name)
+	if !migrationNameRegex.MatchString(clean) {
+		return nil, fmt.Errorf("invalid migration name %q: use letters, digits, spaces, dashes or underscores", name)
+	}
+
+	migrationsDir := filepath.Join(s.wabisabyRoot, "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var latest uint64
+	width := defaultMigrationVersionWidth
+	for _, entry := range entries {
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		if version >= latest {
+			latest = version
+			width = len(matches[1]) // keep the existing padding
+		}
+	}
+	prefix := fmt.Sprintf("%0*d_%s", width, latest+1, clean)
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(migrationsDir, fmt.Sprintf("%s.%s.sql", prefix, direction))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			for _, p := range paths {
+				_ = os.Remove(p)
+			}
+			if os.IsExist(err) {
+				return nil, fmt.Errorf("migration %s already exists", filepath.Base(path))
+			}
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+		}
+		_ = f.Close()
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// sanitizeMigrationName lowercases name and replaces runs of spaces and dashes with one underscore
+func sanitizeMigrationName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(strings.TrimSpace(name)), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '\t'
+	})
+	return strings.Join(fields, "_")
+}
+
 // getCurrentVersion gets the current migration version by running the migrate tool
 func (s *MigrationService) getCurrentVersion() (uint, bool, error) {
 	// Load .env to get DATABASE_URL
@@ -139,26 +392,93 @@ func (s *MigrationService) parseVersionFromStatus() (uint, bool, error) {
 
 // Up runs all pending migrations
 func (s *MigrationService) Up() (string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return "", err
+	}
 	return s.runMigration("-up")
 }
 
 // Down rolls back the last migration
 func (s *MigrationService) Down() (string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return "", err
+	}
 	return s.runMigration("-down")
 }
 
-// runMigration executes the migrate tool with the given flag
-func (s *MigrationService) runMigration(flag string) (string, error) {
+// DownN rolls back the last n migrations; n must be between 1 and the number of applied migrations
+func (s *MigrationService) DownN(n int) (string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return "", err
+	}
+	if err := s.checkDownN(n); err != nil {
+		return "", err
+	}
+	return s.runMigration("-down", strconv.Itoa(n))
+}
+
+// checkDownN returns an error unless 1 <= n <= the number of applied migrations
+func (s *MigrationService) checkDownN(n int) error {
+	if n < 1 {
+		return fmt.Errorf("number of migrations to roll back must be at least 1")
+	}
+	status, err := s.GetStatus()
+	if err != nil {
+		return err
+	}
+	applied := 0
+	for _, m := range status.Migrations {
+		if m.Applied {
+			applied++
+		}
+	}
+	if n > applied {
+		return fmt.Errorf("cannot roll back %d migrations: only %d applied (current version %d)", n, applied, status.CurrentVersion)
+	}
+	return nil
+}
+
+// Goto migrates up or down to the given version, which must be one of the migrations in GetStatus
+func (s *MigrationService) Goto(version uint) (string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return "", err
+	}
+	if err := s.checkGotoVersion(version); err != nil {
+		return "", err
+	}
+	return s.runMigration("-goto", strconv.FormatUint(uint64(version), 10))
+}
+
+// checkGotoVersion returns an error unless version is a known migration version
+func (s *MigrationService) checkGotoVersion(version uint) error {
+	status, err := s.GetStatus()
+	if err != nil {
+		return err
+	}
+	for _, m := range status.Migrations {
+		if m.Version == version {
+			return nil
+		}
+	}
+	if status.Error != "" && len(status.Migrations) == 0 {
+		return fmt.Errorf("cannot validate migration version %d: %s", version, status.Error)
+	}
+	return fmt.Errorf("migration version %d does not exist", version)
+}
+
+// runMigration executes the migrate tool with the given flag (and flag value, e.g. "-goto", "3")
+func (s *MigrationService) runMigration(args ...string) (string, error) {
 	envVars, err := loadEnvFile(s.wabisabyRoot)
 	if err != nil {
 		return "", fmt.Errorf("failed to load .env: %w", err)
 	}
 
-	cmd := exec.Command("go", "run", "./tools/migrate", flag)
+	cmd := exec.Command("go", append([]string{"run", "./tools/migrate"}, args...)...)
 	cmd.Dir = s.wabisabyRoot
 	cmd.Env = append(envForGoRun(), envVars...)
 
 	output, err := cmd.CombinedOutput()
+	s.InvalidateStatus()
 	if err != nil {
 		return string(output), fmt.Errorf("migration failed: %w\n%s", err, string(output))
 	}
@@ -168,24 +488,56 @@ func (s *MigrationService) runMigration(flag string) (string, error) {
 
 // UpStream runs migrations and streams output
 func (s *MigrationService) UpStream(ctx context.Context) (<-chan string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return nil, err
+	}
 	return s.runMigrationStream(ctx, "-up")
 }
 
 // DownStream rolls back migrations and streams output
 func (s *MigrationService) DownStream(ctx context.Context) (<-chan string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return nil, err
+	}
 	return s.runMigrationStream(ctx, "-down")
 }
 
-// runMigrationStream executes the migrate tool and streams output
-func (s *MigrationService) runMigrationStream(ctx context.Context, flag string) (<-chan string, error) {
+// DownNStream rolls back the last n migrations and streams output (see DownN)
+func (s *MigrationService) DownNStream(ctx context.Context, n int) (<-chan string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return nil, err
+	}
+	if err := s.checkDownN(n); err != nil {
+		return nil, err
+	}
+	return s.runMigrationStream(ctx, "-down", strconv.Itoa(n))
+}
+
+// GotoStream migrates up or down to the given version and streams output; the version must be
+// one of the migrations in GetStatus
+func (s *MigrationService) GotoStream(ctx context.Context, version uint) (<-chan string, error) {
+	if err := s.ensureDatabase(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGotoVersion(version); err != nil {
+		return nil, err
+	}
+	return s.runMigrationStream(ctx, "-goto", strconv.FormatUint(uint64(version), 10))
+}
+
+// runMigrationStream executes the migrate tool and streams output. Callers check the database first.
+func (s *MigrationService) runMigrationStream(ctx context.Context, args ...string) (<-chan string, error) {
 	envVars, err := loadEnvFile(s.wabisabyRoot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load .env: %w", err)
 	}
 
-	cmd := exec.CommandContext(ctx, "go", "run", "./tools/migrate", flag)
+	cmd := exec.CommandContext(ctx, "go", append([]string{"run", "./tools/migrate"}, args...)...)
 	cmd.Dir = s.wabisabyRoot
 	cmd.Env = append(envForGoRun(), envVars...)
+	// "go run" execs the compiled migrate binary as a child; run both in their own process group
+	// so cancelling terminates the actual migration, not just the go tool.
+	CancelProcessGroup(cmd)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -204,42 +556,46 @@ func (s *MigrationService) runMigrationStream(ctx context.Context, flag string)
 		return nil, fmt.Errorf("failed to start migration: %w", err)
 	}
 
-	go func() {
-		defer close(ch)
-
-		// Read stdout
-		go func() {
-			scanner := bufio.NewScanner(stdout)
-			for scanner.Scan() {
-				select {
-				case ch <- scanner.Text():
-				case <-ctx.Done():
-					return
-				}
-			}
-		}()
-
-		// Read stderr
-		scanner := bufio.NewScanner(stderr)
+	// forward sends lines until ctx is cancelled, then keeps draining so the process never blocks on a full pipe
+	forward := func(r io.Reader, prefix string) {
+		scanner := bufio.NewScanner(r)
 		for scanner.Scan() {
+			if ctx.Err() != nil {
+				continue
+			}
 			select {
-			case ch <- "[stderr] " + scanner.Text():
+			case ch <- prefix + scanner.Text():
 			case <-ctx.Done():
-				return
 			}
 		}
+	}
+
+	go func() {
+		// ch is closed only once the process has exited, so receivers can rely on it being gone
+		defer close(ch)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			forward(stdout, "")
+		}()
+		go func() {
+			defer wg.Done()
+			forward(stderr, "[stderr] ")
+		}()
+		wg.Wait()
 
 		// Wait for completion
-		if err := cmd.Wait(); err != nil {
-			select {
-			case ch <- fmt.Sprintf("[error] Migration failed: %v", err):
-			case <-ctx.Done():
-			}
-		} else {
-			select {
-			case ch <- "[done] Migration completed successfully":
-			case <-ctx.Done():
-			}
+		err := cmd.Wait()
+		s.InvalidateStatus()
+		line := "[done] Migration completed successfully"
+		if err != nil {
+			line = fmt.Sprintf("[error] Migration failed: %v", err)
+		}
+		select {
+		case ch <- line:
+		case <-ctx.Done():
 		}
 	}()
 
@@ -248,23 +604,9 @@ func (s *MigrationService) runMigrationStream(ctx context.Context, flag string)
 
 // loadEnvFile is a helper to load .env file (shared with process.go)
 func loadEnvFile(wabisabyRoot string) ([]string, error) {
-	envPath := filepath.Join(wabisabyRoot, ".env")
-	data, err := os.ReadFile(envPath)
+	data, err := os.ReadFile(filepath.Join(wabisabyRoot, ".env"))
 	if err != nil {
 		return nil, err
 	}
-
-	var envVars []string
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		if strings.Contains(line, "=") {
-			envVars = append(envVars, line)
-		}
-	}
-
-	return envVars, nil
+	return dotenv.Parse(data).Environ(), nil
 }
@@ -0,0 +1,134 @@
+//go:build !windows
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeGoScript stands in for "go run ./tools/migrate". -version prints the contents of ./version;
+// a migration marks the schema dirty, then (like go run) leaves the real work to a child process
+// whose PID it records in ./child.pid, and waits for it.
+const fakeGoScript = `#!/bin/sh
+for last; do :; done
+if [ "$last" = "-version" ]; then
+	cat version
+	exit 0
+fi
+echo "2 dirty" > version
+sleep 60 &
+echo $! > child.pid
+echo "applying 000002_add_users"
+wait
+`
+
+// newFakeMigrateRoot returns a wabisaby root whose migrations run fakeGoScript, at version 1
+func newFakeMigrateRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "go"), []byte(fakeGoScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	for name, content := range map[string]string{
+		".env":                               "DATABASE_URL=postgres://localhost:5432/wabisaby\n",
+		"version":                            "1\n",
+		"migrations/000001_init.up.sql":      "",
+		"migrations/000002_add_users.up.sql": "",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// processGone reports whether pid has exited (a zombie awaiting its reaper counts as gone)
+func processGone(pid int) bool {
+	if !processAlive(pid) {
+		return true
+	}
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	// Format: pid (comm) state ...
+	s := string(stat)
+	i := strings.LastIndexByte(s, ')')
+	return i >= 0 && i+2 < len(s) && s[i+2] == 'Z'
+}
+
+func TestMigrationStreamCancelKillsProcessGroup(t *testing.T) {
+	root := newFakeMigrateRoot(t)
+	s := NewMigrationService(root)
+	s.SetDatabaseCheck(func() bool { return true })
+
+	before, err := s.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.CurrentVersion != 1 || before.Dirty {
+		t.Fatalf("status before = version %d dirty %v, want 1 clean (%s)", before.CurrentVersion, before.Dirty, before.Error)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := s.UpStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case line := <-ch:
+		if line != "applying 000002_add_users" {
+			t.Fatalf("first line = %q", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no output from the migration")
+	}
+	data, err := os.ReadFile(filepath.Join(root, "child.pid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	// The channel closes only once the process has exited; well before processGroupKillDelay, since
+	// SIGTERM reaches the whole group
+	deadline := time.After(processGroupKillDelay - time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-ch:
+		case <-deadline:
+			t.Fatal("migration still running after cancel")
+		}
+	}
+	for i := 0; !processGone(child); i++ {
+		if i == 50 {
+			t.Fatalf("migration child %d survived cancel", child)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// The run invalidated the cached probe, so the status reflects what the cancelled migration left
+	after, err := s.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.CurrentVersion != 2 || !after.Dirty {
+		t.Errorf("status after cancel = version %d dirty %v, want 2 dirty (%s)", after.CurrentVersion, after.Dirty, after.Error)
+	}
+}
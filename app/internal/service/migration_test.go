@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeDatabase stands in for PostgreSQL: probe reports up, start brings it up (or fails with startErr)
+type fakeDatabase struct {
+	up       bool
+	startErr error
+	probes   int
+	starts   int
+}
+
+func (d *fakeDatabase) probe() bool {
+	d.probes++
+	return d.up
+}
+
+func (d *fakeDatabase) start() error {
+	d.starts++
+	if d.startErr != nil {
+		return d.startErr
+	}
+	d.up = true
+	return nil
+}
+
+func newTestMigrationService(db *fakeDatabase, autoStart bool) *MigrationService {
+	s := NewMigrationService(testWabisabyRoot)
+	s.SetDatabaseCheck(db.probe)
+	s.SetDatabaseStarter(db.start)
+	s.SetAutoStart(autoStart)
+	return s
+}
+
+// testWabisabyRoot is a wabisaby root that doesn't exist; no test here gets far enough to run the migrate tool
+const testWabisabyRoot = "/nonexistent/wabisaby-core"
+
+func TestEnsureDatabase(t *testing.T) {
+	startFailed := errors.New("failed to start PostgreSQL")
+	tests := []struct {
+		name       string
+		db         fakeDatabase
+		autoStart  bool
+		wantErr    error
+		wantProbes int
+		wantStarts int
+	}{
+		{name: "up", db: fakeDatabase{up: true}, wantProbes: 1},
+		{name: "up with auto-start", db: fakeDatabase{up: true}, autoStart: true, wantProbes: 1},
+		{name: "down", db: fakeDatabase{}, wantErr: ErrDatabaseDown, wantProbes: 1},
+		{name: "down with auto-start", db: fakeDatabase{}, autoStart: true, wantProbes: 2, wantStarts: 1},
+		{name: "auto-start fails", db: fakeDatabase{startErr: startFailed}, autoStart: true, wantErr: startFailed, wantProbes: 1, wantStarts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := tt.db
+			s := newTestMigrationService(&db, tt.autoStart)
+			err := s.ensureDatabase()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ensureDatabase() = %v, want %v", err, tt.wantErr)
+			}
+			if db.probes != tt.wantProbes || db.starts != tt.wantStarts {
+				t.Errorf("probes = %d, starts = %d; want %d, %d", db.probes, db.starts, tt.wantProbes, tt.wantStarts)
+			}
+		})
+	}
+}
+
+func TestMigrationsProbeDatabaseOnce(t *testing.T) {
+	ops := map[string]func(s *MigrationService) error{
+		"Up":          func(s *MigrationService) error { _, err := s.Up(); return err },
+		"Down":        func(s *MigrationService) error { _, err := s.Down(); return err },
+		"DownN":       func(s *MigrationService) error { _, err := s.DownN(2); return err },
+		"Goto":        func(s *MigrationService) error { _, err := s.Goto(3); return err },
+		"UpStream":    func(s *MigrationService) error { _, err := s.UpStream(context.Background()); return err },
+		"DownStream":  func(s *MigrationService) error { _, err := s.DownStream(context.Background()); return err },
+		"DownNStream": func(s *MigrationService) error { _, err := s.DownNStream(context.Background(), 2); return err },
+		"GotoStream":  func(s *MigrationService) error { _, err := s.GotoStream(context.Background(), 3); return err },
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			db := &fakeDatabase{}
+			s := newTestMigrationService(db, false)
+			if err := op(s); !errors.Is(err, ErrDatabaseDown) {
+				t.Fatalf("%s with the database down = %v, want ErrDatabaseDown", name, err)
+			}
+			if db.probes != 1 {
+				t.Errorf("%s probed the database %d times, want 1", name, db.probes)
+			}
+		})
+	}
+}
+
+func TestSetAutoStartTakesEffect(t *testing.T) {
+	db := &fakeDatabase{}
+	s := newTestMigrationService(db, false)
+	if err := s.ensureDatabase(); !errors.Is(err, ErrDatabaseDown) {
+		t.Fatalf("ensureDatabase() = %v, want ErrDatabaseDown", err)
+	}
+	s.SetAutoStart(true)
+	if err := s.ensureDatabase(); err != nil {
+		t.Fatalf("ensureDatabase() after SetAutoStart(true) = %v", err)
+	}
+	if db.starts != 1 {
+		t.Errorf("starts = %d, want 1", db.starts)
+	}
+}
+
+func TestInvalidateStatusDuringProbe(t *testing.T) {
+	s := NewMigrationService(testWabisabyRoot)
+	probing, release := make(chan struct{}), make(chan struct{})
+	var probes atomic.Int32
+	s.probeVersion = func() (uint, bool, error) {
+		if probes.Add(1) == 1 {
+			close(probing)
+			<-release
+			return 1, false, nil // from before the migration below
+		}
+		return 2, false, nil
+	}
+
+	done := make(chan uint)
+	go func() {
+		v, _, _ := s.cachedCurrentVersion(false)
+		done <- v
+	}()
+	<-probing
+	// A migration finishes while the probe runs; invalidating must not wait for the probe
+	s.InvalidateStatus()
+	close(release)
+	if v := <-done; v != 1 {
+		t.Fatalf("in-flight probe returned %d, want 1", v)
+	}
+
+	if v, _, _ := s.cachedCurrentVersion(false); v != 2 {
+		t.Errorf("version after invalidation = %d, want a fresh probe (2), not the stale result", v)
+	}
+	if v, _, _ := s.cachedCurrentVersion(false); v != 2 || probes.Load() != 2 {
+		t.Errorf("version = %d after %d probes, want the fresh result cached", v, probes.Load())
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,11 +13,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/wabisaby/devkit-dashboard/internal/config"
+	"github.com/wabisaby/devkit-dashboard/internal/model"
 )
 
 const (
@@ -24,6 +27,16 @@ const (
 	portRegistryFile = "started-ports.json"
 	portFreeWaitMax  = 3 * time.Second
 	portFreePoll     = 100 * time.Millisecond
+
+	healthProbeTimeout = 1 * time.Second
+
+	readinessPollInterval = 200 * time.Millisecond
+	startGracePeriod      = 500 * time.Millisecond // readiness wait for services without a port
+
+	processGroupKillDelay = 5 * time.Second // SIGTERM to SIGKILL for cancelled stream commands
+
+	defaultStopTimeout    = 10 * time.Second // Stop/StopAll: SIGTERM to SIGKILL, per service
+	defaultStopAllTimeout = 15 * time.Second // StopAll (app quit): overall deadline before remaining services are killed
 )
 
 // ProcessState represents the state of a managed process
@@ -47,12 +60,15 @@ type ManagedProcess struct {
 	Cmd       *exec.Cmd
 	StartTime time.Time
 	Error     error
+	Adopted   bool // found running on its port rather than started by us (start time unknown)
+
+	restarts int // times the service's process exited unexpectedly (carried over to its next process)
 
 	// Log streaming
 	logMu          sync.RWMutex
-	subscribers    map[chan string]struct{}
+	subscribers    map[chan model.LogLine]struct{}
 	done           chan struct{}
-	lastOutput     []string          // last N lines of stdout/stderr for failed services
+	lastOutput     []model.LogLine   // last N lines of stdout/stderr for failed services
 	onActivityLine func(line string) // optional; called for each line for Activity feed
 }
 
@@ -62,15 +78,53 @@ type BackendExitCallback func(serviceName string, err error, lastOutput []string
 // ActivityLineCallback is called for each stdout/stderr line from a backend (optional, for Activity feed).
 type ActivityLineCallback func(serviceName string, line string)
 
+// ProcessInfo is a point-in-time view of a tracked service process
+type ProcessInfo struct {
+	Status     string
+	PID        int // 0 unless running
+	Error      string
+	LastOutput []string
+	Uptime     time.Duration
+	Restarts   int
+	Adopted    bool
+}
+
 // ProcessManager tracks running Go processes
 type ProcessManager struct {
-	mu             sync.RWMutex
-	processes      map[string]*ManagedProcess
-	wabisabyRoot   string
-	projectsDir    string
-		envRoot        string // directory to load .env from (e.g. devkit repo root)
-	onExit         BackendExitCallback
-	onActivityLine ActivityLineCallback
+	mu              sync.RWMutex
+	processes       map[string]*ManagedProcess
+	wabisabyRoot    string
+	projectsDir     string
+	envRoot         string // directory to load .env from (e.g. devkit repo root)
+	onExit          BackendExitCallback
+	onActivityLine  ActivityLineCallback
+	httpClient      *http.Client        // used for health probes
+	portOwner       PortOwnerFunc       // finds the PID listening on a port (Reconcile)
+	processIdentity ProcessIdentityFunc // identifies a port owner before Reconcile adopts it
+	buildMode       string              // config.BackendBuildModeRun (default) or config.BackendBuildModeBuild
+	building        map[string]bool
+	stopTimeout     time.Duration // SIGTERM to SIGKILL, per service
+	stopAllTimeout  time.Duration // overall StopAll deadline
+}
+
+// SetStopTimeouts sets how long a stopping service gets to exit after SIGTERM before it is
+// force-killed, and the overall deadline for StopAll. Non-positive values keep the current setting.
+func (pm *ProcessManager) SetStopTimeouts(perService, overall time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if perService > 0 {
+		pm.stopTimeout = perService
+	}
+	if overall > 0 {
+		pm.stopAllTimeout = overall
+	}
+}
+
+// SetHTTPClient replaces the client used for health probes (e.g. to inject a transport in tests).
+func (pm *ProcessManager) SetHTTPClient(client *http.Client) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.httpClient = client
 }
 
 // SetOnExit sets a callback invoked when a backend service process exits (e.g. to emit to Activity).
@@ -94,10 +148,17 @@ func NewProcessManager(wabisabyRoot string, projectsDir string, envRoot string)
 		envRoot = wabisabyRoot
 	}
 	pm := &ProcessManager{
-		processes:    make(map[string]*ManagedProcess),
-		wabisabyRoot: wabisabyRoot,
-		projectsDir:  projectsDir,
-		envRoot:      envRoot,
+		processes:       make(map[string]*ManagedProcess),
+		building:        make(map[string]bool),
+		wabisabyRoot:    wabisabyRoot,
+		projectsDir:     projectsDir,
+		envRoot:         envRoot,
+		httpClient:      sharedHTTPClient,
+		portOwner:       lsofPortOwner,
+		processIdentity: psProcessIdentity,
+
+		stopTimeout:    defaultStopTimeout,
+		stopAllTimeout: defaultStopAllTimeout,
 	}
 	pm.freePortsFromRegistry()
 	return pm
@@ -200,13 +261,34 @@ func (pm *ProcessManager) recordPortStopped(serviceName string) {
 	_ = pm.savePortRegistry(reg)
 }
 
-// Start starts a WabiSaby-Go service
+// Start starts a WabiSaby-Go service with "go run", or via BuildAndStart in build mode (see SetBuildMode)
 func (pm *ProcessManager) Start(serviceName string) error {
+	pm.mu.RLock()
+	mode := pm.buildMode
+	pm.mu.RUnlock()
+	if mode == config.BackendBuildModeBuild {
+		return pm.BuildAndStart(serviceName)
+	}
+	return pm.start(serviceName, nil)
+}
+
+// start runs the service with "go run", or runs build's binary when build is non-nil, and waits for it
+// to become ready. The build's temp files are removed when the process exits (or if it fails to start).
+func (pm *ProcessManager) start(serviceName string, build *serviceBuild) error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
+	started := false
+	if build != nil {
+		defer func() {
+			if !started {
+				build.cleanup()
+			}
+		}()
+	}
+
 	// Check if already running
-	if proc, exists := pm.processes[serviceName]; exists && proc.State == ProcessRunning {
+	if proc, exists := pm.processes[serviceName]; exists && (proc.State == ProcessRunning || proc.State == ProcessStarting) {
 		return fmt.Errorf("service %s is already running", serviceName)
 	}
 
@@ -224,37 +306,15 @@ func (pm *ProcessManager) Start(serviceName string) error {
 		}
 	}
 
-	// Load .env file
-	envVars, err := pm.loadEnvFile()
-	if err != nil {
-		log.Printf("Warning: failed to load .env file: %v", err)
-		// Continue without .env - some vars might be set in environment
-	}
-
-	// Node: default IPFS API to port 5011 so it doesn't conflict with system IPFS or other nodes on 5001
-	if serviceName == "node" {
-		hasIPFSAPI := false
-		for _, e := range envVars {
-			if strings.HasPrefix(e, "WABISABY_NODE_IPFS_API_URL=") {
-				hasIPFSAPI = true
-				break
-			}
-		}
-		if !hasIPFSAPI {
-			envVars = append(envVars, "WABISABY_NODE_IPFS_API_URL=http://localhost:5011")
-		}
-	}
-
 	// Create command
-	cmd := exec.Command("go", "run", svcConfig.CmdPath)
-	// Use the service's repo directory if specified, otherwise default to wabisaby-core
-	if svcConfig.RepoName != "" {
-		cmd.Dir = filepath.Join(pm.projectsDir, svcConfig.RepoName)
+	var cmd *exec.Cmd
+	if build != nil {
+		cmd = exec.Command(build.binPath)
 	} else {
-		cmd.Dir = pm.wabisabyRoot
+		cmd = exec.Command("go", "run", svcConfig.CmdPath)
 	}
-	// Use GOTOOLCHAIN=auto so the project's go.mod toolchain requirement is respected (e.g. 1.24.4)
-	cmd.Env = append(envForGoRun(), envVars...)
+	cmd.Dir = pm.serviceDir(svcConfig)
+	cmd.Env = pm.serviceEnv(svcConfig)
 
 	// Set up process group for clean termination (Unix only)
 	setSysProcAttr(cmd)
@@ -275,7 +335,7 @@ func (pm *ProcessManager) Start(serviceName string) error {
 		Name:        serviceName,
 		State:       ProcessStarting,
 		Cmd:         cmd,
-		subscribers: make(map[chan string]struct{}),
+		subscribers: make(map[chan model.LogLine]struct{}),
 		done:        make(chan struct{}),
 	}
 	if pm.onActivityLine != nil {
@@ -284,30 +344,47 @@ func (pm *ProcessManager) Start(serviceName string) error {
 		proc.onActivityLine = func(line string) { cb(name, line) }
 	}
 
+	if prev, exists := pm.processes[serviceName]; exists {
+		proc.restarts = prev.restarts
+	}
+	if build != nil {
+		proc.lastOutput = build.output // build log stays visible (and replayed) after start
+	}
+
 	// Start process
 	if err := cmd.Start(); err != nil {
 		proc.State = ProcessError
 		proc.Error = err
 		return fmt.Errorf("failed to start process: %w", err)
 	}
+	started = true
 
 	proc.PID = cmd.Process.Pid
 	proc.StartTime = time.Now()
+	pm.processes[serviceName] = proc // visible as "starting" while we wait for readiness
 
 	// Start log capture goroutines
-	go proc.captureOutput(stdout, "")
-	go proc.captureOutput(stderr, "[stderr] ")
+	go proc.captureOutput(stdout, LogStreamStdout)
+	go proc.captureOutput(stderr, LogStreamStderr)
 
 	// Monitor process
 	go func() {
 		err := cmd.Wait()
+		if build != nil {
+			build.cleanup()
+		}
 		pm.mu.Lock()
 
 		close(proc.done)
 
+		// Exiting on the signal Stop sent is a requested stop, not a crash
+		if proc.State == ProcessStopping {
+			err = nil
+		}
 		if err != nil {
 			proc.State = ProcessError
 			proc.Error = err
+			proc.restarts++ // a crash, so starting it again is a restart; a requested stop isn't
 			log.Printf("Service %s exited with error: %v", serviceName, err)
 		} else {
 			proc.State = ProcessStopped
@@ -315,15 +392,11 @@ func (pm *ProcessManager) Start(serviceName string) error {
 		}
 
 		// Notify subscribers that logs are done
-		proc.broadcast("[Process exited]")
+		proc.broadcast(newLogLine(serviceName, LogStreamSystem, "[Process exited]"))
 
 		// Copy lastOutput and invoke exit callback for Activity (must not hold logMu long)
-		var exitOutput []string
 		proc.logMu.RLock()
-		if len(proc.lastOutput) > 0 {
-			exitOutput = make([]string, len(proc.lastOutput))
-			copy(exitOutput, proc.lastOutput)
-		}
+		exitOutput := proc.lastOutputText()
 		proc.logMu.RUnlock()
 		cb := pm.onExit
 		pm.mu.Unlock()
@@ -340,46 +413,117 @@ func (pm *ProcessManager) Start(serviceName string) error {
 		}
 	}()
 
-	// Wait briefly to detect immediate failures
-	time.Sleep(500 * time.Millisecond)
-
+	// Wait for the service to become ready (or exit) without holding the lock
 	pm.mu.Unlock()
+	pm.waitReady(proc, svcConfig)
 	pm.mu.Lock()
 
-	if proc.State == ProcessError {
+	switch proc.State {
+	case ProcessStarting:
+	case ProcessError:
 		return proc.Error
+	case ProcessStopping:
+		return fmt.Errorf("service %s was stopped during startup", serviceName)
+	default:
+		return fmt.Errorf("service %s exited during startup", serviceName)
 	}
 
 	proc.State = ProcessRunning
-	pm.processes[serviceName] = proc
 	pm.recordPortStarted(serviceName, svcConfig.Port)
 	log.Printf("Started service %s (PID: %d)", serviceName, proc.PID)
 
 	return nil
 }
 
+// serviceDir returns the directory a service is built and run from: its repo under projectsDir if
+// specified, otherwise wabisaby-core
+func (pm *ProcessManager) serviceDir(svcConfig *config.BackendServiceConfig) string {
+	if svcConfig.RepoName != "" {
+		return filepath.Join(pm.projectsDir, svcConfig.RepoName)
+	}
+	return pm.wabisabyRoot
+}
+
+// waitReady blocks until the starting service is ready or its process exits. A service with a health
+// endpoint is ready once it answers with a healthy status, one with only a port once the port accepts
+// connections; either way a process still alive after the service's start timeout counts as ready.
+// A service without a port is ready if it survives startGracePeriod.
+func (pm *ProcessManager) waitReady(proc *ManagedProcess, svc *config.BackendServiceConfig) {
+	timeout := svc.StartupTimeout()
+	if svc.Port <= 0 {
+		timeout = startGracePeriod
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-proc.done:
+			return
+		case <-deadline.C:
+			return
+		case <-ticker.C:
+			if svc.Port <= 0 {
+				continue
+			}
+			if svc.HealthPath != "" {
+				if pm.ProbeHealth(*svc) {
+					return
+				}
+			} else if pm.IsPortInUse(svc.Port) {
+				return
+			}
+		}
+	}
+}
+
 // Stop stops a WabiSaby-Go service
 func (pm *ProcessManager) Stop(serviceName string) error {
+	pm.mu.RLock()
+	timeout := pm.stopTimeout
+	pm.mu.RUnlock()
+	_, err := pm.stop(serviceName, timeout)
+	return err
+}
+
+// stop sends the service's process group SIGTERM and force-kills it when it hasn't exited after
+// timeout (immediately when timeout <= 0). Reports whether it had to be force-killed.
+func (pm *ProcessManager) stop(serviceName string, timeout time.Duration) (forced bool, err error) {
 	pm.mu.Lock()
 	proc, exists := pm.processes[serviceName]
 	if !exists || (proc.State != ProcessRunning && proc.State != ProcessStarting) {
 		pm.mu.Unlock()
-		return nil
+		return false, nil
 	}
 	proc.State = ProcessStopping
 	pm.mu.Unlock()
 
-	// Send SIGTERM (or equivalent) to process group
-	terminateProcess(proc.Cmd)
+	terminate, forceKill := terminateProcess, forceKillProcess
+	if proc.Adopted {
+		// Not our child: its process group may hold unrelated processes, so only the PID is signalled
+		terminate, forceKill = terminateSingleProcess, forceKillSingleProcess
+	}
 
-	// Wait with timeout
-	select {
-	case <-proc.done:
-		// Clean exit
-	case <-time.After(10 * time.Second):
-		// Force kill
-		forceKillProcess(proc.Cmd)
+	if timeout <= 0 {
+		forceKill(proc.Cmd)
 		<-proc.done
+		forced = true
+	} else {
+		// Send SIGTERM (or equivalent) to process group
+		terminate(proc.Cmd)
+
+		// Wait with timeout
+		select {
+		case <-proc.done:
+			// Clean exit
+		case <-time.After(timeout):
+			// Force kill
+			forceKill(proc.Cmd)
+			<-proc.done
+			forced = true
+		}
 	}
 
 	pm.mu.Lock()
@@ -387,12 +531,44 @@ func (pm *ProcessManager) Stop(serviceName string) error {
 	pm.recordPortStopped(serviceName)
 	pm.mu.Unlock()
 
-	log.Printf("Stopped service %s", serviceName)
-	return nil
+	if forced && timeout <= 0 {
+		log.Printf("Force-killed service %s (stop deadline passed)", serviceName)
+	} else if forced {
+		log.Printf("Force-killed service %s after %s", serviceName, timeout)
+	} else {
+		log.Printf("Stopped service %s", serviceName)
+	}
+	return forced, nil
 }
 
-// StopAll stops all running services
-func (pm *ProcessManager) StopAll() error {
+// Restart stops the service (if running), waits for it to exit and starts it again. Log subscribers of
+// the old process receive a final line and have their channels closed, so they can resubscribe to the
+// new process instead of waiting on one that will never log again.
+func (pm *ProcessManager) Restart(serviceName string) error {
+	if config.GetServiceByName(serviceName) == nil {
+		return fmt.Errorf("unknown service: %s", serviceName)
+	}
+
+	if err := pm.Stop(serviceName); err != nil {
+		return fmt.Errorf("failed to stop %s: %w", serviceName, err)
+	}
+
+	pm.mu.RLock()
+	proc, exists := pm.processes[serviceName]
+	pm.mu.RUnlock()
+	if exists {
+		proc.closeSubscribers(newLogLine(serviceName, LogStreamSystem, fmt.Sprintf("[Restarting %s]", serviceName)))
+	}
+
+	return pm.Start(serviceName)
+}
+
+// StopAll stops all running services (on app quit) in reverse dependency order, so no service loses
+// a dependency while it is still running: each wave of services nothing else running depends on is
+// stopped concurrently, each getting the per-service stop timeout before it is force-killed. Once
+// the overall StopAll deadline passes, services still running are killed without waiting. Logs
+// which services stopped cleanly and returns the (sorted) ones that had to be killed.
+func (pm *ProcessManager) StopAll() []string {
 	pm.mu.RLock()
 	names := make([]string, 0, len(pm.processes))
 	for name, proc := range pm.processes {
@@ -400,19 +576,98 @@ func (pm *ProcessManager) StopAll() error {
 			names = append(names, name)
 		}
 	}
+	perService, overall := pm.stopTimeout, pm.stopAllTimeout
 	pm.mu.RUnlock()
+	if len(names) == 0 {
+		return nil
+	}
+	deadline := time.Now().Add(overall)
+
+	var mu sync.Mutex
+	var clean, killed []string
+	for _, wave := range stopWaves(names) {
+		var wg sync.WaitGroup
+		timeout := perService
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+		for _, name := range wave {
+			wg.Add(1)
+			go func(n string) {
+				defer wg.Done()
+				forced, _ := pm.stop(n, timeout)
+				mu.Lock()
+				if forced {
+					killed = append(killed, n)
+				} else {
+					clean = append(clean, n)
+				}
+				mu.Unlock()
+			}(name)
+		}
+		wg.Wait()
+	}
 
-	var wg sync.WaitGroup
+	sort.Strings(clean)
+	sort.Strings(killed)
+	if len(killed) > 0 {
+		log.Printf("Shutdown: stopped %d service(s) cleanly (%s); force-killed %s",
+			len(clean), strings.Join(clean, ", "), strings.Join(killed, ", "))
+	} else {
+		log.Printf("Shutdown: stopped %d service(s) cleanly (%s)", len(clean), strings.Join(clean, ", "))
+	}
+	return killed
+}
+
+// stopWaves splits services into stop waves in reverse start order, like StopGroup: in the
+// config.OrderByDependencies order each service's level is one more than its deepest dependency's
+// (counting dependencies that aren't being stopped, so transitive ones are respected), and waves
+// stop the highest level first, so dependents stop before their dependencies. When the services
+// can't be ordered (a dependency cycle or unknown service) they all stop in a single wave.
+func stopWaves(names []string) [][]string {
+	var services []config.BackendServiceConfig
 	for _, name := range names {
-		wg.Add(1)
-		go func(n string) {
-			defer wg.Done()
-			pm.Stop(n)
-		}(name)
+		if svc := config.GetServiceByName(name); svc != nil {
+			services = append(services, *svc)
+		}
+	}
+	ordered, err := config.OrderByDependencies(services)
+	if err != nil {
+		log.Printf("Stopping %s together: %v", strings.Join(names, ", "), err)
+		wave := append([]string(nil), names...)
+		sort.Strings(wave)
+		return [][]string{wave}
 	}
-	wg.Wait()
 
-	return nil
+	// Dependencies come first in ordered, so their levels are final when a dependent is reached
+	level := make(map[string]int, len(ordered))
+	for _, svc := range ordered {
+		for _, dep := range svc.DependsOn {
+			if level[dep]+1 > level[svc.Name] {
+				level[svc.Name] = level[dep] + 1
+			}
+		}
+	}
+	maxLevel := 0
+	for _, name := range names {
+		if level[name] > maxLevel {
+			maxLevel = level[name]
+		}
+	}
+	waves := make([][]string, 0, maxLevel+1)
+	for l := maxLevel; l >= 0; l-- {
+		var wave []string
+		for _, name := range names {
+			if level[name] == l {
+				wave = append(wave, name)
+			}
+		}
+		if len(wave) > 0 {
+			sort.Strings(wave)
+			waves = append(waves, wave)
+		}
+	}
+	return waves
 }
 
 // GetStatus returns the status of a service
@@ -439,6 +694,34 @@ func (pm *ProcessManager) GetPID(serviceName string) int {
 	return proc.PID
 }
 
+// Snapshot returns the state of every tracked process, read under a single lock so the result is consistent.
+// Services the manager has never started (or adopted) are absent from the map.
+func (pm *ProcessManager) Snapshot() map[string]ProcessInfo {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	snapshot := make(map[string]ProcessInfo, len(pm.processes))
+	for name, proc := range pm.processes {
+		info := ProcessInfo{
+			Status:   string(proc.State),
+			Uptime:   proc.Uptime(),
+			Restarts: proc.RestartCount(),
+			Adopted:  proc.Adopted,
+		}
+		if proc.State == ProcessRunning {
+			info.PID = proc.PID
+		}
+		if proc.Error != nil {
+			info.Error = proc.Error.Error()
+		}
+		proc.logMu.RLock()
+		info.LastOutput = proc.lastOutputText()
+		proc.logMu.RUnlock()
+		snapshot[name] = info
+	}
+	return snapshot
+}
+
 // GetError returns the error for a service in error state
 func (pm *ProcessManager) GetError(serviceName string) string {
 	pm.mu.RLock()
@@ -461,27 +744,31 @@ func (pm *ProcessManager) GetLastOutput(serviceName string) []string {
 	}
 	proc.logMu.RLock()
 	defer proc.logMu.RUnlock()
-	if len(proc.lastOutput) == 0 {
-		return nil
-	}
-	out := make([]string, len(proc.lastOutput))
-	copy(out, proc.lastOutput)
-	return out
+	return proc.lastOutputText()
 }
 
-// ProbeHealth returns true if the given port/path responds with 2xx (e.g. after dashboard restart we can detect services we didn't start).
-func (pm *ProcessManager) ProbeHealth(port int, path string) bool {
-	if port <= 0 || path == "" {
+// ProbeHealth returns true if the service's health endpoint responds with the expected status (2xx by default),
+// using the configured health method (e.g. after dashboard restart we can detect services we didn't start).
+func (pm *ProcessManager) ProbeHealth(svc config.BackendServiceConfig) bool {
+	if svc.Port <= 0 || svc.HealthPath == "" {
 		return false
 	}
-	url := fmt.Sprintf("http://localhost:%d%s", port, path)
-	client := &http.Client{Timeout: 1 * time.Second}
-	resp, err := client.Get(url)
+	url := fmt.Sprintf("http://localhost:%d%s", svc.Port, svc.HealthPath)
+	ctx, cancel := context.WithTimeout(context.Background(), healthProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, svc.HealthCheckMethod(), url, nil)
 	if err != nil {
 		return false
 	}
-	defer resp.Body.Close()
-	return resp.StatusCode >= 200 && resp.StatusCode < 300
+	pm.mu.RLock()
+	client := pm.httpClient
+	pm.mu.RUnlock()
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer DrainAndClose(resp.Body)
+	return svc.HealthStatusOK(resp.StatusCode)
 }
 
 // KillProcessOnPort sends SIGTERM to any process listening on the given port (Unix). Used to stop "orphan" services that were left running before a dashboard restart.
@@ -509,51 +796,76 @@ func (pm *ProcessManager) KillProcessOnPort(port int) error {
 	return nil
 }
 
-// SubscribeLogs subscribes to log output from a service
-func (pm *ProcessManager) SubscribeLogs(serviceName string) (<-chan string, func()) {
+// SubscribeLogs subscribes to log output from a service. The channel first receives the service's
+// buffered recent output (up to maxLastOutputLines, marked Replayed), then live lines.
+func (pm *ProcessManager) SubscribeLogs(serviceName string) (<-chan model.LogLine, func()) {
 	pm.mu.RLock()
 	proc, exists := pm.processes[serviceName]
 	pm.mu.RUnlock()
 
-	ch := make(chan string, 100)
+	ch := make(chan model.LogLine, 100)
 
 	if !exists {
 		close(ch)
 		return ch, func() {}
 	}
 
+	// Replay and register under the same lock so no line is missed or duplicated
 	proc.logMu.Lock()
+	for _, line := range proc.lastOutput {
+		line.Replayed = true
+		ch <- line // fits: the buffer is larger than maxLastOutputLines
+	}
 	proc.subscribers[ch] = struct{}{}
 	proc.logMu.Unlock()
 
 	unsubscribe := func() {
 		proc.logMu.Lock()
-		delete(proc.subscribers, ch)
-		close(ch)
+		if _, ok := proc.subscribers[ch]; ok { // already closed if the process was restarted
+			delete(proc.subscribers, ch)
+			close(ch)
+		}
 		proc.logMu.Unlock()
 	}
 
 	return ch, unsubscribe
 }
 
-// captureOutput reads from a reader and broadcasts to subscribers
-func (proc *ManagedProcess) captureOutput(reader io.Reader, prefix string) {
+// Uptime returns how long the process has been running; 0 if it is not running or its start time is
+// unknown (adopted). Callers must hold the ProcessManager lock.
+func (proc *ManagedProcess) Uptime() time.Duration {
+	if proc.State != ProcessRunning || proc.Adopted || proc.StartTime.IsZero() {
+		return 0
+	}
+	return time.Since(proc.StartTime)
+}
+
+// RestartCount returns how many times the service has crashed (exited without being stopped) since
+// the dashboard started; stopping and starting it again doesn't count. Callers must hold the
+// ProcessManager lock.
+func (proc *ManagedProcess) RestartCount() int {
+	return proc.restarts
+}
+
+// captureOutput reads lines from one of the process's output streams (LogStreamStdout or
+// LogStreamStderr) and broadcasts them to subscribers
+func (proc *ManagedProcess) captureOutput(reader io.Reader, stream string) {
 	scanner := bufio.NewScanner(reader)
 	// Increase buffer size for long lines
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 1024*1024)
 
 	for scanner.Scan() {
-		line := prefix + scanner.Text()
+		line := newLogLine(proc.Name, stream, scanner.Text())
 		proc.broadcast(line)
 		proc.appendLastOutput(line)
 	}
 }
 
 // broadcast sends a log line to all subscribers and optional activity callback
-func (proc *ManagedProcess) broadcast(line string) {
+func (proc *ManagedProcess) broadcast(line model.LogLine) {
 	if proc.onActivityLine != nil {
-		proc.onActivityLine(line)
+		proc.onActivityLine(logLineText(line))
 	}
 	proc.logMu.RLock()
 	defer proc.logMu.RUnlock()
@@ -567,8 +879,36 @@ func (proc *ManagedProcess) broadcast(line string) {
 	}
 }
 
+// closeSubscribers sends a final line to all subscribers, then closes and removes their channels
+func (proc *ManagedProcess) closeSubscribers(line model.LogLine) {
+	proc.logMu.Lock()
+	defer proc.logMu.Unlock()
+
+	for ch := range proc.subscribers {
+		select {
+		case ch <- line:
+		default:
+		}
+		close(ch)
+		delete(proc.subscribers, ch)
+	}
+}
+
+// lastOutputText returns the buffered recent output as plain text lines (nil if empty).
+// Callers must hold logMu.
+func (proc *ManagedProcess) lastOutputText() []string {
+	if len(proc.lastOutput) == 0 {
+		return nil
+	}
+	out := make([]string, len(proc.lastOutput))
+	for i, line := range proc.lastOutput {
+		out[i] = logLineText(line)
+	}
+	return out
+}
+
 // appendLastOutput keeps the last maxLastOutputLines for debugging failed starts
-func (proc *ManagedProcess) appendLastOutput(line string) {
+func (proc *ManagedProcess) appendLastOutput(line model.LogLine) {
 	proc.logMu.Lock()
 	defer proc.logMu.Unlock()
 	proc.lastOutput = append(proc.lastOutput, line)
@@ -602,16 +942,97 @@ func (pm *ProcessManager) loadEnvFile() ([]string, error) {
 	return envVars, nil
 }
 
-// StartGroup starts all services in a group
+// serviceEnv builds the environment a service runs with: the dashboard's environment with
+// GOTOOLCHAIN=auto, then .env, then per-service defaults, then the service's EnvOverrides.
+// Later entries win when a name repeats.
+func (pm *ProcessManager) serviceEnv(svcConfig *config.BackendServiceConfig) []string {
+	// Load .env file
+	envVars, err := pm.loadEnvFile()
+	if err != nil {
+		log.Printf("Warning: failed to load .env file: %v", err)
+		// Continue without .env - some vars might be set in environment
+	}
+
+	// Node: default IPFS API to port 5011 so it doesn't conflict with system IPFS or other nodes on 5001
+	if svcConfig.Name == "node" {
+		hasIPFSAPI := false
+		for _, e := range envVars {
+			if strings.HasPrefix(e, "WABISABY_NODE_IPFS_API_URL=") {
+				hasIPFSAPI = true
+				break
+			}
+		}
+		if !hasIPFSAPI {
+			envVars = append(envVars, "WABISABY_NODE_IPFS_API_URL=http://localhost:5011")
+		}
+	}
+
+	// Per-service overrides win over .env (sorted so the environment is stable between starts)
+	keys := make([]string, 0, len(svcConfig.EnvOverrides))
+	for k := range svcConfig.EnvOverrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		envVars = append(envVars, k+"="+svcConfig.EnvOverrides[k])
+	}
+
+	// Use GOTOOLCHAIN=auto so the project's go.mod toolchain requirement is respected (e.g. 1.24.4)
+	return append(envForGoRun(), envVars...)
+}
+
+// EffectiveEnv returns the environment Start would run the service with, as NAME=value entries in
+// first-seen order with duplicates resolved the way exec does (the last value wins).
+func (pm *ProcessManager) EffectiveEnv(serviceName string) ([]string, error) {
+	svcConfig := config.GetServiceByName(serviceName)
+	if svcConfig == nil {
+		return nil, fmt.Errorf("unknown service: %s", serviceName)
+	}
+
+	env := pm.serviceEnv(svcConfig)
+	index := make(map[string]int, len(env))
+	result := make([]string, 0, len(env))
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		if runtime.GOOS == "windows" {
+			name = strings.ToUpper(name) // env names are case-insensitive on Windows
+		}
+		if i, ok := index[name]; ok {
+			result[i] = e
+			continue
+		}
+		index[name] = len(result)
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// StartGroup starts all services in a group in dependency order. Each service is started only once
+// its dependencies are ready (Start waits for readiness); dependencies outside the group are started
+// too unless already running. Services whose dependency failed are skipped and reported.
 func (pm *ProcessManager) StartGroup(group string) error {
 	services := config.GetServicesByGroup(group)
 	if len(services) == 0 {
 		return fmt.Errorf("unknown group: %s", group)
 	}
+	ordered, err := config.OrderByDependencies(services)
+	if err != nil {
+		return err
+	}
 
+	failed := make(map[string]bool)
 	var errors []string
-	for _, svc := range services {
+	for _, svc := range ordered {
+		if dep := failedDependency(svc, failed); dep != "" {
+			failed[svc.Name] = true
+			errors = append(errors, fmt.Sprintf("%s: dependency %s failed to start", svc.Name, dep))
+			continue
+		}
+		if svc.Group != group && pm.isUp(svc) {
+			continue
+		}
 		if err := pm.Start(svc.Name); err != nil {
+			failed[svc.Name] = true
 			errors = append(errors, fmt.Sprintf("%s: %v", svc.Name, err))
 		}
 	}
@@ -622,15 +1043,38 @@ func (pm *ProcessManager) StartGroup(group string) error {
 	return nil
 }
 
-// StopGroup stops all services in a group
+// failedDependency returns the first dependency of svc recorded in failed, or "" if none
+func failedDependency(svc config.BackendServiceConfig, failed map[string]bool) string {
+	for _, dep := range svc.DependsOn {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// isUp returns true if the service is running, whether started by us or detected via its health endpoint
+func (pm *ProcessManager) isUp(svc config.BackendServiceConfig) bool {
+	return pm.GetStatus(svc.Name) == string(ProcessRunning) || pm.ProbeHealth(svc)
+}
+
+// StopGroup stops all services in a group, dependents before the services they depend on
 func (pm *ProcessManager) StopGroup(group string) error {
 	services := config.GetServicesByGroup(group)
 	if len(services) == 0 {
 		return fmt.Errorf("unknown group: %s", group)
 	}
+	ordered, err := config.OrderByDependencies(services)
+	if err != nil {
+		return err
+	}
 
 	var errors []string
-	for _, svc := range services {
+	for i := len(ordered) - 1; i >= 0; i-- {
+		svc := ordered[i]
+		if svc.Group != group {
+			continue
+		}
 		if err := pm.Stop(svc.Name); err != nil {
 			errors = append(errors, fmt.Sprintf("%s: %v", svc.Name, err))
 		}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// errProcessExited is returned by readProcessStats when the PID no longer exists
+var errProcessExited = errors.New("process has exited")
+
+// ResourceUsage returns the resident memory and CPU usage of a running service's process.
+// For services started with "go run" this is the process we launched (the go tool), not its
+// compiled child. Returns an error if the service is not running or its process has exited.
+func (pm *ProcessManager) ResourceUsage(serviceName string) (*model.ProcessStats, error) {
+	pm.mu.RLock()
+	proc, exists := pm.processes[serviceName]
+	var pid int
+	if exists && proc.State == ProcessRunning {
+		pid = proc.PID
+	}
+	pm.mu.RUnlock()
+
+	if pid <= 0 {
+		return nil, fmt.Errorf("service %s is not running", serviceName)
+	}
+	if !processAlive(pid) {
+		return nil, fmt.Errorf("service %s: process %d has exited", serviceName, pid)
+	}
+
+	rss, cpu, err := readProcessStats(pid)
+	if errors.Is(err, errProcessExited) {
+		return nil, fmt.Errorf("service %s: process %d has exited", serviceName, pid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage of %s (PID %d): %w", serviceName, pid, err)
+	}
+	return &model.ProcessStats{
+		Name:       serviceName,
+		PID:        pid,
+		RSSBytes:   rss,
+		CPUPercent: cpu,
+	}, nil
+}
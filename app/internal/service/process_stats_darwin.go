@@ -0,0 +1,35 @@
+//go:build darwin
+
+package service
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// readProcessStats reads RSS (bytes) and CPU percent via ps
+func readProcessStats(pid int) (int64, float64, error) {
+	out, err := exec.Command("ps", "-o", "rss=,pcpu=", "-p", strconv.Itoa(pid)).Output()
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		// ps prints nothing (and exits non-zero) when the PID does not exist
+		return 0, 0, errProcessExited
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(fields) < 2 {
+		return 0, 0, fmt.Errorf("unexpected ps output: %q", string(out))
+	}
+	rssKB, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected ps output: %q", string(out))
+	}
+	cpu, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected ps output: %q", string(out))
+	}
+	return rssKB * 1024, cpu, nil
+}
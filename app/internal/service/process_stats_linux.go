@@ -0,0 +1,71 @@
+//go:build linux
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/<pid>/stat (100 on all common Linux builds)
+const clockTicks = 100
+
+// readProcessStats reads RSS (bytes) from /proc/<pid>/statm and CPU percent from /proc/<pid>/stat.
+// CPU percent is averaged over the process lifetime, like ps reports it.
+func readProcessStats(pid int) (int64, float64, error) {
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if os.IsNotExist(err) {
+		return 0, 0, errProcessExited
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, 0, fmt.Errorf("unexpected statm format")
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected statm format: %w", err)
+	}
+	rss := pages * int64(os.Getpagesize())
+
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if os.IsNotExist(err) {
+		return 0, 0, errProcessExited
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	// comm (field 2) may contain spaces; the remaining fields start after its closing paren
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return 0, 0, fmt.Errorf("unexpected stat format")
+	}
+	fields = strings.Fields(string(stat)[end+1:])
+	// fields[0] is state (field 3): utime=14, stime=15, starttime=22
+	if len(fields) < 20 {
+		return 0, 0, fmt.Errorf("unexpected stat format")
+	}
+	utime, _ := strconv.ParseFloat(fields[11], 64)
+	stime, _ := strconv.ParseFloat(fields[12], 64)
+	start, _ := strconv.ParseFloat(fields[19], 64)
+
+	uptimeData, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return rss, 0, nil
+	}
+	uptimeFields := strings.Fields(string(uptimeData))
+	if len(uptimeFields) == 0 {
+		return rss, 0, nil
+	}
+	uptime, _ := strconv.ParseFloat(uptimeFields[0], 64)
+
+	elapsed := uptime - start/clockTicks
+	if elapsed <= 0 {
+		return rss, 0, nil
+	}
+	return rss, 100 * (utime + stime) / clockTicks / elapsed, nil
+}
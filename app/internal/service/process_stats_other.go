@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !windows
+
+package service
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// readProcessStats is not supported on this platform
+func readProcessStats(pid int) (int64, float64, error) {
+	return 0, 0, fmt.Errorf("resource usage is unsupported on this platform (%s)", runtime.GOOS)
+}
@@ -0,0 +1,65 @@
+//go:build windows
+
+package service
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// errorInvalidParameter is ERROR_INVALID_PARAMETER, which OpenProcess returns for an unknown PID
+const errorInvalidParameter syscall.Errno = 87
+
+var procGetProcessMemoryInfo = syscall.NewLazyDLL("psapi.dll").NewProc("GetProcessMemoryInfo")
+
+// processMemoryCounters is PROCESS_MEMORY_COUNTERS, which the syscall package lacks
+type processMemoryCounters struct {
+	cb                         uint32
+	pageFaultCount             uint32
+	peakWorkingSetSize         uintptr
+	workingSetSize             uintptr
+	quotaPeakPagedPoolUsage    uintptr
+	quotaPagedPoolUsage        uintptr
+	quotaPeakNonPagedPoolUsage uintptr
+	quotaNonPagedPoolUsage     uintptr
+	pagefileUsage              uintptr
+	peakPagefileUsage          uintptr
+}
+
+// readProcessStats reads the working set (bytes) with GetProcessMemoryInfo and CPU percent with
+// GetProcessTimes. CPU percent is averaged over the process lifetime, like on Linux.
+func readProcessStats(pid int) (int64, float64, error) {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err == errorInvalidParameter {
+		return 0, 0, errProcessExited
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer syscall.CloseHandle(h)
+
+	var mem processMemoryCounters
+	mem.cb = uint32(unsafe.Sizeof(mem))
+	if ok, _, err := procGetProcessMemoryInfo.Call(uintptr(h), uintptr(unsafe.Pointer(&mem)), uintptr(mem.cb)); ok == 0 {
+		return 0, 0, fmt.Errorf("GetProcessMemoryInfo: %w", err)
+	}
+	rss := int64(mem.workingSetSize)
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return rss, 0, nil
+	}
+	elapsed := time.Since(time.Unix(0, creation.Nanoseconds())).Seconds()
+	if elapsed <= 0 {
+		return rss, 0, nil
+	}
+	cpuSeconds := float64(filetimeTicks(kernel)+filetimeTicks(user)) / 1e7
+	return rss, cpuSeconds / elapsed * 100, nil
+}
+
+// filetimeTicks is a FILETIME duration (kernel or user time) in 100-nanosecond ticks
+func filetimeTicks(ft syscall.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
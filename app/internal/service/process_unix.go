@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strconv"
 	"syscall"
+	"time"
 )
 
 // setSysProcAttr configures the command to run in its own process group (Unix).
@@ -16,6 +17,25 @@ func setSysProcAttr(cmd *exec.Cmd) {
 	}
 }
 
+// CancelProcessGroup runs cmd (an exec.CommandContext command) in its own process group and makes
+// cancelling its context SIGTERM the whole group, then SIGKILL whatever is left of it after
+// processGroupKillDelay. Without this only the direct child is killed, and the processes it spawned
+// (e.g. the compilers under "make test") keep running and holding the output pipes open.
+func CancelProcessGroup(cmd *exec.Cmd) {
+	setSysProcAttr(cmd)
+	cmd.Cancel = func() error {
+		pgid := cmd.Process.Pid // Setpgid: the command leads its own group
+		if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+			return cmd.Process.Signal(syscall.SIGTERM)
+		}
+		go func() {
+			time.Sleep(processGroupKillDelay)
+			syscall.Kill(-pgid, syscall.SIGKILL) // ESRCH once the whole group has exited
+		}()
+		return nil
+	}
+}
+
 // terminateProcess sends SIGTERM to the process group (Unix).
 func terminateProcess(cmd *exec.Cmd) {
 	if cmd.Process == nil {
@@ -42,6 +62,20 @@ func forceKillProcess(cmd *exec.Cmd) {
 	}
 }
 
+// terminateSingleProcess sends SIGTERM to the process only, not its group (Unix).
+func terminateSingleProcess(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		cmd.Process.Signal(syscall.SIGTERM)
+	}
+}
+
+// forceKillSingleProcess sends SIGKILL to the process only, not its group (Unix).
+func forceKillSingleProcess(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		cmd.Process.Kill()
+	}
+}
+
 // killPidByPort sends SIGTERM to a PID found on a port (Unix).
 func killPidByPort(pidStr string, port int) {
 	pid, err := strconv.Atoi(pidStr)
@@ -52,3 +86,9 @@ func killPidByPort(pidStr string, port int) {
 		log.Printf("Failed to kill PID %d on port %d: %v", pid, port, err)
 	}
 }
+
+// processAlive reports whether a process with the given PID exists (Unix).
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -5,6 +5,7 @@ package service
 import (
 	"log"
 	"os/exec"
+	"syscall"
 )
 
 // setSysProcAttr is a no-op on Windows (no process groups via Setpgid).
@@ -13,6 +14,10 @@ func setSysProcAttr(cmd *exec.Cmd) {
 	// differently (e.g. Job Objects). For now, we skip process group setup.
 }
 
+// CancelProcessGroup keeps exec.CommandContext's default of killing the process on Windows
+// (no process groups via Setpgid).
+func CancelProcessGroup(cmd *exec.Cmd) {}
+
 // terminateProcess kills the process on Windows.
 func terminateProcess(cmd *exec.Cmd) {
 	if cmd.Process == nil {
@@ -34,7 +39,39 @@ func forceKillProcess(cmd *exec.Cmd) {
 	}
 }
 
+// terminateSingleProcess kills the process on Windows (there are no process groups to spare).
+func terminateSingleProcess(cmd *exec.Cmd) {
+	terminateProcess(cmd)
+}
+
+// forceKillSingleProcess force-kills the process on Windows.
+func forceKillSingleProcess(cmd *exec.Cmd) {
+	forceKillProcess(cmd)
+}
+
 // killPidByPort is a no-op on Windows (TODO: implement via taskkill).
 func killPidByPort(pidStr string, port int) {
 	// TODO: implement for Windows (netstat -ano, taskkill)
 }
+
+// processQueryLimitedInformation is PROCESS_QUERY_LIMITED_INFORMATION, which the syscall package lacks
+const processQueryLimitedInformation = 0x1000
+
+// stillActive is the exit code GetExitCodeProcess reports for a running process (STILL_ACTIVE)
+const stillActive = 259
+
+// processAlive reports whether a process with the given PID is still running (Windows). A handle can
+// be opened for a process that has exited but is not yet reaped, so its exit code is checked too.
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		// Access denied means the process exists but belongs to someone else
+		return err == syscall.ERROR_ACCESS_DENIED
+	}
+	defer syscall.CloseHandle(h)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
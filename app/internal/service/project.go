@@ -6,24 +6,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
+	"github.com/wabisaby/devkit-dashboard/internal/config"
 	"github.com/wabisaby/devkit-dashboard/internal/git"
 	"github.com/wabisaby/devkit-dashboard/internal/model"
 )
 
-// projectRepoURLs maps project names to clone URLs for plain clone (app mode).
-var projectRepoURLs = map[string]string{
-	"wabisaby-core":          "https://github.com/WabiSaby/wabisaby-core.git",
-	"wabisaby-node":          "https://github.com/WabiSaby/wabisaby-node.git",
-	"wabisaby-protos":        "https://github.com/WabiSaby/wabisaby-protos.git",
-	"wabisaby-plugin-sdk-go": "https://github.com/WabiSaby/wabisaby-plugin-sdk-go.git",
-	"wabisaby-plugins":       "https://github.com/WabiSaby/wabisaby-plugins.git",
-	"wabisaby-ui":            "https://github.com/WabiSaby/wabisaby-ui.git",
-	"wabisaby-web": "https://github.com/WabiSaby/wabisaby-web.git",
-}
-
 // detectProjectLanguage returns the primary language of a project (GitHub-style),
 // based on manifest files and common conventions. projectName is used to infer
 // proto-focused repos (e.g. wabisaby-protos) that ship Go bindings.
@@ -87,25 +78,18 @@ func hasProtoFiles(dir string) bool {
 	return false
 }
 
-// GetProjects returns a list of all projects with their status
+// GetProjects returns a list of all configured projects (config.GetProjects) with their status
 func GetProjects(projectsDir string) ([]model.Project, error) {
-	projects := []model.Project{
-		{Name: "wabisaby-core"},
-		{Name: "wabisaby-node"},
-		{Name: "wabisaby-protos"},
-		{Name: "wabisaby-plugin-sdk-go"},
-		{Name: "wabisaby-plugins"},
-		{Name: "wabisaby-ui"},
-		{Name: "wabisaby-web"},
+	configured := config.GetProjects()
+	projects := make([]model.Project, len(configured))
+	for i, p := range configured {
+		// GitHub repo URL for the project card link (web URL: strip .git from clone URL)
+		projects[i] = model.Project{Name: p.Name, RepoURL: strings.TrimSuffix(p.RepoURL, ".git")}
 	}
 
 	for i := range projects {
 		project := &projects[i]
 		projectDir := filepath.Join(projectsDir, project.Name)
-		// Set GitHub repo URL for known projects (web URL: strip .git from clone URL)
-		if cloneURL, ok := projectRepoURLs[project.Name]; ok {
-			project.RepoURL = strings.TrimSuffix(cloneURL, ".git")
-		}
 
 		// Check if project directory exists
 		if _, err := os.Stat(projectDir); os.IsNotExist(err) {
@@ -124,8 +108,18 @@ func GetProjects(projectsDir string) ([]model.Project, error) {
 				project.Commit = commit
 			}
 
-			// Check if dirty
-			project.Dirty = git.IsDirty(projectDir)
+			// Check if dirty (same rule as git.IsDirty, from a single git status)
+			if staged, unstaged, untracked, err := git.StatusCounts(projectDir); err == nil {
+				project.Staged, project.Unstaged, project.Untracked = staged, unstaged, untracked
+				project.Dirty = staged > 0 || unstaged > 0
+			}
+
+			project.Shallow = git.IsShallow(projectDir)
+
+			// Compare with upstream (no fetch; reflects the last update)
+			if ahead, behind, hasUpstream, err := git.AheadBehind(projectDir); err == nil {
+				project.Ahead, project.Behind, project.HasUpstream = ahead, behind, hasUpstream
+			}
 
 			// Determine status
 			if project.Dirty {
@@ -136,38 +130,81 @@ func GetProjects(projectsDir string) ([]model.Project, error) {
 
 			// Detect primary language (GitHub-style)
 			project.Language = detectProjectLanguage(projectDir, project.Name)
+
+			// Disk usage is only reported once measured (ProjectSize); a full walk per listing is too slow
+			if size, ok := cachedProjectSize(projectDir); ok {
+				project.SizeBytes = size.SizeBytes
+			}
 		}
 	}
 
 	return projects, nil
 }
 
-// CloneProject clones a project: submodule init when devkit root is a git repo and projects
-// dir is under it, otherwise plain git clone into projects dir.
-func CloneProject(devkitRoot, projectsDir, projectName string) error {
-	projectDir := filepath.Join(projectsDir, projectName)
+// useSubmodule reports whether a project is managed as a devkit submodule. That requires devkit root
+// to be a git repo with projects dir under it; the project's Submodule setting then decides, and
+// when unset the project must be declared in the devkit's .gitmodules (so a project added only in
+// projects.json is cloned plainly).
+func useSubmodule(devkitRoot, projectsDir string, project *config.ProjectConfig) bool {
 	gitDir := filepath.Join(devkitRoot, ".git")
 	rel, _ := filepath.Rel(devkitRoot, projectsDir)
-	useSubmodule := false
-	if _, err := os.Stat(gitDir); err == nil && rel != "" && !strings.HasPrefix(rel, "..") {
-		useSubmodule = true
+	if _, err := os.Stat(gitDir); err != nil || rel == "" || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	if project == nil {
+		return true
+	}
+	if project.Submodule != nil {
+		return *project.Submodule
+	}
+	data, err := os.ReadFile(filepath.Join(devkitRoot, ".gitmodules"))
+	if err != nil {
+		return false
 	}
-	if useSubmodule {
-		return git.InitializeSubmodule(devkitRoot, projectsDir, projectName)
+	submodulePath := filepath.ToSlash(filepath.Join(rel, project.Name))
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "path" && strings.TrimSpace(value) == submodulePath {
+			return true
+		}
 	}
-	url, ok := projectRepoURLs[projectName]
-	if !ok {
+	return false
+}
+
+// CloneProject clones a configured project: submodule init when it is managed as a devkit
+// submodule (see useSubmodule), otherwise plain git clone of its RepoURL (and DefaultBranch) into
+// projects dir. Clones are depth commits deep (WABISABY_CLONE_DEPTH); 0 clones full history. Shallow
+// clones can be completed later with UnshallowProject.
+func CloneProject(devkitRoot, projectsDir, projectName string, depth int) error {
+	defer invalidateDiskUsage(filepath.Join(projectsDir, projectName))
+	project := config.GetProjectByName(projectName)
+	if project == nil {
 		return fmt.Errorf("unknown project: %s", projectName)
 	}
-	return git.CloneRepo(url, projectDir)
+	if useSubmodule(devkitRoot, projectsDir, project) {
+		return git.InitializeSubmodule(devkitRoot, projectsDir, projectName, depth)
+	}
+	if project.RepoURL == "" {
+		return fmt.Errorf("project %s has no repoUrl", projectName)
+	}
+	return git.CloneRepo(project.RepoURL, filepath.Join(projectsDir, projectName), project.DefaultBranch, depth)
+}
+
+// UnshallowProject fetches the full history of a shallow-cloned project
+func UnshallowProject(projectsDir, projectName string) error {
+	defer invalidateDiskUsage(filepath.Join(projectsDir, projectName))
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.Unshallow(projectDir)
 }
 
 // UpdateProject updates a project: submodule update when in devkit repo, else git pull.
 func UpdateProject(devkitRoot, projectsDir, projectName string) error {
+	defer invalidateDiskUsage(filepath.Join(projectsDir, projectName))
 	projectDir := filepath.Join(projectsDir, projectName)
-	gitDir := filepath.Join(devkitRoot, ".git")
-	rel, _ := filepath.Rel(devkitRoot, projectsDir)
-	if _, err := os.Stat(gitDir); err == nil && rel != "" && !strings.HasPrefix(rel, "..") {
+	if useSubmodule(devkitRoot, projectsDir, config.GetProjectByName(projectName)) {
+		rel, _ := filepath.Rel(devkitRoot, projectsDir)
 		submodulePath := filepath.ToSlash(filepath.Join(rel, projectName))
 		cmd := exec.Command("git", "submodule", "update", "--remote", submodulePath)
 		cmd.Dir = devkitRoot
@@ -178,13 +215,14 @@ func UpdateProject(devkitRoot, projectsDir, projectName string) error {
 	return cmd.Run()
 }
 
-// CreateReleaseTag creates an annotated tag at HEAD and optionally pushes to origin.
-func CreateReleaseTag(devkitRoot, projectsDir, projectName, tagName, message string, push bool) error {
+// CreateReleaseTag creates an annotated (or lightweight) tag at commit (HEAD when empty) and optionally
+// pushes to origin.
+func CreateReleaseTag(devkitRoot, projectsDir, projectName, tagName, message, commit string, lightweight, push bool) error {
 	projectDir := filepath.Join(projectsDir, projectName)
 	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
 		return fmt.Errorf("project not cloned: clone the project first")
 	}
-	if err := git.CreateTag(projectDir, tagName, message); err != nil {
+	if err := git.CreateTag(projectDir, tagName, message, commit, lightweight); err != nil {
 		return err
 	}
 	if push {
@@ -195,6 +233,38 @@ func CreateReleaseTag(devkitRoot, projectsDir, projectName, tagName, message str
 	return nil
 }
 
+// DeleteReleaseTag deletes a tag locally and, when remote is set, from origin. A tag that exists only
+// on origin can still be deleted there.
+func DeleteReleaseTag(projectsDir, projectName, tagName string, remote bool) error {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project not cloned: clone the project first")
+	}
+	tags, err := git.ListTags(projectDir)
+	if err != nil {
+		return err
+	}
+	local := false
+	for _, t := range tags {
+		if t == tagName {
+			local = true
+			break
+		}
+	}
+	if !local && !remote {
+		return fmt.Errorf("tag %s not found", tagName)
+	}
+	if local {
+		if err := git.DeleteTag(projectDir, tagName); err != nil {
+			return err
+		}
+	}
+	if remote {
+		return git.DeleteRemoteTag(projectDir, tagName)
+	}
+	return nil
+}
+
 // ListProjectTags returns tag names for the project. Returns empty list if project is not cloned.
 func ListProjectTags(devkitRoot, projectsDir, projectName string) ([]string, error) {
 	projectDir := filepath.Join(projectsDir, projectName)
@@ -204,6 +274,135 @@ func ListProjectTags(devkitRoot, projectsDir, projectName string) ([]string, err
 	return git.ListTags(projectDir)
 }
 
+// ProjectDiff returns the project's uncommitted diff (staged or unstaged), capped at git.MaxDiffBytes
+func ProjectDiff(projectsDir, projectName string, staged bool) (string, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return "", fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.Diff(projectDir, staged)
+}
+
+// ProjectCommits returns the project's n most recent commits (git.DefaultCommitCount when n <= 0)
+func ProjectCommits(projectsDir, projectName string, n int) ([]model.Commit, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.RecentCommits(projectDir, n)
+}
+
+// ListProjectBranches returns the project's local and remote-tracking branch names
+func ListProjectBranches(projectsDir, projectName string) ([]string, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.ListBranches(projectDir)
+}
+
+// CheckoutProjectBranch switches the project to branch, refusing when the working tree has
+// uncommitted changes so nothing is carried over or lost.
+func CheckoutProjectBranch(projectsDir, projectName, branch string) error {
+	defer invalidateDiskUsage(filepath.Join(projectsDir, projectName))
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project %s is not cloned", projectName)
+	}
+	if git.IsDirty(projectDir) {
+		return fmt.Errorf("%s has uncommitted changes: commit or stash your changes first", projectName)
+	}
+	return git.Checkout(projectDir, branch)
+}
+
+// StashProject stashes the project's uncommitted changes to tracked files
+func StashProject(projectsDir, projectName, message string) error {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.Stash(projectDir, message)
+}
+
+// UnstashProject pops the project's most recent stash entry
+func UnstashProject(projectsDir, projectName string) error {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.StashPop(projectDir)
+}
+
+// ListProjectStashes returns the project's stash entries, newest first
+func ListProjectStashes(projectsDir, projectName string) ([]string, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("project %s is not cloned", projectName)
+	}
+	return git.StashList(projectDir)
+}
+
+// TestRunArgs returns the command line running the tests matching pattern under pkg (a path relative
+// to the project, empty = whole project): "go test -run <pattern> ./<pkg>/..." for Go projects and
+// "npm test -- --testNamePattern <pattern> <pkg>" for Node projects.
+func TestRunArgs(projectDir, pattern, pkg string) ([]string, error) {
+	if pattern != "" {
+		if strings.HasPrefix(pattern, "-") {
+			return nil, fmt.Errorf("invalid test pattern: must not start with '-'")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid test pattern: %w", err)
+		}
+	}
+	pkg, err := cleanPackagePath(projectDir, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "go.mod")); err == nil {
+		args := []string{"go", "test"}
+		if pattern != "" {
+			args = append(args, "-run", pattern)
+		}
+		if pkg == "" {
+			return append(args, "./..."), nil
+		}
+		return append(args, "./"+pkg+"/..."), nil
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "package.json")); err == nil {
+		args := []string{"npm", "test", "--"}
+		if pattern != "" {
+			args = append(args, "--testNamePattern", pattern)
+		}
+		if pkg != "" {
+			args = append(args, pkg)
+		}
+		return args, nil
+	}
+	return nil, fmt.Errorf("test runs are supported for Go and Node projects only")
+}
+
+// cleanPackagePath validates pkg as an existing directory inside projectDir and returns it
+// slash-separated and without a leading "./" or trailing "/..." ("" for the project root).
+func cleanPackagePath(projectDir, pkg string) (string, error) {
+	pkg = strings.TrimSuffix(strings.TrimSpace(pkg), "/...")
+	if pkg == "" || pkg == "." || pkg == "./" {
+		return "", nil
+	}
+	if strings.HasPrefix(pkg, "-") || filepath.IsAbs(pkg) {
+		return "", fmt.Errorf("invalid package path: %s", pkg)
+	}
+	cleaned := filepath.ToSlash(filepath.Clean(pkg))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("package path must be inside the project: %s", pkg)
+	}
+	info, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(cleaned)))
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("package directory not found: %s", pkg)
+	}
+	return cleaned, nil
+}
+
 // OpenProject opens a project in the editor
 func OpenProject(devkitRoot, projectsDir, projectName string) error {
 	editor, err := detectEditor()
@@ -251,6 +450,24 @@ func OpenProject(devkitRoot, projectsDir, projectName string) error {
 	return nil
 }
 
+// OpenFiles opens files in the detected editor (Cursor or VSCode)
+func OpenFiles(paths ...string) error {
+	editor, err := detectEditor()
+	if err != nil {
+		return fmt.Errorf("no editor found: %w", err)
+	}
+
+	cmd := exec.Command(editor, paths...)
+	setSysProcAttr(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start editor: %w", err)
+	}
+	go func() {
+		cmd.Wait()
+	}()
+	return nil
+}
+
 // detectEditor detects available editor (Cursor or VSCode)
 func detectEditor() (string, error) {
 	editors := []string{"cursor", "code"}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTestRunArgs(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "go.mod", "module example.com/core\n")
+	writeProjectFile(t, projectsDir, "core", "internal/auth/auth.go", "package auth\n")
+	writeProjectFile(t, projectsDir, "web", "package.json", "{}\n")
+	writeProjectFile(t, projectsDir, "web", "src/components/button.ts", "")
+	core := filepath.Join(projectsDir, "core")
+	web := filepath.Join(projectsDir, "web")
+
+	tests := []struct {
+		name       string
+		projectDir string
+		pattern    string
+		pkg        string
+		want       []string
+	}{
+		{"go whole project", core, "", "", []string{"go", "test", "./..."}},
+		{"go pattern", core, "TestLogin$", "", []string{"go", "test", "-run", "TestLogin$", "./..."}},
+		{"go package", core, "TestLogin", "internal/auth", []string{"go", "test", "-run", "TestLogin", "./internal/auth/..."}},
+		{"go package spelled as a go pattern", core, "", "./internal/auth/...", []string{"go", "test", "./internal/auth/..."}},
+		{"go package with redundant elements", core, "", "internal/../internal/auth/", []string{"go", "test", "./internal/auth/..."}},
+		{"go project root as a package", core, "", ".", []string{"go", "test", "./..."}},
+		{"node whole project", web, "", "", []string{"npm", "test", "--"}},
+		{"node pattern and path", web, "renders a button", "src/components", []string{"npm", "test", "--", "--testNamePattern", "renders a button", "src/components"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TestRunArgs(tt.projectDir, tt.pattern, tt.pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRunArgs(%q, %q) = %q, want %q", tt.pattern, tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTestRunArgsRejectsBadInput(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "core", "go.mod", "module example.com/core\n")
+	writeProjectFile(t, projectsDir, "core", "internal/auth/auth.go", "package auth\n")
+	writeProjectFile(t, projectsDir, "other", "go.mod", "module example.com/other\n")
+	writeProjectFile(t, projectsDir, "scripts", "run.sh", "")
+	core := filepath.Join(projectsDir, "core")
+
+	tests := []struct {
+		name       string
+		projectDir string
+		pattern    string
+		pkg        string
+	}{
+		{"pattern that is a flag", core, "-exec=rm", ""},
+		{"invalid pattern", core, "Test(", ""},
+		{"package that is a flag", core, "", "-exec=rm"},
+		{"absolute package", core, "", filepath.Join(core, "internal")},
+		{"package outside the project", core, "", "../other"},
+		{"package escaping through the project", core, "", "internal/../../other"},
+		{"parent directory", core, "", ".."},
+		{"missing package", core, "", "internal/billing"},
+		{"package that is a file", core, "", "go.mod"},
+		{"unsupported project", filepath.Join(projectsDir, "scripts"), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := TestRunArgs(tt.projectDir, tt.pattern, tt.pkg); err == nil {
+				t.Errorf("TestRunArgs(%q, %q) = %q, want an error", tt.pattern, tt.pkg, got)
+			}
+		})
+	}
+}
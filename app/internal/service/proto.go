@@ -7,6 +7,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/wabisaby/devkit-dashboard/internal/model"
@@ -17,11 +20,20 @@ const protosProjectName = "wabisaby-protos"
 // ProtoService manages protobuf codegen for wabisaby-protos
 type ProtoService struct {
 	projectsDir string
+	makeTargets map[string]string // GenerateTarget target -> wabisaby-protos make target
+
+	breakMu    sync.Mutex
+	lastBreaks []model.ProtoBreak // Result of the last CheckBreaking, for notices
 }
 
-// NewProtoService creates a new proto service
-func NewProtoService(projectsDir string) *ProtoService {
-	return &ProtoService{projectsDir: projectsDir}
+// NewProtoService creates a new proto service. extraTargets (WABISABY_PROTO_TARGETS) add to or
+// override the default GenerateTarget targets.
+func NewProtoService(projectsDir string, extraTargets map[string]string) *ProtoService {
+	makeTargets := defaultProtoMakeTargets()
+	for name, target := range extraTargets {
+		makeTargets[name] = target
+	}
+	return &ProtoService{projectsDir: projectsDir, makeTargets: makeTargets}
 }
 
 // GetStatus returns whether generated code is out of date relative to .proto sources
@@ -111,16 +123,65 @@ func maxMtimeInDir(root, dir, ext string) (time.Time, error) {
 	return max, err
 }
 
+// ProtoTargetAll is the GenerateTarget target that regenerates every language (make proto)
+const ProtoTargetAll = "all"
+
+// defaultProtoMakeTargets maps GenerateTarget targets to wabisaby-protos make targets before
+// NewProtoService's extra targets are applied. Only a service's targets can be run.
+func defaultProtoMakeTargets() map[string]string {
+	return map[string]string{
+		ProtoTargetAll: "proto",
+		"plugin":       "proto-go-plugin",
+		"node":         "proto-go-node",
+	}
+}
+
+// Targets returns the allowed GenerateTarget targets, sorted
+func (s *ProtoService) Targets() []string {
+	targets := make([]string, 0, len(s.makeTargets))
+	for target := range s.makeTargets {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// CheckTarget returns an error naming the allowed targets unless target ("" = all) can be generated
+func (s *ProtoService) CheckTarget(target string) error {
+	if target == "" {
+		target = ProtoTargetAll
+	}
+	if _, ok := s.makeTargets[target]; !ok {
+		return fmt.Errorf("unknown proto target %q (allowed: %s)", target, strings.Join(s.Targets(), ", "))
+	}
+	return nil
+}
+
 // RunProtoStream runs make proto and streams output lines to the returned channel
 func (s *ProtoService) RunProtoStream(ctx context.Context) (<-chan string, error) {
+	return s.GenerateTarget(ctx, ProtoTargetAll)
+}
+
+// GenerateTarget runs the make target mapped to target (e.g. "plugin" -> make proto-go-plugin, see
+// Targets) and streams output lines to the returned channel. Empty target means all.
+func (s *ProtoService) GenerateTarget(ctx context.Context, target string) (<-chan string, error) {
+	if target == "" {
+		target = ProtoTargetAll
+	}
+	if err := s.CheckTarget(target); err != nil {
+		return nil, err
+	}
+	makeTarget := s.makeTargets[target]
+
 	protosPath := filepath.Join(s.projectsDir, protosProjectName)
 	stat, err := os.Stat(protosPath)
 	if err != nil || stat == nil || !stat.IsDir() {
 		return nil, fmt.Errorf("wabisaby-protos not found at %s", protosPath)
 	}
 
-	cmd := exec.CommandContext(ctx, "make", "proto")
+	cmd := exec.CommandContext(ctx, "make", makeTarget)
 	cmd.Dir = protosPath
+	CancelProcessGroup(cmd)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -135,7 +196,7 @@ func (s *ProtoService) RunProtoStream(ctx context.Context) (<-chan string, error
 
 	if err := cmd.Start(); err != nil {
 		close(ch)
-		return nil, fmt.Errorf("failed to start make proto: %w", err)
+		return nil, fmt.Errorf("failed to start make %s: %w", makeTarget, err)
 	}
 
 	go func() {
@@ -165,7 +226,7 @@ func (s *ProtoService) RunProtoStream(ctx context.Context) (<-chan string, error
 
 		if err := cmd.Wait(); err != nil {
 			select {
-			case ch <- fmt.Sprintf("[error] make proto failed: %v", err):
+			case ch <- fmt.Sprintf("[error] make %s failed: %v", makeTarget, err):
 			case <-ctx.Done():
 			}
 		} else {
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// protoBreakingAgainst is the buf input that working-tree protos are compared with
+const protoBreakingAgainst = ".git#branch=main"
+
+// ErrBufNotInstalled is returned by the buf-based checks when buf is not on PATH
+var ErrBufNotInstalled = errors.New("buf is not installed; install buf to enable proto checks (see https://buf.build/docs/installation)")
+
+// bufAnnotation is one line of `buf breaking|lint --error-format=json` output. Lines that aren't
+// JSON are kept as a message-only annotation so nothing buf reported is lost.
+type bufAnnotation struct {
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	Type        string `json:"type"`
+	Message     string `json:"message"`
+}
+
+// bufProtosPath returns the wabisaby-protos dir after checking it exists and buf is installed
+func (s *ProtoService) bufProtosPath() (string, error) {
+	protosPath := filepath.Join(s.projectsDir, protosProjectName)
+	if stat, err := os.Stat(protosPath); err != nil || !stat.IsDir() {
+		return "", fmt.Errorf("wabisaby-protos not found at %s", protosPath)
+	}
+	if _, err := exec.LookPath("buf"); err != nil {
+		return "", ErrBufNotInstalled
+	}
+	return protosPath, nil
+}
+
+// runBuf runs `buf <args> --error-format=json` in wabisaby-protos and returns its annotations.
+// buf exits non-zero when it reports findings, so that only counts as failure when nothing was reported.
+func (s *ProtoService) runBuf(ctx context.Context, args ...string) ([]bufAnnotation, error) {
+	protosPath, err := s.bufProtosPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "buf", append(args, "--error-format=json")...)
+	cmd.Dir = protosPath
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	annotations := []bufAnnotation{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var a bufAnnotation
+		if err := json.Unmarshal([]byte(line), &a); err != nil {
+			a = bufAnnotation{Message: line}
+		}
+		annotations = append(annotations, a)
+	}
+
+	if runErr != nil && len(annotations) == 0 {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = runErr.Error()
+		}
+		return nil, fmt.Errorf("buf %s failed: %s", args[0], msg)
+	}
+	return annotations, nil
+}
+
+// CheckBreaking runs buf breaking in wabisaby-protos against the main branch and returns the
+// wire-incompatible changes found (empty when compatible). Returns ErrBufNotInstalled when buf
+// is missing. The result is remembered for LastBreaking.
+func (s *ProtoService) CheckBreaking(ctx context.Context) ([]model.ProtoBreak, error) {
+	annotations, err := s.runBuf(ctx, "breaking", "--against", protoBreakingAgainst)
+	if err != nil {
+		return nil, err
+	}
+	breaks := make([]model.ProtoBreak, 0, len(annotations))
+	for _, a := range annotations {
+		breaks = append(breaks, model.ProtoBreak{
+			File:    a.Path,
+			Line:    a.StartLine,
+			Column:  a.StartColumn,
+			Rule:    a.Type,
+			Message: a.Message,
+		})
+	}
+
+	s.breakMu.Lock()
+	s.lastBreaks = breaks
+	s.breakMu.Unlock()
+	return breaks, nil
+}
+
+// LastBreaking returns the breaking changes found by the most recent successful CheckBreaking
+// (nil if it hasn't run)
+func (s *ProtoService) LastBreaking() []model.ProtoBreak {
+	s.breakMu.Lock()
+	defer s.breakMu.Unlock()
+	return s.lastBreaks
+}
+
+// Lint runs buf lint in wabisaby-protos and returns the style issues found (empty when clean).
+// Returns ErrBufNotInstalled when buf is missing.
+func (s *ProtoService) Lint(ctx context.Context) ([]model.ProtoLintIssue, error) {
+	annotations, err := s.runBuf(ctx, "lint")
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]model.ProtoLintIssue, 0, len(annotations))
+	for _, a := range annotations {
+		issues = append(issues, model.ProtoLintIssue{
+			File:    a.Path,
+			Line:    a.StartLine,
+			Column:  a.StartColumn,
+			Rule:    a.Type,
+			Message: a.Message,
+		})
+	}
+	return issues, nil
+}
+
+// LintStream runs buf lint in wabisaby-protos and streams its text output (one issue per line,
+// "file:line:column:message") to the returned channel, ending with a [done] or [error] line.
+func (s *ProtoService) LintStream(ctx context.Context) (<-chan string, error) {
+	protosPath, err := s.bufProtosPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "buf", "lint")
+	cmd.Dir = protosPath
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start buf lint: %w", err)
+	}
+
+	ch := make(chan string, 100)
+	send := func(line string) bool {
+		select {
+		case ch <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(ch)
+
+		stderrDone := make(chan struct{})
+		go func() {
+			defer close(stderrDone)
+			scanner := bufio.NewScanner(stderr)
+			for scanner.Scan() {
+				if !send("[stderr] " + scanner.Text()) {
+					return
+				}
+			}
+		}()
+		issues := 0
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			issues++
+			if !send(scanner.Text()) {
+				break
+			}
+		}
+		<-stderrDone // all pipe reads must finish before Wait
+
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err == nil:
+			send("[done] No proto lint issues")
+		case issues > 0:
+			send(fmt.Sprintf("[done] %d proto lint issue(s)", issues))
+		default:
+			send(fmt.Sprintf("[error] buf lint failed: %v", err))
+		}
+	}()
+
+	return ch, nil
+}
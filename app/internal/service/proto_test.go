@@ -0,0 +1,29 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProtoServiceTargets(t *testing.T) {
+	s := NewProtoService(t.TempDir(), map[string]string{"web": "proto-ts-web", "plugin": "proto-plugin-v2"})
+	if got, want := s.Targets(), []string{"all", "node", "plugin", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Targets() = %v, want %v", got, want)
+	}
+	if s.makeTargets["plugin"] != "proto-plugin-v2" {
+		t.Errorf("plugin runs %q, want the configured override", s.makeTargets["plugin"])
+	}
+	for _, target := range []string{"", "all", "web"} {
+		if err := s.CheckTarget(target); err != nil {
+			t.Errorf("CheckTarget(%q) = %v", target, err)
+		}
+	}
+	if err := s.CheckTarget("ruby"); err == nil {
+		t.Error("CheckTarget(ruby) accepted an unknown target")
+	}
+
+	// Extra targets belong to that service only
+	if got := NewProtoService(t.TempDir(), nil).Targets(); !reflect.DeepEqual(got, []string{"all", "node", "plugin"}) {
+		t.Errorf("default Targets() = %v", got)
+	}
+}
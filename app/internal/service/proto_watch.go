@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// protoWatchDebounce is how long WatchStream waits after the last .proto change before regenerating,
+// so saving several files (or an editor's write-rename dance) triggers a single make proto.
+const protoWatchDebounce = 500 * time.Millisecond
+
+// WatchStream watches api/proto in wabisaby-protos and runs make proto whenever a .proto file
+// changes (debounced), streaming the same lines as RunProtoStream plus "[watch]" status lines.
+// Runs until ctx is cancelled; the channel is closed when watching stops.
+func (s *ProtoService) WatchStream(ctx context.Context) (<-chan string, error) {
+	protoDir := filepath.Join(s.projectsDir, protosProjectName, "api", "proto")
+	if stat, err := os.Stat(protoDir); err != nil || !stat.IsDir() {
+		return nil, fmt.Errorf("proto sources not found at %s", protoDir)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	if err := watchDirTree(watcher, protoDir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	ch := make(chan string, 100)
+	send := func(line string) bool {
+		select {
+		case ch <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(ch)
+		defer watcher.Close()
+
+		if !send(fmt.Sprintf("[watch] Watching %s for .proto changes", protoDir)) {
+			return
+		}
+
+		debounce := time.NewTimer(protoWatchDebounce)
+		debounce.Stop()
+		var changed string
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if !send(fmt.Sprintf("[watch] [stderr] %v", err)) {
+					return
+				}
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// New subdirectories need their own watch (fsnotify is not recursive)
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						_ = watchDirTree(watcher, event.Name)
+						continue
+					}
+				}
+				if filepath.Ext(event.Name) != ".proto" || event.Op == fsnotify.Chmod {
+					continue
+				}
+				changed = event.Name
+				debounce.Reset(protoWatchDebounce)
+			case <-debounce.C:
+				rel, err := filepath.Rel(protoDir, changed)
+				if err != nil {
+					rel = changed
+				}
+				if !send(fmt.Sprintf("[watch] %s changed, regenerating...", rel)) {
+					return
+				}
+				outputCh, err := s.RunProtoStream(ctx)
+				if err != nil {
+					if !send(fmt.Sprintf("[error] %v", err)) {
+						return
+					}
+					continue
+				}
+				for line := range outputCh {
+					if !send(line) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// watchDirTree adds root and every directory below it to watcher
+func watchDirTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxReadmeBytes caps how much of a README is returned for preview.
+const maxReadmeBytes = 256 * 1024
+
+// ErrReadmeNotFound is returned when a project has no README in its root.
+var ErrReadmeNotFound = errors.New("README not found")
+
+// readmeNames are README variants in order of preference (matched case-insensitively).
+var readmeNames = []string{"readme.md", "readme.markdown", "readme", "readme.txt", "readme.rst"}
+
+// readmeCacheEntry holds README content read at a given mtime.
+type readmeCacheEntry struct {
+	modTime time.Time
+	content string
+}
+
+var (
+	readmeMu    sync.Mutex
+	readmeCache = make(map[string]readmeCacheEntry)
+)
+
+// GetProjectReadme returns the project's README content, truncated to maxReadmeBytes.
+// Returns ErrReadmeNotFound when the project has no README. Results are cached by file mtime.
+func GetProjectReadme(projectsDir, projectName string) (string, error) {
+	projectDir := filepath.Join(projectsDir, projectName)
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("project not cloned: clone the project first")
+		}
+		return "", err
+	}
+
+	path := findReadme(projectDir, entries)
+	if path == "" {
+		return "", ErrReadmeNotFound
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", ErrReadmeNotFound
+	}
+
+	readmeMu.Lock()
+	if entry, ok := readmeCache[path]; ok && entry.modTime.Equal(info.ModTime()) {
+		readmeMu.Unlock()
+		return entry.content, nil
+	}
+	readmeMu.Unlock()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open README: %w", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxReadmeBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read README: %w", err)
+	}
+	content := string(data)
+
+	readmeMu.Lock()
+	readmeCache[path] = readmeCacheEntry{modTime: info.ModTime(), content: content}
+	readmeMu.Unlock()
+
+	return content, nil
+}
+
+// findReadme returns the path of the preferred README variant among entries, or "" if none.
+func findReadme(projectDir string, entries []os.DirEntry) string {
+	byLower := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			byLower[strings.ToLower(e.Name())] = e.Name()
+		}
+	}
+	for _, name := range readmeNames {
+		if actual, ok := byLower[name]; ok {
+			return filepath.Join(projectDir, actual)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetProjectReadmeVariants(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string // README variants present in the project
+		want  string   // the variant GetProjectReadme should pick
+	}{
+		{"markdown", []string{"README.md"}, "README.md"},
+		{"lowercase", []string{"readme.md"}, "readme.md"},
+		{"mixed case", []string{"ReadMe.Markdown"}, "ReadMe.Markdown"},
+		{"plain", []string{"README"}, "README"},
+		{"rst", []string{"Readme.rst"}, "Readme.rst"},
+		{"prefers markdown", []string{"README.txt", "README", "readme.MD"}, "readme.MD"},
+		{"prefers plain over txt", []string{"README.txt", "README"}, "README"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectsDir := t.TempDir()
+			for _, f := range tt.files {
+				writeProjectFile(t, projectsDir, "p", f, "from "+f)
+			}
+			got, err := GetProjectReadme(projectsDir, "p")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != "from "+tt.want {
+				t.Fatalf("README = %q, want content of %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProjectReadmeMissing(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "p", "main.go", "package main")
+	// A directory named like a README is not one
+	if err := os.Mkdir(filepath.Join(projectsDir, "p", "README.md"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetProjectReadme(projectsDir, "p"); !errors.Is(err, ErrReadmeNotFound) {
+		t.Fatalf("err = %v, want ErrReadmeNotFound", err)
+	}
+	if _, err := GetProjectReadme(projectsDir, "not-cloned"); err == nil || errors.Is(err, ErrReadmeNotFound) {
+		t.Fatalf("err = %v, want a not-cloned error", err)
+	}
+}
+
+func TestGetProjectReadmeSizeCap(t *testing.T) {
+	projectsDir := t.TempDir()
+	writeProjectFile(t, projectsDir, "p", "README.md", strings.Repeat("x", maxReadmeBytes+1024))
+
+	got, err := GetProjectReadme(projectsDir, "p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != maxReadmeBytes {
+		t.Fatalf("len(README) = %d, want %d", len(got), maxReadmeBytes)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// adoptedPollInterval is how often an adopted process (not our child, so not Wait-able) is checked for exit
+const adoptedPollInterval = time.Second
+
+// PortOwnerFunc returns the PID of the process listening on port, or 0 if there is none
+type PortOwnerFunc func(port int) int
+
+// SetPortOwnerLookup replaces the port-owner lookup used by Reconcile (e.g. to inject a fake in tests).
+func (pm *ProcessManager) SetPortOwnerLookup(fn PortOwnerFunc) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.portOwner = fn
+}
+
+// ProcessIdentity identifies a running process well enough to tell whether it is one of our services
+type ProcessIdentity struct {
+	Executable string // base name of the executable, e.g. "api"
+	Dir        string // working directory
+}
+
+// ProcessIdentityFunc looks up the executable and working directory of pid
+type ProcessIdentityFunc func(pid int) (ProcessIdentity, error)
+
+// SetProcessIdentityLookup replaces the process lookup Reconcile uses to verify a port owner before
+// adopting it (e.g. to inject a fake in tests).
+func (pm *ProcessManager) SetProcessIdentityLookup(fn ProcessIdentityFunc) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.processIdentity = fn
+}
+
+// Reconcile adopts processes listening on configured service ports that the manager is not tracking
+// (e.g. "go run" children orphaned by an unclean dashboard shutdown), so they can be stopped and
+// restarted like services we started. A port owner is only adopted when its executable and working
+// directory match the service (see ownsService); anything else on the port is left alone. Returns the
+// names of adopted services.
+func (pm *ProcessManager) Reconcile() []string {
+	pm.mu.RLock()
+	lookup, identify := pm.portOwner, pm.processIdentity
+	pm.mu.RUnlock()
+	if lookup == nil || identify == nil {
+		return nil
+	}
+
+	var adopted []string
+	for _, svc := range config.GetBackendServices() {
+		if svc.Port <= 0 {
+			continue
+		}
+		pm.mu.RLock()
+		proc, tracked := pm.processes[svc.Name]
+		active := tracked && (proc.State == ProcessRunning || proc.State == ProcessStarting || proc.State == ProcessStopping)
+		pm.mu.RUnlock()
+		if active {
+			continue
+		}
+
+		pid := lookup(svc.Port)
+		if pid <= 0 || pid == os.Getpid() {
+			continue
+		}
+		pi, err := identify(pid)
+		if err != nil {
+			log.Printf("Not adopting PID %d on port %d: %v", pid, svc.Port, err)
+			continue
+		}
+		if !pm.ownsService(&svc, pi) {
+			log.Printf("Not adopting PID %d on port %d: %s in %s is not %s", pid, svc.Port, pi.Executable, pi.Dir, svc.Name)
+			continue
+		}
+		if err := pm.adopt(svc, pid); err != nil {
+			log.Printf("Failed to adopt %s (PID %d on port %d): %v", svc.Name, pid, svc.Port, err)
+			continue
+		}
+		log.Printf("Adopted running service %s (PID %d on port %d)", svc.Name, pid, svc.Port)
+		adopted = append(adopted, svc.Name)
+	}
+	return adopted
+}
+
+// ownsService reports whether a process is an instance of svc: it must run in the service's directory,
+// from the binary "go run" builds for CmdPath (named after its last element) or the one BuildAndStart
+// builds (named after the service).
+func (pm *ProcessManager) ownsService(svc *config.BackendServiceConfig, pi ProcessIdentity) bool {
+	if !samePath(pi.Dir, pm.serviceDir(svc)) {
+		return false
+	}
+	exe := strings.TrimSuffix(pi.Executable, ".exe")
+	return exe == filepath.Base(svc.CmdPath) || exe == svc.Name
+}
+
+// samePath reports whether a and b name the same directory, resolving symlinks where possible
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// adopt registers pid as the running process for svc and starts a monitor that marks it stopped on
+// exit. Fails if the service is already tracked as active, so a concurrent Start is never replaced.
+func (pm *ProcessManager) adopt(svc config.BackendServiceConfig, pid int) error {
+	osProc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if !processAlive(pid) {
+		return fmt.Errorf("process %d is not running", pid)
+	}
+
+	proc := &ManagedProcess{
+		Name:        svc.Name,
+		State:       ProcessRunning,
+		PID:         pid,
+		Cmd:         &exec.Cmd{Process: osProc}, // lets Stop signal the process (never its group: Adopted)
+		StartTime:   time.Now(),
+		Adopted:     true,
+		subscribers: make(map[chan model.LogLine]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	pm.mu.Lock()
+	if prev, exists := pm.processes[svc.Name]; exists {
+		if prev.State == ProcessRunning || prev.State == ProcessStarting || prev.State == ProcessStopping {
+			pm.mu.Unlock()
+			return fmt.Errorf("%s is already %s", svc.Name, prev.State)
+		}
+		proc.restarts = prev.restarts
+	}
+	pm.processes[svc.Name] = proc
+	pm.recordPortStarted(svc.Name, svc.Port)
+	pm.mu.Unlock()
+
+	go pm.monitorAdopted(proc)
+	return nil
+}
+
+// monitorAdopted polls an adopted process until it exits, then marks it stopped and notifies
+func (pm *ProcessManager) monitorAdopted(proc *ManagedProcess) {
+	for processAlive(proc.PID) {
+		time.Sleep(adoptedPollInterval)
+	}
+
+	pm.mu.Lock()
+	close(proc.done)
+	proc.State = ProcessStopped
+	cb := pm.onExit
+	pm.mu.Unlock()
+
+	proc.broadcast(newLogLine(proc.Name, LogStreamSystem, "[Process exited]"))
+	log.Printf("Adopted service %s stopped", proc.Name)
+	if cb != nil {
+		cb(proc.Name, nil, nil)
+	}
+}
+
+// psProcessIdentity looks up a process's executable (ps) and working directory (lsof) on Unix. Not
+// supported on Windows, where Reconcile then adopts nothing.
+func psProcessIdentity(pid int) (ProcessIdentity, error) {
+	if runtime.GOOS == "windows" {
+		return ProcessIdentity{}, fmt.Errorf("process lookup is not supported on windows")
+	}
+	out, err := exec.Command("ps", "-o", "args=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ProcessIdentity{}, fmt.Errorf("ps: %w", err)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return ProcessIdentity{}, fmt.Errorf("process %d not found", pid)
+	}
+	info := ProcessIdentity{Executable: filepath.Base(fields[0])}
+
+	// -Fn prints one "n<path>" line for the cwd descriptor
+	out, err = exec.Command("lsof", "-a", "-p", strconv.Itoa(pid), "-d", "cwd", "-Fn").Output()
+	if err != nil {
+		return ProcessIdentity{}, fmt.Errorf("lsof: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "n") {
+			info.Dir = strings.TrimPrefix(line, "n")
+			break
+		}
+	}
+	if info.Dir == "" {
+		return ProcessIdentity{}, fmt.Errorf("working directory of process %d not found", pid)
+	}
+	return info, nil
+}
+
+// lsofPortOwner returns the PID listening on port via lsof (Unix); 0 if none or unsupported.
+func lsofPortOwner(port int) int {
+	if runtime.GOOS == "windows" {
+		// TODO: implement for Windows (netstat -ano)
+		return 0
+	}
+	out, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-t").Output()
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if pid, err := strconv.Atoi(strings.TrimSpace(line)); err == nil && pid > 0 {
+			return pid
+		}
+	}
+	return 0
+}
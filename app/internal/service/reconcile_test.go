@@ -0,0 +1,125 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startSleeper starts a child in the test's own process group, so signalling that group would
+// kill the test binary too
+func startSleeper(t *testing.T) (*exec.Cmd, chan struct{}) {
+	t.Helper()
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("sleep unavailable: %v", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait() // reaps the child so processAlive sees it go
+		close(exited)
+	}()
+	t.Cleanup(func() { cmd.Process.Kill() })
+	return cmd, exited
+}
+
+func TestReconcileAdoptsOnlyMatchingProcess(t *testing.T) {
+	root := t.TempDir()
+	cmd, exited := startSleeper(t)
+	pid := cmd.Process.Pid
+
+	pm := NewProcessManager(root, t.TempDir(), root)
+	pm.SetPortOwnerLookup(func(port int) int {
+		if port == 8080 {
+			return pid
+		}
+		return 0
+	})
+
+	identity := ProcessIdentity{Executable: "sleep", Dir: root}
+	pm.SetProcessIdentityLookup(func(int) (ProcessIdentity, error) { return identity, nil })
+	if adopted := pm.Reconcile(); len(adopted) != 0 {
+		t.Fatalf("adopted unrelated process: %v", adopted)
+	}
+
+	identity = ProcessIdentity{Executable: "api", Dir: t.TempDir()}
+	if adopted := pm.Reconcile(); len(adopted) != 0 {
+		t.Fatalf("adopted process from another directory: %v", adopted)
+	}
+
+	identity = ProcessIdentity{Executable: "api", Dir: root}
+	adopted := pm.Reconcile()
+	if len(adopted) != 1 || adopted[0] != "api" {
+		t.Fatalf("Reconcile = %v, want [api]", adopted)
+	}
+	if again := pm.Reconcile(); len(again) != 0 {
+		t.Fatalf("re-adopted an already tracked service: %v", again)
+	}
+
+	// Stopping signals only the adopted PID; signalling its group would take the test down with it
+	if err := pm.Stop("api"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("adopted process still running after Stop")
+	}
+	if status := pm.GetStatus("api"); status != string(ProcessStopped) {
+		t.Fatalf("status = %s, want stopped", status)
+	}
+}
+
+func TestRestartCountOnlyCountsCrashes(t *testing.T) {
+	// "go run" stands in for the service: it crashes while ./crash exists, otherwise keeps running
+	root, bin := t.TempDir(), t.TempDir()
+	script := "#!/bin/sh\n[ -f crash ] && exit 1\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(bin, "go"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	pm := NewProcessManager(root, t.TempDir(), root)
+	const svc = "stateful-plugin-worker" // no port: ready once it survives startGracePeriod
+	restarts := func() int {
+		return pm.Snapshot()[svc].Restarts
+	}
+
+	// A manual stop and start is not a restart
+	if err := pm.Start(svc); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Stop(svc); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Start(svc); err != nil {
+		t.Fatal(err)
+	}
+	if got := restarts(); got != 0 {
+		t.Errorf("restarts after stop/start = %d, want 0", got)
+	}
+	if err := pm.Stop(svc); err != nil {
+		t.Fatal(err)
+	}
+
+	// A crash is, and the count carries over to the next process
+	if err := os.WriteFile(filepath.Join(root, "crash"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Start(svc); err == nil {
+		t.Fatal("Start of a crashing service succeeded")
+	}
+	if err := os.Remove(filepath.Join(root, "crash")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Start(svc); err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop(svc)
+	if got := restarts(); got != 1 {
+		t.Errorf("restarts after one crash = %d, want 1", got)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+)
+
+// Crash webhook delivery: each attempt is bounded by crashWebhookTimeout and failed attempts are
+// retried after crashWebhookRetryDelay (doubling), up to crashWebhookAttempts in total. A 4xx
+// response other than 429 means the request itself is wrong, so it is not retried.
+const (
+	crashWebhookTimeout    = 10 * time.Second
+	crashWebhookAttempts   = 3
+	crashWebhookRetryDelay = time.Second
+	crashWebhookMaxLines   = 20   // lastOutput lines included in chat (slack/discord) messages
+	discordMaxContent      = 2000 // Discord rejects longer message content (in characters)
+)
+
+// CrashWebhook posts backend crash reports to an incoming webhook URL
+type CrashWebhook struct {
+	url        string
+	format     string
+	client     *http.Client
+	retryDelay time.Duration // before the second attempt, doubling after each
+}
+
+// permanentError is a delivery failure that retrying can't fix
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+// NewCrashWebhook returns a webhook posting to url in the given format (config.CrashWebhookFormat*),
+// or nil when url is empty
+func NewCrashWebhook(url, format string) *CrashWebhook {
+	if url == "" {
+		return nil
+	}
+	return &CrashWebhook{url: url, format: format, client: sharedHTTPClient, retryDelay: crashWebhookRetryDelay}
+}
+
+// SetHTTPClient replaces the client used to post reports; safe on a nil webhook
+func (w *CrashWebhook) SetHTTPClient(client *http.Client) {
+	if w != nil {
+		w.client = client
+	}
+}
+
+// Notify reports a crashed backend service in the background (fire-and-forget); safe on a nil webhook
+func (w *CrashWebhook) Notify(serviceName, errStr string, lastOutput []string) {
+	if w == nil {
+		return
+	}
+	body, err := w.payload(serviceName, errStr, lastOutput)
+	if err != nil {
+		return
+	}
+	go w.deliver(body)
+}
+
+// deliver posts body, retrying failed attempts that aren't permanent; returns the last error
+func (w *CrashWebhook) deliver(body []byte) error {
+	delay := w.retryDelay
+	var err error
+	for attempt := 1; attempt <= crashWebhookAttempts; attempt++ {
+		if err = w.post(body); err == nil {
+			return nil
+		}
+		if _, permanent := err.(permanentError); permanent {
+			return err
+		}
+		if attempt < crashWebhookAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return err
+}
+
+// payload encodes the crash report in the webhook's format
+func (w *CrashWebhook) payload(serviceName, errStr string, lastOutput []string) ([]byte, error) {
+	if w.format == config.CrashWebhookFormatSlack || w.format == config.CrashWebhookFormatDiscord {
+		lines := lastOutput
+		if len(lines) > crashWebhookMaxLines {
+			lines = lines[len(lines)-crashWebhookMaxLines:]
+		}
+		text := fmt.Sprintf(":rotating_light: *%s* crashed: %s", serviceName, errStr)
+		if w.format == config.CrashWebhookFormatDiscord {
+			text = fmt.Sprintf(":rotating_light: **%s** crashed: %s", serviceName, errStr)
+			return json.Marshal(map[string]string{"content": discordContent(text, lines)})
+		}
+		if len(lines) > 0 {
+			text += "\n```\n" + strings.Join(lines, "\n") + "\n```"
+		}
+		return json.Marshal(map[string]string{"text": text})
+	}
+	return json.Marshal(map[string]interface{}{
+		"service":    serviceName,
+		"error":      errStr,
+		"lastOutput": lastOutput,
+	})
+}
+
+// discordContent appends as many of the newest output lines to header as fit in Discord's content
+// limit, cutting the header itself (with "…") when even that is too long
+func discordContent(header string, lines []string) string {
+	if utf8.RuneCountInString(header) > discordMaxContent {
+		return string([]rune(header)[:discordMaxContent-1]) + "…"
+	}
+	// Drop the oldest lines until the code block fits
+	for ; len(lines) > 0; lines = lines[1:] {
+		text := header + "\n```\n" + strings.Join(lines, "\n") + "\n```"
+		if utf8.RuneCountInString(text) <= discordMaxContent {
+			return text
+		}
+	}
+	return header
+}
+
+// post sends one delivery attempt; non-2xx responses are errors, permanent for a 4xx other than 429
+func (w *CrashWebhook) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), crashWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer DrainAndClose(resp.Body)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{fmt.Errorf("webhook returned %s", resp.Status)}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
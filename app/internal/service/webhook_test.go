@@ -0,0 +1,77 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+)
+
+// newTestWebhook returns a webhook posting to a server that answers with status, and its request count
+func newTestWebhook(t *testing.T, format string, status int) (*CrashWebhook, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	w := NewCrashWebhook(srv.URL, format)
+	w.retryDelay = 0
+	return w, &requests
+}
+
+func TestCrashWebhookRetries(t *testing.T) {
+	for _, tt := range []struct {
+		status   int
+		attempts int32
+	}{
+		{http.StatusNoContent, 1},
+		{http.StatusBadRequest, 1}, // permanent: the payload or URL is wrong
+		{http.StatusNotFound, 1},
+		{http.StatusTooManyRequests, crashWebhookAttempts},
+		{http.StatusBadGateway, crashWebhookAttempts},
+	} {
+		w, requests := newTestWebhook(t, config.CrashWebhookFormatJSON, tt.status)
+		err := w.deliver([]byte(`{}`))
+		if (err == nil) != (tt.status < 300) {
+			t.Errorf("status %d: deliver = %v", tt.status, err)
+		}
+		if got := requests.Load(); got != tt.attempts {
+			t.Errorf("status %d: %d attempts, want %d", tt.status, got, tt.attempts)
+		}
+	}
+}
+
+func TestDiscordPayloadFitsContentLimit(t *testing.T) {
+	w, _ := newTestWebhook(t, config.CrashWebhookFormatDiscord, http.StatusNoContent)
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = strings.Repeat("é", 150) // multi-byte: the limit counts characters
+	}
+	lines[19] = "last line"
+
+	for _, errStr := range []string{"exit status 2", strings.Repeat("x", 3000)} {
+		body, err := w.payload("api", errStr, lines)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg struct {
+			Content string `json:"content"`
+		}
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if n := utf8.RuneCountInString(msg.Content); n > discordMaxContent {
+			t.Errorf("content is %d characters, over Discord's %d", n, discordMaxContent)
+		}
+		if len(errStr) < 100 && (!strings.Contains(msg.Content, "last line") || !strings.HasSuffix(msg.Content, "```")) {
+			t.Errorf("content lost the newest output or the closing fence:\n%s", msg.Content)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+func TestSortNotices(t *testing.T) {
+	notices := []model.Notice{
+		{ID: "ports", Severity: "warn"},
+		{ID: "env-diff", Severity: "info"},
+		{ID: "proto-breaking", Severity: "warn"},
+		{ID: "deps", Severity: "warn"},
+		{ID: "env-invalid", Severity: "warn"},
+		{ID: "sync", Severity: "warn"},
+		{ID: "proto", Severity: "warn"},
+		{ID: "env", Severity: "error"},
+		{ID: "migration", Severity: "warn"},
+		{ID: "docker", Severity: "info"},
+	}
+	sortNotices(notices)
+
+	var got []string
+	for _, n := range notices {
+		got = append(got, n.ID)
+	}
+	want := []string{"env", "sync", "proto", "proto-breaking", "deps", "migration", "env-invalid", "ports", "env-diff", "docker"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted notices = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// operation records how a stream was started so it can be re-run with the same parameters
+type operation struct {
+	Kind   string   // "project", "test-run", "bulk", "migration", "migration-down-n", "migration-goto", "proto", "release-protos-go"
+	Params []string // Start*Stream arguments, in order
+	At     time.Time
+}
+
+// recordFailedOperation remembers op as the most recent failed operation
+func (a *App) recordFailedOperation(op *operation) {
+	a.opMu.Lock()
+	a.lastFailedOp = op
+	a.opMu.Unlock()
+}
+
+// recordOperationActivity adds a finished stream operation (clone, migration, proto generation, ...)
+// to the Activity feed
+func (a *App) recordOperationActivity(op *operation, completed, failed bool) {
+	typ, target := op.Kind, strings.Join(op.Params, " ")
+	switch op.Kind {
+	case "project", "test-run":
+		typ, target = "project", op.Params[0]
+	case "migration-down-n", "migration-goto":
+		typ = "migration"
+	}
+	ev := model.ActivityEvent{Type: typ, Target: target, Message: strings.Join(append([]string{op.Kind}, op.Params...), " ")}
+	switch {
+	case !completed:
+		ev.Level, ev.Message = "warn", ev.Message+" cancelled"
+	case failed:
+		ev.Message += " failed"
+	default:
+		ev.Success, ev.Message = true, ev.Message+" completed"
+	}
+	a.recordActivity(ev)
+}
+
+// RerunLastFailed re-runs the most recent failed stream operation (project action, test run, bulk
+// action, migration, proto generation or protos-go release) with its original parameters.
+func (a *App) RerunLastFailed() error {
+	a.opMu.Lock()
+	op := a.lastFailedOp
+	a.opMu.Unlock()
+
+	if op == nil {
+		return fmt.Errorf("no failed operation to rerun")
+	}
+
+	switch op.Kind {
+	case "project":
+		return a.StartProjectStream(op.Params[0], op.Params[1])
+	case "test-run":
+		return a.StartProjectTestRun(op.Params[0], op.Params[1], op.Params[2])
+	case "bulk":
+		return a.StartBulkProjectStream(op.Params[0])
+	case "migration":
+		return a.StartMigrationStream(op.Params[0])
+	case "migration-down-n":
+		n, err := strconv.Atoi(op.Params[0])
+		if err != nil {
+			return err
+		}
+		return a.StartMigrationDownNStream(n)
+	case "migration-goto":
+		version, err := strconv.ParseUint(op.Params[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		return a.StartMigrationGotoStream(uint(version))
+	case "proto":
+		return a.StartProtoStreamTarget(op.Params[0])
+	case "release-protos-go":
+		return a.StartReleaseProtosGoStream(op.Params[0])
+	default:
+		return fmt.Errorf("unknown operation kind: %s", op.Kind)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/activity"
+)
+
+// lastFailed returns the operation RerunLastFailed would re-run
+func (a *App) lastFailed() *operation {
+	a.opMu.Lock()
+	defer a.opMu.Unlock()
+	return a.lastFailedOp
+}
+
+func TestRerunLastFailedUsesSameParams(t *testing.T) {
+	a := newTestApp(t)
+	a.projectsDir = t.TempDir()
+	a.activity = activity.NewStore(t.TempDir(), activity.DefaultMaxEvents)
+	events := recordEvents(a)
+	projectDir := filepath.Join(a.projectsDir, "core")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	makefile := "lint:\n\t@echo linting core\n\t@exit 3\n\nbuild:\n\t@echo building\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "Makefile"), []byte(makefile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	seeded := &operation{Kind: "project", Params: []string{"core", "lint"}, At: time.Now().Add(-time.Minute)}
+	a.recordFailedOperation(seeded)
+	if err := a.RerunLastFailed(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The re-run fails again, so it becomes the last failed operation once its stream finishes
+	deadline := time.Now().Add(10 * time.Second)
+	for a.lastFailed() == seeded {
+		if time.Now().After(deadline) {
+			t.Fatal("re-run did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	rerun := a.lastFailed()
+	if rerun.Kind != seeded.Kind || !reflect.DeepEqual(rerun.Params, seeded.Params) {
+		t.Errorf("re-run operation = %s %v, want %s %v", rerun.Kind, rerun.Params, seeded.Kind, seeded.Params)
+	}
+
+	done := events.named("devkit:project:stream:done")
+	if len(done) != 1 {
+		t.Fatalf("got %d done events, want 1", len(done))
+	}
+	p := done[0].payload
+	if p["project"] != "core" || p["action"] != "lint" || p["success"] != false || p["exitCode"] != 2 {
+		t.Errorf("done payload = %v, want core lint failing with make's exit code 2", p)
+	}
+	ran := false
+	for _, ev := range events.named("devkit:project:stream") {
+		if ev.payload["line"] == "linting core" {
+			ran = true
+		}
+		if ev.payload["line"] == "building" {
+			t.Error("re-run ran the build target")
+		}
+	}
+	if !ran {
+		t.Error("re-run did not run the lint target")
+	}
+}
+
+func TestRerunLastFailedNothingToRerun(t *testing.T) {
+	a := newTestApp(t)
+	if err := a.RerunLastFailed(); err == nil {
+		t.Error("RerunLastFailed with no failed operation succeeded, want an error")
+	}
+
+	a.recordFailedOperation(&operation{Kind: "teleport", Params: []string{"core"}})
+	if err := a.RerunLastFailed(); err == nil {
+		t.Error("RerunLastFailed of an unknown kind succeeded, want an error")
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/wabisaby/devkit-dashboard/internal/config"
+	"github.com/wabisaby/devkit-dashboard/internal/service"
+)
+
+// newSettingsTestApp returns an app with its settings in a temp dir and a connected maintainer, so
+// permission-gated setters are allowed
+func newSettingsTestApp(t *testing.T) *App {
+	t.Helper()
+	a := newTestApp(t)
+	a.appDataDir = t.TempDir()
+	a.processManager = service.NewProcessManager(t.TempDir(), t.TempDir(), "")
+	authDir := t.TempDir()
+	auth := `{"accessToken": "token", "username": "octo", "teams": ["maintainers"]}`
+	if err := os.WriteFile(filepath.Join(authDir, "github_auth.json"), []byte(auth), 0600); err != nil {
+		t.Fatal(err)
+	}
+	a.githubSvc = service.NewGitHubService("", []string{"WabiSaby"}, authDir)
+	return a
+}
+
+func TestConcurrentBuildModeChanges(t *testing.T) {
+	a := newSettingsTestApp(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		mode := config.BackendBuildModeRun
+		if i%2 == 0 {
+			mode = config.BackendBuildModeBuild
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each change reads settings.json; a concurrent write must never leave it half written
+			if err := a.SetBackendBuildMode(mode); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := config.LoadSettings(a.appDataDir); err != nil {
+		t.Fatalf("settings unreadable after concurrent changes: %v", err)
+	}
+}
+
+func TestConcurrentKeepANSIAndBuildModeChangesBothPersist(t *testing.T) {
+	a := newSettingsTestApp(t)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := a.SetKeepANSI(true); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := a.SetBackendBuildMode(config.BackendBuildModeBuild); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settings.KeepANSI || settings.BackendBuildMode != config.BackendBuildModeBuild {
+		t.Errorf("settings = %+v, want both changes kept", settings)
+	}
+}
+
+func TestServiceEnvOverrides(t *testing.T) {
+	a := newSettingsTestApp(t)
+	t.Cleanup(func() { config.SetServiceEnvOverrides(nil) })
+
+	// Concurrent changes to different vars (and another setting) all persist
+	var wg sync.WaitGroup
+	for _, key := range []string{"LOG_LEVEL", "PORT", "FEATURE_X", "TRACE"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			if err := a.SetServiceEnvOverride("api", key, "1"); err != nil {
+				t.Error(err)
+			}
+		}(key)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := a.SetKeepANSI(true); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+	settings, err := config.LoadSettings(a.appDataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings.ServiceEnvOverrides["api"]) != 4 || !settings.KeepANSI {
+		t.Fatalf("settings = %+v, want 4 api overrides and KeepANSI", settings)
+	}
+
+	// An empty value is an override; only Delete removes one
+	if err := a.SetServiceEnvOverride("api", "LOG_LEVEL", ""); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := config.GetServiceByName("api").EnvOverrides["LOG_LEVEL"]; !ok || v != "" {
+		t.Errorf("LOG_LEVEL override = %q, %v; want an empty override", v, ok)
+	}
+	for _, key := range []string{"LOG_LEVEL", "PORT", "FEATURE_X", "TRACE"} {
+		if err := a.DeleteServiceEnvOverride("api", key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	settings, _ = config.LoadSettings(a.appDataDir)
+	if _, ok := settings.ServiceEnvOverrides["api"]; ok || len(config.GetServiceByName("api").EnvOverrides) != 0 {
+		t.Errorf("overrides after deleting every var = %v", settings.ServiceEnvOverrides)
+	}
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wabisaby/devkit-dashboard/internal/model"
+)
+
+// streamStopWait bounds how long StopStream waits for a cancelled stream to wind down
+const streamStopWait = 2 * time.Second
+
+// Replay buffering (ResumeStream): every event a stream emits gets a sequence number ("seq" in the
+// payload, starting at 1 per stream run) and the id of its run ("run", unique across the app's
+// streams), and the last streamReplayEvents of them are kept per stream id.
+// When the buffer is full the oldest event is evicted. A stream's buffer outlives the stream so a
+// reconnecting panel can still fetch the tail and the :done event; it is replaced when a stream with
+// the same id starts, and once more than maxReplayStreams ids are buffered the buffers of the
+// longest-finished streams are dropped.
+const (
+	streamReplayEvents = 500
+	maxReplayStreams   = 64
+)
+
+// replayBuffer holds the recent events of one stream run
+type replayBuffer struct {
+	run uint64 // run id, fixed at creation
+
+	mu         sync.Mutex
+	events     []model.StreamEvent
+	lastSeq    int
+	finished   bool
+	finishedAt time.Time
+}
+
+// add numbers an event, stores it (evicting the oldest past streamReplayEvents) and sets payload["seq"]
+// and payload["run"]
+func (b *replayBuffer) add(event string, payload map[string]interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastSeq++
+	payload["seq"] = b.lastSeq
+	payload["run"] = b.run
+	b.events = append(b.events, model.StreamEvent{Seq: b.lastSeq, Event: event, Payload: payload})
+	if len(b.events) > streamReplayEvents {
+		b.events = append(b.events[:0:0], b.events[len(b.events)-streamReplayEvents:]...)
+	}
+}
+
+// finish marks the stream run over
+func (b *replayBuffer) finish() {
+	b.mu.Lock()
+	b.finished = true
+	b.finishedAt = time.Now()
+	b.mu.Unlock()
+}
+
+// activeStream tracks a running output stream (project operation, logs, migration, ...)
+// so it can be cancelled by ID and report its final state.
+type activeStream struct {
+	id     string
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	op         *operation // set for re-runnable operations; recorded if the stream fails
+	replay     *replayBuffer
+	eventsEmit func(ctx context.Context, eventName string, optionalData ...interface{}) // App.eventsEmit
+
+	mu         sync.Mutex
+	lines      int
+	exitCode   *int
+	completed  bool
+	failed     bool
+	superseded bool // replaced by a newer stream with the same id; its remaining events are dropped
+}
+
+// startStream registers a stream under id, cancelling any existing stream with the same id.
+// The returned context is cancelled when the stream is stopped or the app shuts down.
+//
+// A replaced stream is marked superseded so its late events (e.g. its cancelled :done) can't be
+// mistaken for the new stream's, and startStream waits briefly for it to wind down so the two
+// don't run their commands side by side. Only the registered stream itself unregisters its id
+// (finishStream compares identity), so the old stream's deferred cleanup never removes the new one.
+func (a *App) startStream(id string) (context.Context, *activeStream) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	stream := &activeStream{
+		id:     id,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+
+		eventsEmit: a.eventsEmit,
+	}
+
+	a.streamMu.Lock()
+	a.streamRuns++
+	stream.replay = &replayBuffer{run: a.streamRuns}
+	existing := a.activeStreams[id]
+	a.activeStreams[id] = stream
+	a.streamReplay[id] = stream.replay
+	a.evictReplayBuffers()
+	a.streamMu.Unlock()
+
+	if existing != nil {
+		existing.mu.Lock()
+		existing.superseded = true
+		existing.mu.Unlock()
+		existing.cancel()
+		select {
+		case <-existing.done:
+		case <-time.After(streamStopWait):
+		}
+	}
+
+	return ctx, stream
+}
+
+// finishStream marks the stream done and unregisters it, unless a newer stream already replaced it.
+// A stream that finishes before being cancelled is recorded as completed; if it also reported
+// failure, its operation becomes the one RerunLastFailed re-runs.
+func (a *App) finishStream(stream *activeStream) {
+	stream.mu.Lock()
+	stream.completed = stream.ctx.Err() == nil
+	failed := stream.failed && stream.completed
+	superseded := stream.superseded
+	stream.mu.Unlock()
+	stream.cancel()
+
+	if failed && stream.op != nil {
+		a.recordFailedOperation(stream.op)
+	}
+	if stream.op != nil && !superseded {
+		a.recordOperationActivity(stream.op, stream.completed, failed)
+	}
+
+	a.streamMu.Lock()
+	if current, ok := a.activeStreams[stream.id]; ok && current == stream {
+		delete(a.activeStreams, stream.id)
+	}
+	a.streamMu.Unlock()
+	stream.replay.finish()
+
+	close(stream.done)
+}
+
+// stopStream cancels and unregisters the stream with the given id. Returns nil if no such stream is active.
+func (a *App) stopStream(id string) *activeStream {
+	a.streamMu.Lock()
+	stream, ok := a.activeStreams[id]
+	if ok {
+		delete(a.activeStreams, id)
+	}
+	a.streamMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	stream.cancel()
+	return stream
+}
+
+// stopAllStreams cancels and unregisters every active stream, returning their ids (sorted)
+func (a *App) stopAllStreams() []string {
+	a.streamMu.Lock()
+	streams := a.activeStreams
+	a.activeStreams = make(map[string]*activeStream)
+	a.streamMu.Unlock()
+
+	ids := make([]string, 0, len(streams))
+	for id, stream := range streams {
+		stream.cancel()
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// emit sends a stream event to the frontend, counting it as an output line when the payload carries one.
+// A done event reporting success false marks the stream as failed. Every event is numbered ("seq")
+// and buffered for ResumeStream. Events of a superseded stream are
+// dropped: they share the event name of the stream that replaced it.
+//
+// Numbering and sending happen under the stream's lock, so the frontend receives a stream's events
+// in seq order even when several goroutines (e.g. stdout and stderr readers) emit at once.
+func (s *activeStream) emit(appCtx context.Context, event string, payload map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.superseded {
+		return
+	}
+	if _, ok := payload["line"]; ok {
+		s.lines++
+	}
+	if success, ok := payload["success"].(bool); ok && !success && strings.HasSuffix(event, ":done") {
+		s.failed = true
+	}
+	s.replay.add(event, payload)
+	s.eventsEmit(appCtx, event, payload)
+}
+
+// setExitCode records the exit code of the stream's underlying command
+func (s *activeStream) setExitCode(code int) {
+	s.mu.Lock()
+	s.exitCode = &code
+	s.mu.Unlock()
+}
+
+// finalState returns a snapshot of the stream's state for StopStream
+func (s *activeStream) finalState() model.StreamFinalState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := model.StreamFinalState{
+		Token:     s.id,
+		WasActive: true,
+		Lines:     s.lines,
+		Completed: s.completed,
+	}
+	if s.exitCode != nil {
+		code := *s.exitCode
+		state.ExitCode = &code
+	}
+	return state
+}
+
+// StopStream cancels the stream identified by token (e.g. "project:wabisaby-core:test", "migration:up")
+// and waits briefly for it to wind down, returning its final state. An unknown token (never started or
+// already finished) is not an error: the result has WasActive false.
+func (a *App) StopStream(token string) (model.StreamFinalState, error) {
+	stream := a.stopStream(token)
+	if stream == nil {
+		return model.StreamFinalState{Token: token, Message: "stream not active"}, nil
+	}
+
+	select {
+	case <-stream.done:
+	case <-time.After(streamStopWait):
+		state := stream.finalState()
+		state.Message = "stream cancelled; still shutting down"
+		return state, nil
+	}
+
+	state := stream.finalState()
+	if state.Completed {
+		state.Message = "stream had already completed"
+	} else {
+		state.Message = "stream cancelled"
+	}
+	return state, nil
+}
+
+// evictReplayBuffers drops the buffers of the longest-finished streams while more than
+// maxReplayStreams ids are buffered. Buffers of running streams are never dropped. Called with
+// streamMu held.
+func (a *App) evictReplayBuffers() {
+	for len(a.streamReplay) > maxReplayStreams {
+		oldestID := ""
+		var oldest time.Time
+		for id, b := range a.streamReplay {
+			b.mu.Lock()
+			finished, at := b.finished, b.finishedAt
+			b.mu.Unlock()
+			if finished && (oldestID == "" || at.Before(oldest)) {
+				oldestID, oldest = id, at
+			}
+		}
+		if oldestID == "" {
+			return
+		}
+		delete(a.streamReplay, oldestID)
+	}
+}
+
+// ResumeStream returns the buffered events of the stream identified by token with a sequence number
+// above afterSeq (0 for everything buffered), so a panel that reconnected (e.g. after a webview
+// reload) can replay the output it missed. run is the "run" of the last event the panel saw (0 if
+// unknown): seq restarts with every run, so when the buffered run differs the replay is Reset and
+// starts from the run's first event. Only the last streamReplayEvents events of a stream are kept;
+// Truncated is set when older ones were needed. An unknown token is not an error.
+func (a *App) ResumeStream(token string, run uint64, afterSeq int) (model.StreamReplay, error) {
+	a.streamMu.Lock()
+	b, ok := a.streamReplay[token]
+	_, active := a.activeStreams[token]
+	a.streamMu.Unlock()
+
+	replay := model.StreamReplay{Token: token, Active: active, Events: []model.StreamEvent{}}
+	if !ok {
+		replay.Message = "no buffered output for this stream"
+		return replay, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	replay.Run = b.run
+	replay.LastSeq = b.lastSeq
+	if (run != 0 && run != b.run) || afterSeq > b.lastSeq {
+		// The panel saw a previous run of this stream id; start over
+		replay.Reset = true
+		afterSeq = 0
+	}
+	for _, e := range b.events {
+		if e.Seq > afterSeq {
+			replay.Events = append(replay.Events, e)
+		}
+	}
+	if len(replay.Events) > 0 && replay.Events[0].Seq > afterSeq+1 {
+		replay.Truncated = true
+	}
+	return replay, nil
+}
+
+// StopAllStreams cancels every active stream (project actions, bulk runs, log tails, migrations, ...)
+// and returns how many were stopped. Emits devkit:streams:stopped with the stopped stream tokens.
+func (a *App) StopAllStreams() int {
+	ids := a.stopAllStreams()
+	a.emitEvent("devkit:streams:stopped", map[string]interface{}{
+		"count":  len(ids),
+		"tokens": ids,
+	})
+	return len(ids)
+}
+
+// lineFilter reports whether a log line should be emitted; nil emits everything
+type lineFilter func(line string) bool
+
+// newLineFilter returns a filter matching lines that contain pattern, or match it as a regular
+// expression when regex is set. An empty pattern returns nil (no filtering).
+func newLineFilter(pattern string, regex bool) (lineFilter, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if !regex {
+		return func(line string) bool { return strings.Contains(line, pattern) }, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter regex: %w", err)
+	}
+	return re.MatchString, nil
+}
+
+// match reports whether line passes the filter
+func (f lineFilter) match(line string) bool {
+	return f == nil || f(line)
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestApp returns an App with just the state stream handling needs
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return &App{
+		ctx:           ctx,
+		activeStreams: make(map[string]*activeStream),
+		streamReplay:  make(map[string]*replayBuffer),
+		eventsEmit:    func(context.Context, string, ...interface{}) {},
+	}
+}
+
+// recordedEvent is one event an App sent to the frontend
+type recordedEvent struct {
+	name    string
+	payload map[string]interface{}
+}
+
+// eventRecorder collects the events an App emits (see recordEvents)
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+// recordEvents makes a record every event it emits instead of sending it to the frontend
+func recordEvents(a *App) *eventRecorder {
+	r := &eventRecorder{}
+	a.eventsEmit = func(_ context.Context, name string, data ...interface{}) {
+		ev := recordedEvent{name: name}
+		if len(data) > 0 {
+			ev.payload, _ = data[0].(map[string]interface{})
+		}
+		r.mu.Lock()
+		r.events = append(r.events, ev)
+		r.mu.Unlock()
+	}
+	return r
+}
+
+// named returns the recorded events called name, in emission order
+func (r *eventRecorder) named(name string) []recordedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []recordedEvent
+	for _, ev := range r.events {
+		if ev.name == name {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// runUntilCancelled starts a stream that runs until it is cancelled, like a log tail
+func runUntilCancelled(a *App, id string) *activeStream {
+	ctx, stream := a.startStream(id)
+	go func() {
+		<-ctx.Done()
+		a.finishStream(stream)
+	}()
+	return stream
+}
+
+func TestStopStreamActive(t *testing.T) {
+	a := newTestApp(t)
+	runUntilCancelled(a, "project:svc:test")
+
+	state, err := a.StopStream("project:svc:test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.WasActive || state.Completed || state.Message != "stream cancelled" {
+		t.Fatalf("state = %+v, want an active stream cancelled before completing", state)
+	}
+	a.streamMu.Lock()
+	_, still := a.activeStreams["project:svc:test"]
+	a.streamMu.Unlock()
+	if still {
+		t.Fatal("stopped stream is still registered")
+	}
+
+	// Stopping it again finds nothing to stop
+	if state, _ := a.StopStream("project:svc:test"); state.WasActive {
+		t.Fatalf("second stop: state = %+v, want inactive", state)
+	}
+}
+
+func TestStopStreamUnknownToken(t *testing.T) {
+	a := newTestApp(t)
+
+	state, err := a.StopStream("migration:up")
+	if err != nil {
+		t.Fatalf("unknown token returned an error: %v", err)
+	}
+	if state.WasActive || state.Token != "migration:up" || state.Message != "stream not active" {
+		t.Fatalf("state = %+v", state)
+	}
+}
+
+func TestStopStreamAfterCompletion(t *testing.T) {
+	a := newTestApp(t)
+	_, stream := a.startStream("proto:generate")
+	stream.setExitCode(0)
+	a.finishStream(stream)
+
+	if state, _ := a.StopStream("proto:generate"); state.WasActive {
+		t.Fatalf("finished stream reported active: %+v", state)
+	}
+	if final := stream.finalState(); !final.Completed || final.ExitCode == nil || *final.ExitCode != 0 {
+		t.Fatalf("final state = %+v, want completed with exit code 0", final)
+	}
+}
+
+func TestLineFilterPlain(t *testing.T) {
+	f, err := newLineFilter("ERROR [db", false)
+	if err != nil {
+		t.Fatalf("plain filter with regex metacharacters: %v", err)
+	}
+	for line, want := range map[string]bool{
+		"2024-01-01 ERROR [db] connection refused": true,
+		"2024-01-01 error [db] connection refused": false, // case-sensitive
+		"2024-01-01 ERROR [http] timeout":          false,
+		"":                                         false,
+	} {
+		if got := f.match(line); got != want {
+			t.Errorf("match(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestLineFilterRegex(t *testing.T) {
+	f, err := newLineFilter(`(?i)^\S+ (warn|error)\b`, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for line, want := range map[string]bool{
+		"12:00:01 ERROR boom":   true,
+		"12:00:01 warn slow":    true,
+		"12:00:01 INFO ok":      false,
+		"12:00:01 warning slow": false,
+	} {
+		if got := f.match(line); got != want {
+			t.Errorf("match(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestLineFilterInvalidRegex(t *testing.T) {
+	f, err := newLineFilter("error(", true)
+	if err == nil || !strings.Contains(err.Error(), "invalid filter regex") {
+		t.Fatalf("newLineFilter(%q) error = %v, want an invalid filter regex error", "error(", err)
+	}
+	if f != nil {
+		t.Error("invalid regex returned a filter")
+	}
+	// The same text is fine as a plain filter
+	if _, err := newLineFilter("error(", false); err != nil {
+		t.Errorf("plain filter %q: %v", "error(", err)
+	}
+}
+
+func TestLineFilterEmptyMatchesEverything(t *testing.T) {
+	f, err := newLineFilter("", true)
+	if err != nil || f != nil {
+		t.Fatalf("newLineFilter(\"\") = %v, %v; want nil, nil", f, err)
+	}
+	if !f.match("anything") || !f.match("") {
+		t.Error("nil filter rejected a line")
+	}
+}
+
+func TestRestartedStreamSurvivesOldRunFinishing(t *testing.T) {
+	a := newTestApp(t)
+	events := recordEvents(a)
+	const id = "project:core:test"
+
+	// The old run reports its cancellation and cleans up a moment after being cancelled, by which
+	// time the new run is already registered under the same id
+	oldCtx, old := a.startStream(id)
+	old.emit(a.ctx, "devkit:project:stream", map[string]interface{}{"line": "old output"})
+	oldFinished := make(chan struct{})
+	go func() {
+		defer close(oldFinished)
+		<-oldCtx.Done()
+		time.Sleep(20 * time.Millisecond)
+		old.emit(a.ctx, "devkit:project:stream:done", map[string]interface{}{"success": false, "error": "cancelled"})
+		a.finishStream(old)
+	}()
+
+	_, current := a.startStream(id)
+	<-oldFinished
+	current.emit(a.ctx, "devkit:project:stream", map[string]interface{}{"line": "new output"})
+
+	a.streamMu.Lock()
+	registered := a.activeStreams[id]
+	replay := a.streamReplay[id]
+	a.streamMu.Unlock()
+	if registered != current {
+		t.Fatal("the old run's finish unregistered the new run")
+	}
+	if replay != current.replay {
+		t.Error("the replay buffer belongs to the old run")
+	}
+	if done := events.named("devkit:project:stream:done"); len(done) != 0 {
+		t.Errorf("the old run's late done event reached the frontend: %v", done)
+	}
+	if lines := events.named("devkit:project:stream"); len(lines) != 2 || lines[1].payload["line"] != "new output" {
+		t.Errorf("stream events = %v, want the old line then the new one", lines)
+	}
+	if old.finalState().Completed {
+		t.Error("the cancelled old run is reported completed")
+	}
+
+	// The new run is still the one a stop reaches
+	go func() {
+		<-current.ctx.Done()
+		a.finishStream(current)
+	}()
+	state, err := a.StopStream(id)
+	if err != nil || !state.WasActive || state.Lines != 1 {
+		t.Errorf("StopStream after restart = %+v, %v; want the new run, active with 1 line", state, err)
+	}
+}
+
+func TestResumeStreamResetsOnNewRun(t *testing.T) {
+	a := newTestApp(t)
+	const id = "proto:generate"
+
+	_, first := a.startStream(id)
+	for i := 0; i < 5; i++ {
+		first.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{"line": "first run"})
+	}
+	a.finishStream(first)
+	_, second := a.startStream(id)
+	for i := 0; i < 3; i++ {
+		second.emit(a.ctx, "devkit:proto:stream", map[string]interface{}{"line": "second run"})
+	}
+
+	// A panel that saw seq 2 of the first run: seq 2 also exists in the second run, so only the
+	// run id tells them apart
+	replay, err := a.ResumeStream(id, first.replay.run, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replay.Reset || replay.Run != second.replay.run || len(replay.Events) != 3 || replay.Events[0].Seq != 1 {
+		t.Errorf("resume from the first run = %+v, want a reset to the second run's 3 events", replay)
+	}
+	if got := replay.Events[0].Payload["run"]; got != second.replay.run {
+		t.Errorf("replayed event run = %v, want %d", got, second.replay.run)
+	}
+
+	// The same run picks up where it left off
+	replay, _ = a.ResumeStream(id, second.replay.run, 2)
+	if replay.Reset || len(replay.Events) != 1 || replay.Events[0].Seq != 3 {
+		t.Errorf("resume within the second run = %+v, want just seq 3", replay)
+	}
+	// Without a run id only seq can be used
+	replay, _ = a.ResumeStream(id, 0, 2)
+	if replay.Reset || len(replay.Events) != 1 {
+		t.Errorf("resume without a run id = %+v, want just seq 3", replay)
+	}
+	replay, _ = a.ResumeStream(id, 0, 4)
+	if !replay.Reset || len(replay.Events) != 3 {
+		t.Errorf("resume past the last seq = %+v, want a reset", replay)
+	}
+}
+
+func TestStreamEventsEmittedInSeqOrder(t *testing.T) {
+	a := newTestApp(t)
+	events := recordEvents(a)
+	_, stream := a.startStream("project:core:build")
+
+	const emitters, perEmitter = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < emitters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perEmitter; j++ {
+				stream.emit(a.ctx, "devkit:project:stream", map[string]interface{}{"line": "output"})
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := events.named("devkit:project:stream")
+	if len(got) != emitters*perEmitter {
+		t.Fatalf("emitted %d events, want %d", len(got), emitters*perEmitter)
+	}
+	for i, ev := range got {
+		if seq := ev.payload["seq"]; seq != i+1 {
+			t.Fatalf("event %d reached the frontend with seq %v; events must arrive in seq order", i, seq)
+		}
+	}
+}